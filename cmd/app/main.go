@@ -8,23 +8,47 @@ import (
 	"os/signal"
 	"syscall"
 
-	"cloud.google.com/go/storage"
+	gcs "cloud.google.com/go/storage"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 	"github.com/tsel-ticketmaster/tm-notification/config"
+	adminapp_preview "github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/preview"
+	adminapp_ticket "github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/ticket"
+	adminapp_webhook "github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/webhook"
 	customerapp_customer "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/customer"
+	customerapp_device "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/device"
+	customerapp_inbox "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/inbox"
+	customerapp_preference "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/preference"
 	customerapp_ticket "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/ticket"
+	customerapp_whatsapp "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/whatsapp"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/jwt"
+	internalMiddleware "github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/applogger"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/kafka"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/middleware"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/monitoring"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/pdf"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/postgresql"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/realtime"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/redis"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/server"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sms"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/storage"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/validator"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/wallet"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 	"gopkg.in/gomail.v2"
 )
@@ -55,12 +79,48 @@ func main() {
 
 	mon.Start(ctx)
 
-	cloudstorage, err := storage.NewClient(context.Background(), option.WithCredentialsJSON(c.GCP.ServiceAccount))
-	if err != nil {
-		logger.WithError(err).Error()
+	var objectStore storage.ObjectStore
+	var localObjectStore *storage.LocalObjectStore
+	switch c.Storage.Driver {
+	case storage.DriverLocal:
+		localObjectStore = storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir:           c.Storage.Local.Dir,
+			KeyPrefix:     c.Storage.KeyPrefix,
+			PublicBaseURL: c.Storage.PublicBaseURL,
+			Secret:        c.Crypto.Secret,
+		})
+		objectStore = localObjectStore
+	default:
+		cloudstorage, err := gcs.NewClient(context.Background(), option.WithCredentialsJSON(c.GCP.ServiceAccount))
+		if err != nil {
+			logger.WithError(err).Error()
+		}
+		objectStore = storage.NewGCSObjectStore(storage.GCSObjectStoreProperty{
+			Client:        cloudstorage,
+			Bucket:        c.Storage.Bucket,
+			KeyPrefix:     c.Storage.KeyPrefix,
+			PublicBaseURL: c.Storage.PublicBaseURL,
+		})
 	}
 
-	_ = validator.Get()
+	validate := validator.Get()
+
+	rc := redis.GetClient()
+
+	jsonWebToken := jwt.NewJSONWebToken(c.JWT.PrivateKey, c.JWT.PublicKey)
+	sessionStore := session.NewRedisSessionStore(logger, rc)
+	adminSessionMiddleware := internalMiddleware.NewAdminSessionMiddleware(jsonWebToken, sessionStore)
+	customerSessionMiddleware := internalMiddleware.NewCustomerSessionMiddleware(jsonWebToken, sessionStore)
+
+	realtimeHub := realtime.NewRedisHub(realtime.RedisHubProperty{
+		Logger:                 logger,
+		Client:                 rc,
+		Prefix:                 "tm-notification:realtime:",
+		MaxSubscriptionsPerKey: c.Realtime.MaxConnectionsPerCustomer,
+		BufferSize:             c.Realtime.BufferSize,
+	})
+
+	db := postgresql.GetDatabase()
 
 	gomailDialer := gomail.NewDialer(
 		c.Mailer.SMTP.Host, c.Mailer.SMTP.Port,
@@ -68,6 +128,33 @@ func main() {
 	)
 	gomailAdapter := mailer.NewGomailAdapter(logger, c.Mailer.Sender, gomailDialer, true)
 
+	smsSender := sms.NewHTTPSender(sms.HTTPSenderProperty{
+		Logger:        logger,
+		Endpoint:      c.SMS.Endpoint,
+		APIKey:        c.SMS.APIKey,
+		DefaultSender: c.SMS.Sender,
+		Timeout:       c.SMS.Timeout,
+	}, c.SMS.Active)
+
+	whatsAppSender := whatsapp.NewCloudAPISender(whatsapp.CloudAPISenderProperty{
+		Logger:        logger,
+		Endpoint:      c.WhatsApp.Endpoint,
+		PhoneNumberID: c.WhatsApp.PhoneNumberID,
+		AccessToken:   c.WhatsApp.AccessToken,
+		Timeout:       c.WhatsApp.Timeout,
+	}, c.WhatsApp.Active)
+
+	mailtemplate.SetLocation(c.Application.Location)
+	if c.MailTemplate.Dir != "" {
+		mailTemplateWatcher := mailtemplate.NewWatcher(mailtemplate.WatcherProperty{
+			Logger:   logger,
+			Source:   os.DirFS(c.MailTemplate.Dir),
+			Interval: c.MailTemplate.ReloadInterval,
+		})
+		mailTemplateWatcher.Start()
+		defer mailTemplateWatcher.Close()
+	}
+
 	router := mux.NewRouter()
 	router.Use(
 		otelmux.Middleware(c.Application.Name),
@@ -75,15 +162,196 @@ func main() {
 		middleware.NewHTTPRequestLogger(logger, c.Application.Debug).Middleware,
 	)
 	router.HandleFunc("/tm-notification", healthCheck).Methods(http.MethodGet)
+	if localObjectStore != nil {
+		router.PathPrefix("/tm-notification/files/").Handler(http.StripPrefix("/tm-notification/files", localObjectStore))
+	}
+
+	var chromePool *pdf.ChromePool
+	var pdfRenderer pdf.TicketRenderer
+	switch c.PDF.Renderer {
+	case pdf.RendererNative:
+		pdfRenderer = pdf.NewNativeTicketRenderer()
+	default:
+		chromePool = pdf.NewChromePool(pdf.ChromePoolProperty{
+			Logger:              logger,
+			Size:                c.PDF.Chrome.PoolSize,
+			RenderTimeout:       c.PDF.Chrome.RenderTimeout,
+			HealthCheckInterval: c.PDF.Chrome.HealthCheckInterval,
+		})
+		pdfRenderer = pdf.NewHTMLTicketRenderer(pdf.NewChromeRenderer(chromePool))
+	}
+
+	var ticketSigner ticketcode.Signer
+	switch c.Ticket.Signer {
+	case ticketcode.SignerJWT:
+		ticketSigner = ticketcode.NewJWTSigner(c.Application.Name, jsonWebToken)
+	default:
+		ticketSigner = ticketcode.NewHMACSigner(c.Crypto.Secret)
+	}
+
+	var applePass wallet.PassGenerator
+	if c.Wallet.Apple.PassTypeIdentifier != "" {
+		generator, err := wallet.NewApplePassGenerator(wallet.ApplePassProperty{
+			PassTypeIdentifier: c.Wallet.Apple.PassTypeIdentifier,
+			TeamIdentifier:     c.Wallet.Apple.TeamIdentifier,
+			OrganizationName:   c.Wallet.Apple.OrganizationName,
+			Certificate:        c.Wallet.Apple.Certificate,
+			PrivateKey:         c.Wallet.Apple.PrivateKey,
+			WWDRCertificate:    c.Wallet.Apple.WWDRCertificate,
+		})
+		if err != nil {
+			logger.WithError(err).Error("apple wallet pass is disabled")
+		}
+		applePass = generator
+	}
+
+	var googleWallet wallet.SaveLinkGenerator
+	if c.Wallet.Google.IssuerID != "" {
+		serviceAccount := c.Wallet.Google.ServiceAccount
+		if len(serviceAccount) == 0 {
+			serviceAccount = c.GCP.ServiceAccount
+		}
+		generator, err := wallet.NewGoogleWalletLinkGenerator(wallet.GoogleWalletProperty{
+			IssuerID:       c.Wallet.Google.IssuerID,
+			IssuerName:     c.Wallet.Google.IssuerName,
+			ServiceAccount: serviceAccount,
+			Origins:        c.Wallet.Google.Origins,
+		})
+		if err != nil {
+			logger.WithError(err).Error("google wallet link is disabled")
+		}
+		googleWallet = generator
+	}
+
+	pushSenders := push.PlatformSender{}
+	if c.Push.FCM.ProjectID != "" {
+		serviceAccount := c.Push.FCM.ServiceAccount
+		if len(serviceAccount) == 0 {
+			serviceAccount = c.GCP.ServiceAccount
+		}
+		credentials, err := google.CredentialsFromJSON(context.Background(), serviceAccount, push.FCMScope)
+		if err != nil {
+			logger.WithError(err).Error("fcm push notification is disabled")
+		} else {
+			fcmSender := push.NewFCMSender(push.FCMSenderProperty{
+				Endpoint:    c.Push.FCM.Endpoint,
+				ProjectID:   c.Push.FCM.ProjectID,
+				TokenSource: credentials.TokenSource,
+				Timeout:     c.Push.Timeout,
+			})
+			pushSenders[push.PlatformAndroid] = fcmSender
+			pushSenders[push.PlatformWeb] = fcmSender
+		}
+	}
+	if c.Push.APNs.KeyID != "" {
+		apnsSender, err := push.NewAPNsSender(push.APNsSenderProperty{
+			Endpoint:   c.Push.APNs.Endpoint,
+			KeyID:      c.Push.APNs.KeyID,
+			TeamID:     c.Push.APNs.TeamID,
+			PrivateKey: c.Push.APNs.PrivateKey,
+			Topic:      c.Push.APNs.Topic,
+			Timeout:    c.Push.Timeout,
+		})
+		if err != nil {
+			logger.WithError(err).Error("apns push notification is disabled")
+		} else {
+			pushSenders[push.PlatformIOS] = apnsSender
+		}
+	}
+	var pushSender push.Sender = pushSenders
+	if len(pushSenders) == 0 {
+		pushSender = push.NewFakeSender(logger)
+	}
 
 	// admin's app
+	adminappPreviewUseCase := adminapp_preview.NewPreviewUseCase(adminapp_preview.PreviewUseCaseProperty{
+		AppName:     AdminApp,
+		Logger:      logger,
+		PDFRenderer: pdfRenderer,
+	})
+	adminapp_preview.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappPreviewUseCase)
+
+	adminappTicketUseCase := adminapp_ticket.NewTicketUseCase(adminapp_ticket.TicketUseCaseProperty{
+		AppName:                AdminApp,
+		Logger:                 logger,
+		TicketSigner:           ticketSigner,
+		IssuedTicketRepository: adminapp_ticket.NewIssuedTicketRepository(logger, db),
+	})
+	adminapp_ticket.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappTicketUseCase)
+
+	adminappWebhookUseCase := adminapp_webhook.NewWebhookUseCase(adminapp_webhook.WebhookUseCaseProperty{
+		AppName:            AdminApp,
+		Logger:             logger,
+		EndpointRepository: adminapp_webhook.NewEndpointRepository(logger, db),
+		DeliveryRepository: adminapp_webhook.NewDeliveryRepository(logger, db),
+		Client: webhook.NewHTTPClient(webhook.HTTPClientProperty{
+			Logger:    logger,
+			Timeout:   c.Webhook.Timeout,
+			UserAgent: "TicketMaster-Webhook/1.0",
+		}),
+		Timeout:      c.Webhook.Timeout,
+		MaxAttempts:  c.Webhook.MaxAttempts,
+		Backoff:      c.Webhook.Backoff,
+		MaxBackoff:   c.Webhook.MaxBackoff,
+		DisableAfter: c.Webhook.DisableAfter,
+		PollInterval: c.Webhook.PollInterval,
+	})
+	adminapp_webhook.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappWebhookUseCase)
+	adminappWebhookUseCase.Start()
 
 	// customer's app
+	customerappInboxUseCase := customerapp_inbox.NewInboxUseCase(customerapp_inbox.InboxUseCaseProperty{
+		AppName:                CustomerApp,
+		Logger:                 logger,
+		NotificationRepository: customerapp_inbox.NewNotificationRepository(logger, db),
+		Hub:                    realtimeHub,
+	})
+	customerapp_inbox.InitHTTPHandler(router, customerSessionMiddleware, validate, customerappInboxUseCase)
+	customerapp_inbox.InitWebSocketHandler(router, logger, customerSessionMiddleware, c.CORS.AllowedOrigins, customerappInboxUseCase)
+
+	customerappDeviceUseCase := customerapp_device.NewDeviceUseCase(customerapp_device.DeviceUseCaseProperty{
+		AppName:          CustomerApp,
+		Logger:           logger,
+		DeviceRepository: customerapp_device.NewDeviceRepository(logger, db),
+		PushSender:       pushSender,
+	})
+	customerapp_device.InitHTTPHandler(router, customerSessionMiddleware, validate, customerappDeviceUseCase)
+
+	customerappWhatsAppUseCase := customerapp_whatsapp.NewWhatsAppUseCase(customerapp_whatsapp.WhatsAppUseCaseProperty{
+		AppName:           CustomerApp,
+		Logger:            logger,
+		MessageRepository: customerapp_whatsapp.NewMessageRepository(logger, db),
+		Sender:            whatsAppSender,
+		AppSecret:         c.WhatsApp.AppSecret,
+		VerifyToken:       c.WhatsApp.VerifyToken,
+	})
+	customerapp_whatsapp.InitHTTPHandler(router, customerappWhatsAppUseCase)
+
+	customerappPreferenceUseCase := customerapp_preference.NewPreferenceUseCase(customerapp_preference.PreferenceUseCaseProperty{
+		AppName:              CustomerApp,
+		Logger:               logger,
+		PreferenceRepository: customerapp_preference.NewPreferenceRepository(logger, db),
+	})
+	customerapp_preference.InitHTTPHandler(router, customerSessionMiddleware, validate, customerappPreferenceUseCase)
+
+	notificationDispatcher := notification.NewDispatcher(notification.DispatcherProperty{
+		Logger: logger,
+		Channels: []notification.Channel{
+			notification.NewEmailChannel(gomailAdapter, c.Mailer.Sender),
+			notification.NewSMSChannel(smsSender, c.SMS.CallingCode),
+			notification.NewPushChannel(customerappDeviceUseCase),
+			notification.NewWhatsAppChannel(customerappWhatsAppUseCase, c.SMS.CallingCode),
+			notification.NewInboxChannel(customerappInboxUseCase),
+		},
+		Routes:           c.Notification.Routes,
+		RequiredChannels: c.Notification.RequiredChannels,
+		PreferenceStore:  customerappPreferenceUseCase,
+	})
+
 	customerappCustomerUseCase := customerapp_customer.NewCustomerUseCase(customerapp_customer.CustomerUseCaseProperty{
-		AppName:     CustomerApp,
-		Logger:      logger,
-		EmailSender: c.Mailer.Sender,
-		Mailer:      gomailAdapter,
+		AppName:    CustomerApp,
+		Logger:     logger,
+		Dispatcher: notificationDispatcher,
 	})
 	customerSignUpSubscriber := pubsub.SubscriberFromConfluentKafkaConsumer(pubsub.ConfluentKafkaConsumerProperty{
 		Logger: logger,
@@ -96,12 +364,26 @@ func main() {
 	customerSignUpSubscriber.Subscribe()
 
 	customerappTicketUseCase := customerapp_ticket.NewTicketUseCase(customerapp_ticket.TicketUseCaseProperty{
-		AppName:      CustomerApp,
-		Logger:       logger,
-		EmailSender:  c.Mailer.Sender,
-		Mailer:       gomailAdapter,
-		CloudStorage: cloudstorage,
+		AppName:                CustomerApp,
+		Logger:                 logger,
+		Dispatcher:             notificationDispatcher,
+		ObjectStore:            objectStore,
+		IssuedTicketRepository: customerapp_ticket.NewIssuedTicketRepository(logger, db),
+		LinkExpiry:             c.Ticket.LinkExpiry,
+		PDFRenderer:            pdfRenderer,
+		TicketSigner:           ticketSigner,
+		Barcode:                c.Ticket.Barcode,
+		ApplePass:              applePass,
+		GoogleWallet:           googleWallet,
+		ShowDuration:           c.Ticket.ShowDuration,
+		Reminder:               c.Ticket.Reminder,
+		OrderWindow:            c.Ticket.OrderWindow,
+		PendingOrderRepository: customerapp_ticket.NewPendingOrderRepository(logger, db),
+		WhatsAppTemplate:       c.WhatsApp.TicketTemplate,
+		WebhookDispatcher:      adminappWebhookUseCase,
 	})
+	customerappTicketUseCase.Start()
+	customerapp_ticket.InitHTTPHandler(router, customerSessionMiddleware, customerappTicketUseCase)
 	customerappAqcuireTicketSubscriber := pubsub.SubscriberFromConfluentKafkaConsumer(pubsub.ConfluentKafkaConsumerProperty{
 		Logger: logger,
 		Topic:  "acquire-ticket",
@@ -140,9 +422,19 @@ func main() {
 	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
 	<-sigterm
 
+	// closing the hub ends the open streams, the shutdown would wait for them.
+	realtimeHub.Close()
 	srv.Shutdown(ctx)
 	customerappAqcuireTicketSubscriber.Close()
+	customerappTicketUseCase.Close()
+	adminappWebhookUseCase.Close()
 	customerSignUpSubscriber.Close()
+	if chromePool != nil {
+		chromePool.Close()
+	}
+	if db != nil {
+		db.Close()
+	}
 	mon.Stop(ctx)
 }
 
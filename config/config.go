@@ -23,6 +23,7 @@ type Config struct {
 		Environment string
 		Debug       bool
 		Timeout     time.Duration
+		Location    *time.Location
 		TMUser      struct {
 			BaseURL string
 		}
@@ -84,6 +85,103 @@ type Config struct {
 		}
 		Sender string
 	}
+	SMS struct {
+		Active      bool
+		Endpoint    string
+		APIKey      string
+		Sender      string
+		CallingCode string
+		Timeout     time.Duration
+	}
+	Push struct {
+		FCM struct {
+			Endpoint       string
+			ProjectID      string
+			ServiceAccount []byte
+		}
+		APNs struct {
+			Endpoint   string
+			KeyID      string
+			TeamID     string
+			PrivateKey []byte
+			Topic      string
+		}
+		Timeout time.Duration
+	}
+	WhatsApp struct {
+		Active         bool
+		Endpoint       string
+		PhoneNumberID  string
+		AccessToken    string
+		AppSecret      string
+		VerifyToken    string
+		TicketTemplate string
+		Timeout        time.Duration
+	}
+	MailTemplate struct {
+		Dir            string
+		ReloadInterval time.Duration
+	}
+	PDF struct {
+		Renderer string
+		Chrome   struct {
+			PoolSize            int
+			RenderTimeout       time.Duration
+			HealthCheckInterval time.Duration
+		}
+	}
+	Storage struct {
+		Driver        string
+		Bucket        string
+		KeyPrefix     string
+		PublicBaseURL string
+		Local         struct {
+			Dir string
+		}
+	}
+	Wallet struct {
+		Apple struct {
+			PassTypeIdentifier string
+			TeamIdentifier     string
+			OrganizationName   string
+			Certificate        []byte
+			PrivateKey         []byte
+			WWDRCertificate    []byte
+		}
+		Google struct {
+			IssuerID       string
+			IssuerName     string
+			ServiceAccount []byte
+			Origins        []string
+		}
+	}
+	Ticket struct {
+		Signer       string
+		Barcode      bool
+		LinkExpiry   time.Duration
+		ShowDuration time.Duration
+		Reminder     time.Duration
+		OrderWindow  time.Duration
+	}
+	Webhook struct {
+		Timeout      time.Duration
+		MaxAttempts  int
+		Backoff      time.Duration
+		MaxBackoff   time.Duration
+		DisableAfter int
+		PollInterval time.Duration
+	}
+	Realtime struct {
+		// MaxConnectionsPerCustomer is enforced by each replica on its own,
+		// a customer has up to this many connections on every replica.
+		MaxConnectionsPerCustomer int
+		BufferSize                int
+	}
+	Notification struct {
+		// Routes are the channels of each type of notification.
+		Routes           map[string][]string
+		RequiredChannels []string
+	}
 }
 
 func (cfg *Config) application() {
@@ -95,6 +193,12 @@ func (cfg *Config) application() {
 	timeoutInSec, _ := strconv.Atoi(os.Getenv("APP_TIMEOUT"))
 	cfg.Application.Timeout = time.Duration(timeoutInSec) * time.Second
 
+	location, err := time.LoadLocation(os.Getenv("APP_TIMEZONE"))
+	if err != nil {
+		location = time.Local
+	}
+	cfg.Application.Location = location
+
 	cfg.Application.TMUser.BaseURL = os.Getenv("APP_TMUSER_BASE_URL")
 }
 
@@ -169,6 +273,157 @@ func (cfg *Config) mailer() {
 	cfg.Mailer.SMTP.Password = os.Getenv("MAILER_SMTP_PASSWORD")
 }
 
+func (cfg *Config) sms() {
+	cfg.SMS.Active, _ = strconv.ParseBool(os.Getenv("SMS_ACTIVE"))
+	cfg.SMS.Endpoint = os.Getenv("SMS_ENDPOINT")
+	cfg.SMS.APIKey = os.Getenv("SMS_API_KEY")
+	cfg.SMS.Sender = os.Getenv("SMS_SENDER")
+	cfg.SMS.CallingCode = os.Getenv("SMS_CALLING_CODE")
+
+	timeoutInSec, _ := strconv.Atoi(os.Getenv("SMS_TIMEOUT"))
+	cfg.SMS.Timeout = time.Duration(timeoutInSec) * time.Second
+}
+
+func (cfg *Config) push() {
+	cfg.Push.FCM.Endpoint = os.Getenv("PUSH_FCM_ENDPOINT")
+	cfg.Push.FCM.ProjectID = os.Getenv("PUSH_FCM_PROJECT_ID")
+	cfg.Push.FCM.ServiceAccount = []byte(os.Getenv("PUSH_FCM_SERVICE_ACCOUNT"))
+
+	cfg.Push.APNs.Endpoint = os.Getenv("PUSH_APNS_ENDPOINT")
+	cfg.Push.APNs.KeyID = os.Getenv("PUSH_APNS_KEY_ID")
+	cfg.Push.APNs.TeamID = os.Getenv("PUSH_APNS_TEAM_ID")
+	cfg.Push.APNs.PrivateKey = []byte(os.Getenv("PUSH_APNS_PRIVATE_KEY"))
+	cfg.Push.APNs.Topic = os.Getenv("PUSH_APNS_TOPIC")
+
+	timeoutInSec, _ := strconv.Atoi(os.Getenv("PUSH_TIMEOUT"))
+	cfg.Push.Timeout = time.Duration(timeoutInSec) * time.Second
+}
+
+func (cfg *Config) whatsApp() {
+	cfg.WhatsApp.Active, _ = strconv.ParseBool(os.Getenv("WHATSAPP_ACTIVE"))
+	cfg.WhatsApp.Endpoint = os.Getenv("WHATSAPP_ENDPOINT")
+	cfg.WhatsApp.PhoneNumberID = os.Getenv("WHATSAPP_PHONE_NUMBER_ID")
+	cfg.WhatsApp.AccessToken = os.Getenv("WHATSAPP_ACCESS_TOKEN")
+	cfg.WhatsApp.AppSecret = os.Getenv("WHATSAPP_APP_SECRET")
+	cfg.WhatsApp.VerifyToken = os.Getenv("WHATSAPP_VERIFY_TOKEN")
+	cfg.WhatsApp.TicketTemplate = os.Getenv("WHATSAPP_TICKET_TEMPLATE")
+
+	timeoutInSec, _ := strconv.Atoi(os.Getenv("WHATSAPP_TIMEOUT"))
+	cfg.WhatsApp.Timeout = time.Duration(timeoutInSec) * time.Second
+}
+
+func (cfg *Config) mailTemplate() {
+	cfg.MailTemplate.Dir = os.Getenv("MAILTEMPLATE_DIR")
+
+	reloadIntervalInSec, _ := strconv.Atoi(os.Getenv("MAILTEMPLATE_RELOAD_INTERVAL"))
+	cfg.MailTemplate.ReloadInterval = time.Duration(reloadIntervalInSec) * time.Second
+}
+
+func (cfg *Config) pdf() {
+	cfg.PDF.Renderer = os.Getenv("PDF_RENDERER")
+	cfg.PDF.Chrome.PoolSize, _ = strconv.Atoi(os.Getenv("PDF_CHROME_POOL_SIZE"))
+
+	renderTimeoutInSec, _ := strconv.Atoi(os.Getenv("PDF_CHROME_RENDER_TIMEOUT"))
+	cfg.PDF.Chrome.RenderTimeout = time.Duration(renderTimeoutInSec) * time.Second
+
+	healthCheckIntervalInSec, _ := strconv.Atoi(os.Getenv("PDF_CHROME_HEALTH_CHECK_INTERVAL"))
+	cfg.PDF.Chrome.HealthCheckInterval = time.Duration(healthCheckIntervalInSec) * time.Second
+}
+
+func (cfg *Config) storage() {
+	cfg.Storage.Driver = os.Getenv("STORAGE_DRIVER")
+	cfg.Storage.Bucket = os.Getenv("STORAGE_BUCKET")
+	cfg.Storage.KeyPrefix = os.Getenv("STORAGE_KEY_PREFIX")
+	cfg.Storage.PublicBaseURL = os.Getenv("STORAGE_PUBLIC_BASE_URL")
+	cfg.Storage.Local.Dir = os.Getenv("STORAGE_LOCAL_DIR")
+}
+
+func (cfg *Config) wallet() {
+	cfg.Wallet.Apple.PassTypeIdentifier = os.Getenv("WALLET_APPLE_PASS_TYPE_IDENTIFIER")
+	cfg.Wallet.Apple.TeamIdentifier = os.Getenv("WALLET_APPLE_TEAM_IDENTIFIER")
+	cfg.Wallet.Apple.OrganizationName = os.Getenv("WALLET_APPLE_ORGANIZATION_NAME")
+	cfg.Wallet.Apple.Certificate = []byte(os.Getenv("WALLET_APPLE_CERTIFICATE"))
+	cfg.Wallet.Apple.PrivateKey = []byte(os.Getenv("WALLET_APPLE_PRIVATE_KEY"))
+	cfg.Wallet.Apple.WWDRCertificate = []byte(os.Getenv("WALLET_APPLE_WWDR_CERTIFICATE"))
+
+	cfg.Wallet.Google.IssuerID = os.Getenv("WALLET_GOOGLE_ISSUER_ID")
+	cfg.Wallet.Google.IssuerName = os.Getenv("WALLET_GOOGLE_ISSUER_NAME")
+	cfg.Wallet.Google.ServiceAccount = []byte(os.Getenv("WALLET_GOOGLE_SERVICE_ACCOUNT"))
+	if origins := os.Getenv("WALLET_GOOGLE_ORIGINS"); origins != "" {
+		cfg.Wallet.Google.Origins = strings.Split(origins, ",")
+	}
+}
+
+func (cfg *Config) ticket() {
+	cfg.Ticket.Signer = os.Getenv("TICKET_SIGNER")
+	cfg.Ticket.Barcode, _ = strconv.ParseBool(os.Getenv("TICKET_BARCODE"))
+
+	linkExpiryInSec, _ := strconv.Atoi(os.Getenv("TICKET_LINK_EXPIRY"))
+	cfg.Ticket.LinkExpiry = time.Duration(linkExpiryInSec) * time.Second
+
+	showDurationInSec, _ := strconv.Atoi(os.Getenv("TICKET_SHOW_DURATION"))
+	cfg.Ticket.ShowDuration = time.Duration(showDurationInSec) * time.Second
+
+	reminderInSec, _ := strconv.Atoi(os.Getenv("TICKET_REMINDER"))
+	cfg.Ticket.Reminder = time.Duration(reminderInSec) * time.Second
+
+	orderWindowInSec, _ := strconv.Atoi(os.Getenv("TICKET_ORDER_WINDOW"))
+	cfg.Ticket.OrderWindow = time.Duration(orderWindowInSec) * time.Second
+}
+
+func (cfg *Config) webhook() {
+	timeoutInSec, _ := strconv.Atoi(os.Getenv("WEBHOOK_TIMEOUT"))
+	cfg.Webhook.Timeout = time.Duration(timeoutInSec) * time.Second
+
+	cfg.Webhook.MaxAttempts, _ = strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+
+	backoffInSec, _ := strconv.Atoi(os.Getenv("WEBHOOK_BACKOFF"))
+	cfg.Webhook.Backoff = time.Duration(backoffInSec) * time.Second
+
+	maxBackoffInSec, _ := strconv.Atoi(os.Getenv("WEBHOOK_MAX_BACKOFF"))
+	cfg.Webhook.MaxBackoff = time.Duration(maxBackoffInSec) * time.Second
+
+	cfg.Webhook.DisableAfter, _ = strconv.Atoi(os.Getenv("WEBHOOK_DISABLE_AFTER"))
+
+	pollIntervalInSec, _ := strconv.Atoi(os.Getenv("WEBHOOK_POLL_INTERVAL"))
+	cfg.Webhook.PollInterval = time.Duration(pollIntervalInSec) * time.Second
+}
+
+func (cfg *Config) realtime() {
+	cfg.Realtime.MaxConnectionsPerCustomer, _ = strconv.Atoi(os.Getenv("REALTIME_MAX_CONNECTIONS_PER_CUSTOMER"))
+	cfg.Realtime.BufferSize, _ = strconv.Atoi(os.Getenv("REALTIME_BUFFER_SIZE"))
+}
+
+// notification reads the routes as `type:channel,channel;type:channel`, e.g.
+// `sign_up:email,sms;acquired_ticket:email,push`.
+func (cfg *Config) notification() {
+	cfg.Notification.Routes = make(map[string][]string)
+	for _, route := range strings.Split(os.Getenv("NOTIFICATION_ROUTES"), ";") {
+		notificationType, channels, ok := strings.Cut(strings.TrimSpace(route), ":")
+		notificationType = strings.TrimSpace(notificationType)
+		if !ok || notificationType == "" {
+			continue
+		}
+		cfg.Notification.Routes[notificationType] = splitChannels(channels)
+	}
+
+	if requiredChannels := os.Getenv("NOTIFICATION_REQUIRED_CHANNELS"); requiredChannels != "" {
+		cfg.Notification.RequiredChannels = splitChannels(requiredChannels)
+	}
+}
+
+// splitChannels splits a comma separated list of channels, e.g. `email, sms`.
+func splitChannels(s string) []string {
+	channels := make([]string, 0)
+	for _, channel := range strings.Split(s, ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+
+	return channels
+}
+
 func load() *Config {
 	cfg := new(Config)
 	cfg.application()
@@ -181,6 +436,17 @@ func load() *Config {
 	cfg.kafka()
 	cfg.gcp()
 	cfg.mailer()
+	cfg.sms()
+	cfg.push()
+	cfg.whatsApp()
+	cfg.mailTemplate()
+	cfg.pdf()
+	cfg.storage()
+	cfg.wallet()
+	cfg.ticket()
+	cfg.webhook()
+	cfg.realtime()
+	cfg.notification()
 	return cfg
 }
 
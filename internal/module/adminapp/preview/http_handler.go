@@ -0,0 +1,113 @@
+package preview
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	PreviewUseCase    PreviewUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, previewUseCase PreviewUseCase) {
+	handler := &HTTPHandler{
+		SessionMiddleware: adminSession,
+		Validate:          validate,
+		PreviewUseCase:    previewUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/admin/mail-templates", handler.SessionMiddleware.Verify(handler.GetTemplates)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/admin/mail-templates/{name}/preview", handler.SessionMiddleware.Verify(handler.Preview)).Methods(http.MethodPost)
+}
+
+func (handler HTTPHandler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.PreviewUseCase.GetTemplates(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of mail templates",
+		Data:    resp,
+	})
+}
+
+// Preview renders the template with the posted sample data. The `format` query
+// returns the raw `html`, `text` or `pdf` instead of the JSON envelope.
+func (handler HTTPHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	if err := handler.Validate.StructCtx(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	req.Name = mux.Vars(r)["name"]
+
+	resp, err := handler.PreviewUseCase.Preview(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case FormatHTML:
+		writeRaw(w, "text/html; charset=utf-8", []byte(resp.HTML))
+	case FormatText:
+		writeRaw(w, "text/plain; charset=utf-8", []byte(resp.Text))
+	case FormatPDF:
+		if resp.PDF == nil {
+			response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+				Status:  status.BAD_REQUEST,
+				Message: "the template has no pdf preview",
+			})
+			return
+		}
+		writeRaw(w, "application/pdf", resp.PDF)
+	default:
+		response.JSON(w, http.StatusOK, response.RESTEnvelope{
+			Status:  status.OK,
+			Message: "preview of mail template",
+			Data:    resp,
+		})
+	}
+}
+
+func writeRaw(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,25 @@
+package preview
+
+import "encoding/json"
+
+// Output format of the preview.
+const (
+	FormatJSON = "json"
+	FormatHTML = "html"
+	FormatText = "text"
+	FormatPDF  = "pdf"
+)
+
+type PreviewRequest struct {
+	Name   string          `json:"-"`
+	Locale string          `json:"locale"`
+	Data   json.RawMessage `json:"data" validate:"required"`
+}
+
+type PreviewResponse struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+	PDF     []byte `json:"pdf,omitempty"`
+}
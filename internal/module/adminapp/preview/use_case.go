@@ -0,0 +1,78 @@
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/pdf"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type PreviewUseCase interface {
+	GetTemplates(ctx context.Context) ([]string, error)
+	Preview(ctx context.Context, req PreviewRequest) (PreviewResponse, error)
+}
+
+type PreviewUseCaseProperty struct {
+	AppName     string
+	Logger      *logrus.Logger
+	PDFRenderer pdf.TicketRenderer
+}
+
+type previewUseCase struct {
+	appName     string
+	logger      *logrus.Logger
+	pdfRenderer pdf.TicketRenderer
+}
+
+func NewPreviewUseCase(props PreviewUseCaseProperty) PreviewUseCase {
+	return &previewUseCase{
+		appName:     props.AppName,
+		logger:      props.Logger,
+		pdfRenderer: props.PDFRenderer,
+	}
+}
+
+// GetTemplates implements PreviewUseCase.
+func (u *previewUseCase) GetTemplates(ctx context.Context) ([]string, error) {
+	return mailtemplate.Names(), nil
+}
+
+// Preview implements PreviewUseCase.
+func (u *previewUseCase) Preview(ctx context.Context, req PreviewRequest) (PreviewResponse, error) {
+	mt, err := mailtemplate.New(req.Name, req.Locale)
+	if err != nil {
+		return PreviewResponse{}, errors.New(http.StatusNotFound, status.NOT_FOUND, err.Error())
+	}
+
+	data := mailtemplate.NewData(req.Name)
+	if err := json.Unmarshal(req.Data, data); err != nil {
+		return PreviewResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid sample data")
+	}
+
+	buff, err := mt.Populate(data)
+	if err != nil {
+		return PreviewResponse{}, errors.New(http.StatusUnprocessableEntity, status.UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	resp := PreviewResponse{
+		Name:    req.Name,
+		Subject: mt.Subject(),
+		HTML:    buff.String(),
+		Text:    mailtemplate.PlainText(buff.String()),
+	}
+
+	if req.Name == mailtemplate.Ticket {
+		resp.PDF, err = u.pdfRenderer.RenderTicket(ctx, req.Locale, data.(*mailtemplate.TicketData))
+		if err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return PreviewResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "failed to generate the pdf")
+		}
+	}
+
+	return resp, nil
+}
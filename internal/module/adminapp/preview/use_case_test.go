@@ -0,0 +1,100 @@
+package preview_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/preview"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/pdf"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+func newPreviewUseCase() preview.PreviewUseCase {
+	return preview.NewPreviewUseCase(preview.PreviewUseCaseProperty{
+		AppName:     "tm-notification",
+		Logger:      logrus.New(),
+		PDFRenderer: pdf.NewNativeTicketRenderer(),
+	})
+}
+
+// loadOverrides registers the override templates until the end of the test.
+func loadOverrides(t *testing.T, files map[string]string) {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	assert.NoError(t, mailtemplate.NewWatcher(mailtemplate.WatcherProperty{Source: os.DirFS(dir)}).Load())
+
+	t.Cleanup(func() {
+		mailtemplate.NewWatcher(mailtemplate.WatcherProperty{Source: os.DirFS(t.TempDir())}).Load()
+	})
+}
+
+func TestPreviewUseCase_Preview(t *testing.T) {
+	t.Run("preview a built-in template", func(t *testing.T) {
+		resp, err := newPreviewUseCase().Preview(context.Background(), preview.PreviewRequest{
+			Name:   mailtemplate.CustomerVerification,
+			Locale: "en",
+			Data:   json.RawMessage(`{"RecipientName":"John Doe","VerificationLink":"https://example.com/verify"}`),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, mailtemplate.CustomerVerification, resp.Name)
+		assert.Contains(t, resp.HTML, "<b>John Doe</b>")
+		assert.Contains(t, resp.Text, "John Doe")
+		assert.Empty(t, resp.PDF)
+	})
+
+	t.Run("preview an override-only template with the sample data", func(t *testing.T) {
+		loadOverrides(t, map[string]string{
+			"pages/en/event_reminder.html": `{{ template "base" . }}{{ define "content" }}<p>{{ .EventName }} starts soon</p>{{ end }}`,
+		})
+
+		resp, err := newPreviewUseCase().Preview(context.Background(), preview.PreviewRequest{
+			Name:   "event_reminder",
+			Locale: "en",
+			Data:   json.RawMessage(`{"EventName":"Java Jazz","UnsubscribeLink":"https://example.com/unsubscribe"}`),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "event_reminder", resp.Name)
+		assert.Contains(t, resp.HTML, "<p>Java Jazz starts soon</p>")
+	})
+
+	t.Run("return not found for an unknown template", func(t *testing.T) {
+		_, err := newPreviewUseCase().Preview(context.Background(), preview.PreviewRequest{
+			Name: "unknown",
+			Data: json.RawMessage(`{}`),
+		})
+		ae := errors.Destruct(err)
+		assert.Equal(t, http.StatusNotFound, ae.HTTPStatusCode)
+		assert.Equal(t, status.NOT_FOUND, ae.Status)
+	})
+
+	t.Run("return bad request for an invalid sample data", func(t *testing.T) {
+		_, err := newPreviewUseCase().Preview(context.Background(), preview.PreviewRequest{
+			Name: mailtemplate.CustomerVerification,
+			Data: json.RawMessage(`[]`),
+		})
+		ae := errors.Destruct(err)
+		assert.Equal(t, http.StatusBadRequest, ae.HTTPStatusCode)
+	})
+
+	t.Run("return unprocessable entity when the sample data breaks the contract", func(t *testing.T) {
+		_, err := newPreviewUseCase().Preview(context.Background(), preview.PreviewRequest{
+			Name: mailtemplate.CustomerVerification,
+			Data: json.RawMessage(`{"RecipientName":"John Doe"}`),
+		})
+		ae := errors.Destruct(err)
+		assert.Equal(t, http.StatusUnprocessableEntity, ae.HTTPStatusCode)
+		assert.Contains(t, ae.Message, "VerificationLink")
+	})
+}
@@ -0,0 +1,17 @@
+package ticket
+
+import "time"
+
+// IssuedTicket is the ticket issued to a customer, the verified code must name
+// one of them.
+type IssuedTicket struct {
+	Number     string
+	CustomerID int64
+	EventID    string
+	ShowID     string
+	Tier       string
+	EventName  string
+	ShowVenue  string
+	ShowTime   time.Time
+	IssuedAt   time.Time
+}
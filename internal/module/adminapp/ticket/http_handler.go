@@ -0,0 +1,67 @@
+package ticket
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	TicketUseCase     TicketUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, ticketUseCase TicketUseCase) {
+	handler := &HTTPHandler{
+		SessionMiddleware: adminSession,
+		Validate:          validate,
+		TicketUseCase:     ticketUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/tickets/verify", handler.SessionMiddleware.Verify(handler.Verify)).Methods(http.MethodPost)
+}
+
+// Verify validates a code scanned by the gate app and returns the ticket.
+func (handler HTTPHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req VerifyTicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	if err := handler.Validate.StructCtx(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := handler.TicketUseCase.Verify(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "ticket is valid",
+		Data:    resp,
+	})
+}
@@ -0,0 +1,18 @@
+package ticket
+
+import "time"
+
+type VerifyTicketRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type VerifyTicketResponse struct {
+	TicketNumber string    `json:"ticket_number"`
+	EventID      string    `json:"event_id"`
+	ShowID       string    `json:"show_id"`
+	CustomerID   int64     `json:"customer_id"`
+	Tier         string    `json:"tier"`
+	EventName    string    `json:"event_name"`
+	ShowVenue    string    `json:"show_venue"`
+	ShowTime     time.Time `json:"show_time"`
+}
@@ -0,0 +1,48 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type IssuedTicketRepository interface {
+	FindByNumber(ctx context.Context, number string) (IssuedTicket, error)
+}
+
+type issuedTicketRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewIssuedTicketRepository(logger *logrus.Logger, db *sql.DB) IssuedTicketRepository {
+	return &issuedTicketRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// FindByNumber implements IssuedTicketRepository.
+func (r *issuedTicketRepository) FindByNumber(ctx context.Context, number string) (IssuedTicket, error) {
+	query := `
+		SELECT number, customer_id, event_id, show_id, tier, event_name, show_venue, show_time, issued_at
+		FROM issued_ticket WHERE number = $1`
+
+	t := IssuedTicket{}
+	err := r.db.QueryRowContext(ctx, query, number).Scan(
+		&t.Number, &t.CustomerID, &t.EventID, &t.ShowID, &t.Tier, &t.EventName, &t.ShowVenue, &t.ShowTime, &t.IssuedAt,
+	)
+	if err == sql.ErrNoRows {
+		return t, errors.New(http.StatusNotFound, status.NOT_FOUND, "ticket is not found")
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return t, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return t, nil
+}
@@ -0,0 +1,69 @@
+package ticket
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+)
+
+type TicketUseCase interface {
+	Verify(ctx context.Context, req VerifyTicketRequest) (VerifyTicketResponse, error)
+}
+
+type TicketUseCaseProperty struct {
+	AppName                string
+	Logger                 *logrus.Logger
+	TicketSigner           ticketcode.Signer
+	IssuedTicketRepository IssuedTicketRepository
+}
+
+type ticketUseCase struct {
+	appName                string
+	logger                 *logrus.Logger
+	ticketSigner           ticketcode.Signer
+	issuedTicketRepository IssuedTicketRepository
+}
+
+func NewTicketUseCase(props TicketUseCaseProperty) TicketUseCase {
+	return &ticketUseCase{
+		appName:                props.AppName,
+		logger:                 props.Logger,
+		ticketSigner:           props.TicketSigner,
+		issuedTicketRepository: props.IssuedTicketRepository,
+	}
+}
+
+// Verify implements TicketUseCase. The scanned code must be signed with the
+// signing key and name an issued ticket of the same customer and show.
+func (u *ticketUseCase) Verify(ctx context.Context, req VerifyTicketRequest) (VerifyTicketResponse, error) {
+	p, err := u.ticketSigner.Verify(req.Code)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Warn()
+		return VerifyTicketResponse{}, errors.New(http.StatusUnprocessableEntity, status.UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	t, err := u.issuedTicketRepository.FindByNumber(ctx, p.TicketNumber)
+	if err != nil {
+		return VerifyTicketResponse{}, err
+	}
+
+	if t.CustomerID != p.CustomerID || t.ShowID != p.ShowID {
+		u.logger.WithContext(ctx).WithField("ticket_number", p.TicketNumber).Warn("ticket code does not match the issued ticket")
+		return VerifyTicketResponse{}, errors.New(http.StatusUnprocessableEntity, status.UNPROCESSABLE_ENTITY, ticketcode.ErrInvalidCode.Error())
+	}
+
+	return VerifyTicketResponse{
+		TicketNumber: t.Number,
+		EventID:      t.EventID,
+		ShowID:       t.ShowID,
+		CustomerID:   t.CustomerID,
+		Tier:         t.Tier,
+		EventName:    t.EventName,
+		ShowVenue:    t.ShowVenue,
+		ShowTime:     t.ShowTime,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package ticket_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/ticket"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/ticket/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/jwt"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+)
+
+var showTime = time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC)
+
+func newPayload() ticketcode.Payload {
+	return ticketcode.Payload{
+		TicketNumber: "TICKET-1",
+		ShowID:       "SHOW-1",
+		CustomerID:   42,
+		EventID:      "EVENT-1",
+		Tier:         "VIP",
+	}
+}
+
+func newIssuedTicket() ticket.IssuedTicket {
+	return ticket.IssuedTicket{
+		Number:     "TICKET-1",
+		CustomerID: 42,
+		EventID:    "EVENT-1",
+		ShowID:     "SHOW-1",
+		Tier:       "VIP",
+		EventName:  "Concert",
+		ShowVenue:  "GBK",
+		ShowTime:   showTime,
+	}
+}
+
+func newJSONWebToken(t *testing.T) *jwt.JSONWebToken {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	return jwt.NewJSONWebToken(
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}),
+	)
+}
+
+func TestTicketUseCase_Verify(t *testing.T) {
+	signer := ticketcode.NewHMACSigner("secret")
+
+	t.Run("return the issued ticket of a valid code", func(t *testing.T) {
+		code, err := signer.Sign(newPayload())
+		assert.NoError(t, err)
+
+		repositoryMock := &mocks.IssuedTicketRepository{}
+		repositoryMock.On("FindByNumber", mock.Anything, "TICKET-1").Return(newIssuedTicket(), nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			TicketSigner:           signer,
+			IssuedTicketRepository: repositoryMock,
+		})
+
+		resp, err := uc.Verify(context.Background(), ticket.VerifyTicketRequest{Code: code})
+		assert.NoError(t, err)
+		assert.Equal(t, ticket.VerifyTicketResponse{
+			TicketNumber: "TICKET-1",
+			EventID:      "EVENT-1",
+			ShowID:       "SHOW-1",
+			CustomerID:   42,
+			Tier:         "VIP",
+			EventName:    "Concert",
+			ShowVenue:    "GBK",
+			ShowTime:     showTime,
+		}, resp)
+	})
+
+	t.Run("reject a tampered code", func(t *testing.T) {
+		code, err := signer.Sign(newPayload())
+		assert.NoError(t, err)
+
+		forgedPayload := newPayload()
+		forgedPayload.Tier = "VVIP"
+		forged, err := ticketcode.NewHMACSigner("another").Sign(forgedPayload)
+		assert.NoError(t, err)
+		_, signature, _ := strings.Cut(code, ".")
+		encodedPayload, _, _ := strings.Cut(forged, ".")
+
+		repositoryMock := &mocks.IssuedTicketRepository{}
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			TicketSigner:           signer,
+			IssuedTicketRepository: repositoryMock,
+		})
+
+		_, err = uc.Verify(context.Background(), ticket.VerifyTicketRequest{Code: encodedPayload + "." + signature})
+		assert.Equal(t, http.StatusUnprocessableEntity, errors.Destruct(err).HTTPStatusCode)
+		repositoryMock.AssertNotCalled(t, "FindByNumber", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reject an expired code", func(t *testing.T) {
+		jsonWebToken := newJSONWebToken(t)
+		code, err := jsonWebToken.Sign(context.Background(), gojwt.MapClaims{
+			"sub": "TICKET-1",
+			"tn":  "TICKET-1",
+			"sid": "SHOW-1",
+			"cid": 42,
+			"typ": ticketcode.TicketTokenType,
+			"exp": time.Now().Add(-time.Minute).Unix(),
+		})
+		assert.NoError(t, err)
+
+		repositoryMock := &mocks.IssuedTicketRepository{}
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			TicketSigner:           ticketcode.NewJWTSigner("tm-notification", jsonWebToken),
+			IssuedTicketRepository: repositoryMock,
+		})
+
+		_, err = uc.Verify(context.Background(), ticket.VerifyTicketRequest{Code: code})
+		assert.True(t, errors.MatchStatus(err, status.UNPROCESSABLE_ENTITY))
+		repositoryMock.AssertNotCalled(t, "FindByNumber", mock.Anything, mock.Anything)
+	})
+
+	t.Run("return not found for an unknown ticket number", func(t *testing.T) {
+		payload := newPayload()
+		payload.TicketNumber = "TICKET-404"
+		code, err := signer.Sign(payload)
+		assert.NoError(t, err)
+
+		repositoryMock := &mocks.IssuedTicketRepository{}
+		repositoryMock.On("FindByNumber", mock.Anything, "TICKET-404").
+			Return(ticket.IssuedTicket{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "ticket is not found"))
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			TicketSigner:           signer,
+			IssuedTicketRepository: repositoryMock,
+		})
+
+		_, err = uc.Verify(context.Background(), ticket.VerifyTicketRequest{Code: code})
+		assert.True(t, errors.MatchStatus(err, status.NOT_FOUND))
+	})
+
+	t.Run("reject a code of another customer", func(t *testing.T) {
+		payload := newPayload()
+		payload.CustomerID = 43
+		code, err := signer.Sign(payload)
+		assert.NoError(t, err)
+
+		repositoryMock := &mocks.IssuedTicketRepository{}
+		repositoryMock.On("FindByNumber", mock.Anything, "TICKET-1").Return(newIssuedTicket(), nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			TicketSigner:           signer,
+			IssuedTicketRepository: repositoryMock,
+		})
+
+		_, err = uc.Verify(context.Background(), ticket.VerifyTicketRequest{Code: code})
+		assert.True(t, errors.MatchStatus(err, status.UNPROCESSABLE_ENTITY))
+	})
+}
@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type DeliveryRepository interface {
+	Save(ctx context.Context, d Delivery) (Delivery, error)
+	// ClaimDue returns the pending deliveries of the active endpoints which
+	// are due at now and postpones them by the lease, so concurrent workers do
+	// not attempt the same delivery.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+	Update(ctx context.Context, d Delivery) error
+	// FindByEndpointID returns the latest deliveries of the endpoint with an
+	// id lower than before, of every status when the status is empty.
+	FindByEndpointID(ctx context.Context, endpointID int64, deliveryStatus string, before int64, limit int) ([]Delivery, error)
+}
+
+type deliveryRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewDeliveryRepository(logger *logrus.Logger, db *sql.DB) DeliveryRepository {
+	return &deliveryRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+const deliveryColumns = `id, endpoint_id, event, payload, status, attempts, response_status, response_body, error, next_attempt_at, delivered_at, created_at, updated_at`
+
+func scanDelivery(s scanner) (Delivery, error) {
+	var d Delivery
+	err := s.Scan(
+		&d.ID, &d.EndpointID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.ResponseStatus,
+		&d.ResponseBody, &d.Error, &d.NextAttemptAt, &d.DeliveredAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	return d, err
+}
+
+// Save implements DeliveryRepository.
+func (r *deliveryRepository) Save(ctx context.Context, d Delivery) (Delivery, error) {
+	query := `
+		INSERT INTO webhook_delivery (endpoint_id, event, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + deliveryColumns
+
+	saved, err := scanDelivery(r.db.QueryRowContext(ctx, query, d.EndpointID, d.Event, d.Payload, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt, d.UpdatedAt))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Delivery{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return saved, nil
+}
+
+// ClaimDue implements DeliveryRepository.
+func (r *deliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error) {
+	query := `
+		UPDATE webhook_delivery SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT d.id FROM webhook_delivery d
+			JOIN webhook_endpoint e ON e.id = d.endpoint_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND e.active
+			ORDER BY d.next_attempt_at
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+
+	return r.find(ctx, query, now, now.Add(lease), limit)
+}
+
+// Update implements DeliveryRepository.
+func (r *deliveryRepository) Update(ctx context.Context, d Delivery) error {
+	query := `
+		UPDATE webhook_delivery SET
+			status = $2, attempts = $3, response_status = $4, response_body = $5, error = $6,
+			next_attempt_at = $7, delivered_at = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, d.ID, d.Status, d.Attempts, d.ResponseStatus, d.ResponseBody, d.Error, d.NextAttemptAt, d.DeliveredAt, d.UpdatedAt)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// FindByEndpointID implements DeliveryRepository.
+func (r *deliveryRepository) FindByEndpointID(ctx context.Context, endpointID int64, deliveryStatus string, before int64, limit int) ([]Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + ` FROM webhook_delivery
+		WHERE endpoint_id = $1 AND ($2 = '' OR status = $2) AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`
+
+	return r.find(ctx, query, endpointID, deliveryStatus, before, limit)
+}
+
+func (r *deliveryRepository) find(ctx context.Context, query string, args ...any) ([]Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	deliveries := make([]Delivery, 0)
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,208 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type EndpointRepository interface {
+	Save(ctx context.Context, e Endpoint) (Endpoint, error)
+	FindByID(ctx context.Context, id int64) (Endpoint, error)
+	FindByOwnerIDAndID(ctx context.Context, ownerID, id int64) (Endpoint, error)
+	// FindByOwnerID returns the endpoints of the owner, of every event when
+	// the eventID is empty.
+	FindByOwnerID(ctx context.Context, ownerID int64, eventID string) ([]Endpoint, error)
+	FindActiveByEventID(ctx context.Context, eventID string) ([]Endpoint, error)
+	Enable(ctx context.Context, ownerID, id int64, at time.Time) (Endpoint, error)
+	DeleteByOwnerIDAndID(ctx context.Context, ownerID, id int64) error
+	// RecordSuccess resets the consecutive failures of the endpoint.
+	RecordSuccess(ctx context.Context, id int64, at time.Time) error
+	// RecordFailure counts a consecutive failure of the endpoint and disables
+	// it once the failures reach disableAfter, it reports whether the endpoint
+	// is disabled.
+	RecordFailure(ctx context.Context, id int64, disableAfter int, at time.Time) (bool, error)
+}
+
+type endpointRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewEndpointRepository(logger *logrus.Logger, db *sql.DB) EndpointRepository {
+	return &endpointRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+const endpointColumns = `id, owner_id, event_id, url, secret, active, failure_count, disabled_at, created_at, updated_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEndpoint(s scanner) (Endpoint, error) {
+	var e Endpoint
+	err := s.Scan(&e.ID, &e.OwnerID, &e.EventID, &e.URL, &e.Secret, &e.Active, &e.FailureCount, &e.DisabledAt, &e.CreatedAt, &e.UpdatedAt)
+	return e, err
+}
+
+// Save implements EndpointRepository.
+func (r *endpointRepository) Save(ctx context.Context, e Endpoint) (Endpoint, error) {
+	query := `
+		INSERT INTO webhook_endpoint (owner_id, event_id, url, secret, active, failure_count, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + endpointColumns
+
+	saved, err := scanEndpoint(r.db.QueryRowContext(ctx, query, e.OwnerID, e.EventID, e.URL, e.Secret, e.Active, e.FailureCount, e.CreatedAt, e.UpdatedAt))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Endpoint{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return saved, nil
+}
+
+// FindByID implements EndpointRepository.
+func (r *endpointRepository) FindByID(ctx context.Context, id int64) (Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoint WHERE id = $1`
+
+	return r.findOne(ctx, query, id)
+}
+
+// FindByOwnerIDAndID implements EndpointRepository.
+func (r *endpointRepository) FindByOwnerIDAndID(ctx context.Context, ownerID, id int64) (Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoint WHERE owner_id = $1 AND id = $2`
+
+	return r.findOne(ctx, query, ownerID, id)
+}
+
+// FindByOwnerID implements EndpointRepository.
+func (r *endpointRepository) FindByOwnerID(ctx context.Context, ownerID int64, eventID string) ([]Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoint WHERE owner_id = $1 AND ($2 = '' OR event_id = $2) ORDER BY id`
+
+	return r.find(ctx, query, ownerID, eventID)
+}
+
+// FindActiveByEventID implements EndpointRepository.
+func (r *endpointRepository) FindActiveByEventID(ctx context.Context, eventID string) ([]Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoint WHERE event_id = $1 AND active ORDER BY id`
+
+	return r.find(ctx, query, eventID)
+}
+
+// Enable implements EndpointRepository.
+func (r *endpointRepository) Enable(ctx context.Context, ownerID, id int64, at time.Time) (Endpoint, error) {
+	query := `
+		UPDATE webhook_endpoint SET active = TRUE, failure_count = 0, disabled_at = NULL, updated_at = $3
+		WHERE owner_id = $1 AND id = $2
+		RETURNING ` + endpointColumns
+
+	return r.findOne(ctx, query, ownerID, id, at)
+}
+
+// DeleteByOwnerIDAndID implements EndpointRepository.
+func (r *endpointRepository) DeleteByOwnerIDAndID(ctx context.Context, ownerID, id int64) error {
+	query := `DELETE FROM webhook_endpoint WHERE owner_id = $1 AND id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, ownerID, id)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	if affected == 0 {
+		return errors.New(http.StatusNotFound, status.NOT_FOUND, "webhook endpoint is not found")
+	}
+
+	return nil
+}
+
+// RecordSuccess implements EndpointRepository.
+func (r *endpointRepository) RecordSuccess(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE webhook_endpoint SET failure_count = 0, updated_at = $2 WHERE id = $1 AND failure_count > 0`
+
+	if _, err := r.db.ExecContext(ctx, query, id, at); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// RecordFailure implements EndpointRepository.
+func (r *endpointRepository) RecordFailure(ctx context.Context, id int64, disableAfter int, at time.Time) (bool, error) {
+	query := `
+		UPDATE webhook_endpoint SET
+			failure_count = failure_count + 1,
+			active = active AND failure_count + 1 < $2,
+			disabled_at = CASE WHEN active AND failure_count + 1 >= $2 THEN $3 ELSE disabled_at END,
+			updated_at = $3
+		WHERE id = $1
+		RETURNING active
+	`
+
+	var active bool
+	if err := r.db.QueryRowContext(ctx, query, id, disableAfter, at).Scan(&active); err != nil {
+		if err == sql.ErrNoRows {
+			return false, errors.New(http.StatusNotFound, status.NOT_FOUND, "webhook endpoint is not found")
+		}
+
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return !active, nil
+}
+
+func (r *endpointRepository) findOne(ctx context.Context, query string, args ...any) (Endpoint, error) {
+	e, err := scanEndpoint(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Endpoint{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "webhook endpoint is not found")
+		}
+
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Endpoint{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return e, nil
+}
+
+func (r *endpointRepository) find(ctx context.Context, query string, args ...any) ([]Endpoint, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	endpoints := make([]Endpoint, 0)
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return endpoints, nil
+}
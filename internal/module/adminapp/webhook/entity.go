@@ -0,0 +1,43 @@
+package webhook
+
+import "time"
+
+// Status of a delivery.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Endpoint is the URL an organizer registers to be notified of an event, it is
+// disabled after too many consecutive failed attempts.
+type Endpoint struct {
+	ID           int64
+	OwnerID      int64
+	EventID      string
+	URL          string
+	Secret       string
+	Active       bool
+	FailureCount int
+	DisabledAt   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Delivery is a payload to be POSTed to an endpoint along with the outcome of
+// its last attempt.
+type Delivery struct {
+	ID             int64
+	EndpointID     int64
+	Event          string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
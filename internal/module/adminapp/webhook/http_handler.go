@@ -0,0 +1,211 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	WebhookUseCase    WebhookUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, webhookUseCase WebhookUseCase) {
+	handler := &HTTPHandler{
+		SessionMiddleware: adminSession,
+		Validate:          validate,
+		WebhookUseCase:    webhookUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/webhooks", handler.SessionMiddleware.Verify(handler.RegisterEndpoint)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-notification/webhooks", handler.SessionMiddleware.Verify(handler.GetEndpoints)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/webhooks/{id}", handler.SessionMiddleware.Verify(handler.DeleteEndpoint)).Methods(http.MethodDelete)
+	router.HandleFunc("/tm-notification/webhooks/{id}/enable", handler.SessionMiddleware.Verify(handler.EnableEndpoint)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-notification/webhooks/{id}/deliveries", handler.SessionMiddleware.Verify(handler.GetDeliveries)).Methods(http.MethodGet)
+}
+
+// RegisterEndpoint registers an endpoint notified of an event.
+func (handler HTTPHandler) RegisterEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req RegisterEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	if err := handler.Validate.StructCtx(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := handler.WebhookUseCase.RegisterEndpoint(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.RESTEnvelope{
+		Status:  status.CREATED,
+		Message: "webhook endpoint is registered",
+		Data:    resp,
+	})
+}
+
+// GetEndpoints lists the endpoints, of an event when `event_id` is queried.
+func (handler HTTPHandler) GetEndpoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.WebhookUseCase.GetEndpoints(ctx, r.URL.Query().Get("event_id"))
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "webhook endpoints",
+		Data:    resp,
+	})
+}
+
+// DeleteEndpoint removes the endpoint and its delivery log.
+func (handler HTTPHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid webhook endpoint id",
+		})
+		return
+	}
+
+	if err := handler.WebhookUseCase.DeleteEndpoint(ctx, id); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "webhook endpoint is deleted",
+	})
+}
+
+// EnableEndpoint enables the endpoint disabled after consecutive failures.
+func (handler HTTPHandler) EnableEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid webhook endpoint id",
+		})
+		return
+	}
+
+	resp, err := handler.WebhookUseCase.EnableEndpoint(ctx, id)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "webhook endpoint is enabled",
+		Data:    resp,
+	})
+}
+
+// GetDeliveries lists the latest deliveries of the endpoint, filtered by
+// `status` and paginated with the `before` cursor and `limit`.
+func (handler HTTPHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid webhook endpoint id",
+		})
+		return
+	}
+
+	query := r.URL.Query()
+	req := GetDeliveriesRequest{Status: query.Get("status")}
+	if v := query.Get("before"); v != "" {
+		if req.Before, err = strconv.ParseInt(v, 10, 64); err != nil {
+			response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+				Status:  status.BAD_REQUEST,
+				Message: "invalid before",
+			})
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+				Status:  status.BAD_REQUEST,
+				Message: "invalid limit",
+			})
+			return
+		}
+	}
+
+	if err := handler.Validate.StructCtx(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := handler.WebhookUseCase.GetDeliveries(ctx, id, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "webhook deliveries",
+		Data:    resp,
+	})
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RegisterEndpointRequest struct {
+	EventID string `json:"event_id" validate:"required,max=64"`
+	URL     string `json:"url" validate:"required,url,max=2048"`
+}
+
+type EndpointResponse struct {
+	ID      int64  `json:"id"`
+	EventID string `json:"event_id"`
+	URL     string `json:"url"`
+	// Secret is only returned when the endpoint is registered.
+	Secret       string     `json:"secret,omitempty"`
+	Active       bool       `json:"active"`
+	FailureCount int        `json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type GetDeliveriesRequest struct {
+	Status string `validate:"omitempty,oneof=pending succeeded failed"`
+	// Before is the cursor, the deliveries with a lower id are returned.
+	Before int64 `validate:"min=0"`
+	Limit  int   `validate:"min=0,max=100"`
+}
+
+type DeliveryResponse struct {
+	ID             int64           `json:"id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	Error          string          `json:"error"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// payload is the body POSTed to the endpoints.
+type payload struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
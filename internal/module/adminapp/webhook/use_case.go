@@ -0,0 +1,454 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
+)
+
+// Defaults of the delivery when they are not configured.
+const (
+	DefaultMaxAttempts  = 8
+	DefaultBackoff      = 10 * time.Second
+	DefaultMaxBackoff   = time.Hour
+	DefaultDisableAfter = 20
+	DefaultPollInterval = 5 * time.Second
+)
+
+// DefaultDeliveriesLimit is the number of deliveries listed when no limit is
+// requested.
+const DefaultDeliveriesLimit = 20
+
+// claimBatchSize is the number of deliveries attempted at once.
+const claimBatchSize = 50
+
+// claimLease is how long a claimed delivery is hidden from the other workers on
+// top of the client timeout.
+const claimLease = time.Minute
+
+type WebhookUseCase interface {
+	RegisterEndpoint(ctx context.Context, req RegisterEndpointRequest) (EndpointResponse, error)
+	GetEndpoints(ctx context.Context, eventID string) ([]EndpointResponse, error)
+	// EnableEndpoint enables a disabled endpoint again, the deliveries still
+	// pending are resumed.
+	EnableEndpoint(ctx context.Context, id int64) (EndpointResponse, error)
+	DeleteEndpoint(ctx context.Context, id int64) error
+	GetDeliveries(ctx context.Context, endpointID int64, req GetDeliveriesRequest) ([]DeliveryResponse, error)
+	// Dispatch queues the data to every active endpoint of the event, the
+	// deliveries are attempted in background.
+	Dispatch(ctx context.Context, eventID, event string, data interface{}) error
+	// DeliverDue attempts the due deliveries and returns how many are
+	// attempted.
+	DeliverDue(ctx context.Context) (int, error)
+	// Start attempts the due deliveries periodically in background.
+	Start()
+	// Close stops the background deliveries.
+	Close()
+}
+
+type WebhookUseCaseProperty struct {
+	AppName            string
+	Logger             *logrus.Logger
+	EndpointRepository EndpointRepository
+	DeliveryRepository DeliveryRepository
+	Client             webhook.Client
+	// Timeout is the timeout of the client, a claimed delivery is not
+	// attempted by another worker for as long. It is webhook.DefaultTimeout,
+	// the default of the client, when it is zero.
+	Timeout time.Duration
+	// MaxAttempts is the number of attempts before a delivery fails.
+	MaxAttempts int
+	// Backoff is the wait after the first failed attempt, it doubles after
+	// every failed attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// DisableAfter is the number of consecutive failed attempts before the
+	// endpoint is disabled.
+	DisableAfter int
+	PollInterval time.Duration
+}
+
+type webhookUseCase struct {
+	appName            string
+	logger             *logrus.Logger
+	endpointRepository EndpointRepository
+	deliveryRepository DeliveryRepository
+	client             webhook.Client
+	lease              time.Duration
+	maxAttempts        int
+	backoff            time.Duration
+	maxBackoff         time.Duration
+	disableAfter       int
+	pollInterval       time.Duration
+	wake               chan struct{}
+	stop               chan struct{}
+	wg                 sync.WaitGroup
+}
+
+func NewWebhookUseCase(props WebhookUseCaseProperty) WebhookUseCase {
+	maxAttempts := props.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	backoff := props.Backoff
+	if backoff <= 0 {
+		backoff = DefaultBackoff
+	}
+
+	maxBackoff := props.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	disableAfter := props.DisableAfter
+	if disableAfter <= 0 {
+		disableAfter = DefaultDisableAfter
+	}
+
+	pollInterval := props.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	timeout := props.Timeout
+	if timeout <= 0 {
+		timeout = webhook.DefaultTimeout
+	}
+
+	return &webhookUseCase{
+		appName:            props.AppName,
+		logger:             props.Logger,
+		endpointRepository: props.EndpointRepository,
+		deliveryRepository: props.DeliveryRepository,
+		client:             props.Client,
+		lease:              timeout + claimLease,
+		maxAttempts:        maxAttempts,
+		backoff:            backoff,
+		maxBackoff:         maxBackoff,
+		disableAfter:       disableAfter,
+		pollInterval:       pollInterval,
+		wake:               make(chan struct{}, 1),
+		stop:               make(chan struct{}),
+	}
+}
+
+// RegisterEndpoint implements WebhookUseCase. The secret is generated and only
+// returned here. The url must be https and not name a private host, see
+// webhook.ValidateURL.
+func (u *webhookUseCase) RegisterEndpoint(ctx context.Context, req RegisterEndpointRequest) (EndpointResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	if err := webhook.ValidateURL(req.URL); err != nil {
+		return EndpointResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, err.Error())
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return EndpointResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	now := time.Now()
+	e, err := u.endpointRepository.Save(ctx, Endpoint{
+		OwnerID:   acc.ID,
+		EventID:   req.EventID,
+		URL:       req.URL,
+		Secret:    secret,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	resp := endpointResponse(e)
+	resp.Secret = e.Secret
+
+	return resp, nil
+}
+
+// GetEndpoints implements WebhookUseCase.
+func (u *webhookUseCase) GetEndpoints(ctx context.Context, eventID string) ([]EndpointResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := u.endpointRepository.FindByOwnerID(ctx, acc.ID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]EndpointResponse, len(endpoints))
+	for i, e := range endpoints {
+		resp[i] = endpointResponse(e)
+	}
+
+	return resp, nil
+}
+
+// EnableEndpoint implements WebhookUseCase.
+func (u *webhookUseCase) EnableEndpoint(ctx context.Context, id int64) (EndpointResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	e, err := u.endpointRepository.Enable(ctx, acc.ID, id, time.Now())
+	if err != nil {
+		return EndpointResponse{}, err
+	}
+
+	u.notify()
+
+	return endpointResponse(e), nil
+}
+
+// DeleteEndpoint implements WebhookUseCase. The deliveries of the endpoint are
+// deleted along.
+func (u *webhookUseCase) DeleteEndpoint(ctx context.Context, id int64) error {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	return u.endpointRepository.DeleteByOwnerIDAndID(ctx, acc.ID, id)
+}
+
+// GetDeliveries implements WebhookUseCase.
+func (u *webhookUseCase) GetDeliveries(ctx context.Context, endpointID int64, req GetDeliveriesRequest) ([]DeliveryResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.endpointRepository.FindByOwnerIDAndID(ctx, acc.ID, endpointID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultDeliveriesLimit
+	}
+
+	deliveries, err := u.deliveryRepository.FindByEndpointID(ctx, endpointID, req.Status, req.Before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]DeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		resp[i] = DeliveryResponse{
+			ID:             d.ID,
+			Event:          d.Event,
+			Payload:        d.Payload,
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			ResponseStatus: d.ResponseStatus,
+			ResponseBody:   d.ResponseBody,
+			Error:          d.Error,
+			DeliveredAt:    d.DeliveredAt,
+			CreatedAt:      d.CreatedAt,
+			UpdatedAt:      d.UpdatedAt,
+		}
+		if d.Status == DeliveryStatusPending {
+			nextAttemptAt := d.NextAttemptAt
+			resp[i].NextAttemptAt = &nextAttemptAt
+		}
+	}
+
+	return resp, nil
+}
+
+// Dispatch implements WebhookUseCase.
+func (u *webhookUseCase) Dispatch(ctx context.Context, eventID, event string, data interface{}) error {
+	endpoints, err := u.endpointRepository.FindActiveByEventID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusUnprocessableEntity, status.UNPROCESSABLE_ENTITY, err.Error())
+	}
+
+	now := time.Now()
+	for _, e := range endpoints {
+		if _, err := u.deliveryRepository.Save(ctx, Delivery{
+			EndpointID:    e.ID,
+			Event:         event,
+			Payload:       payload,
+			Status:        DeliveryStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}); err != nil {
+			return err
+		}
+	}
+
+	u.notify()
+
+	return nil
+}
+
+// DeliverDue implements WebhookUseCase.
+func (u *webhookUseCase) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := u.deliveryRepository.ClaimDue(ctx, time.Now(), u.lease, claimBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, d := range deliveries {
+		wg.Add(1)
+		go func(d Delivery) {
+			defer wg.Done()
+			u.deliver(ctx, d)
+		}(d)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver attempts the delivery and records its outcome, a failed attempt is
+// retried after the backoff until the attempts run out.
+func (u *webhookUseCase) deliver(ctx context.Context, d Delivery) {
+	logger := u.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"webhook.endpoint_id": d.EndpointID,
+		"webhook.delivery_id": d.ID,
+	})
+
+	e, err := u.endpointRepository.FindByID(ctx, d.EndpointID)
+	if err != nil {
+		logger.WithError(err).Warn()
+		return
+	}
+
+	id := strconv.FormatInt(d.ID, 10)
+	body, err := json.Marshal(payload{
+		ID:        id,
+		Event:     d.Event,
+		CreatedAt: d.CreatedAt,
+		Data:      d.Payload,
+	})
+	if err != nil {
+		logger.WithError(err).Error()
+		return
+	}
+
+	result, err := u.client.Deliver(ctx, webhook.Request{
+		ID:     id,
+		Event:  d.Event,
+		URL:    e.URL,
+		Secret: e.Secret,
+		Body:   body,
+	})
+
+	now := time.Now()
+	d.Attempts++
+	d.ResponseStatus = result.StatusCode
+	d.ResponseBody = result.Body
+	d.Error = ""
+	d.UpdatedAt = now
+
+	if err == nil {
+		d.Status = DeliveryStatusSucceeded
+		d.DeliveredAt = &now
+		if err := u.endpointRepository.RecordSuccess(ctx, e.ID, now); err != nil {
+			logger.WithError(err).Warn()
+		}
+	} else {
+		d.Error = err.Error()
+		if d.Attempts >= u.maxAttempts {
+			d.Status = DeliveryStatusFailed
+		} else {
+			d.NextAttemptAt = now.Add(webhook.Backoff(d.Attempts, u.backoff, u.maxBackoff))
+		}
+
+		disabled, err := u.endpointRepository.RecordFailure(ctx, e.ID, u.disableAfter, now)
+		if err != nil {
+			logger.WithError(err).Warn()
+		} else if disabled {
+			logger.Warn("webhook endpoint is disabled after consecutive failures")
+		}
+	}
+
+	if err := u.deliveryRepository.Update(ctx, d); err != nil {
+		logger.WithError(err).Error()
+	}
+}
+
+// Start implements WebhookUseCase.
+func (u *webhookUseCase) Start() {
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+
+		ticker := time.NewTicker(u.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-u.stop:
+				return
+			case <-ticker.C:
+			case <-u.wake:
+			}
+
+			// keep going while there are more due deliveries than a batch.
+			for {
+				n, err := u.DeliverDue(context.Background())
+				if err != nil || n < claimBatchSize {
+					break
+				}
+			}
+		}
+	}()
+}
+
+// Close implements WebhookUseCase.
+func (u *webhookUseCase) Close() {
+	close(u.stop)
+	u.wg.Wait()
+}
+
+// notify wakes the background deliveries up without waiting for the poll.
+func (u *webhookUseCase) notify() {
+	select {
+	case u.wake <- struct{}{}:
+	default:
+	}
+}
+
+func endpointResponse(e Endpoint) EndpointResponse {
+	return EndpointResponse{
+		ID:           e.ID,
+		EventID:      e.EventID,
+		URL:          e.URL,
+		Active:       e.Active,
+		FailureCount: e.FailureCount,
+		DisabledAt:   e.DisabledAt,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
@@ -0,0 +1,244 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/webhook"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/adminapp/webhook/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	pkgwebhook "github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
+)
+
+func newAdminContext() context.Context {
+	return context.WithValue(context.Background(), session.AccountContextKey{}, session.Account{
+		ID:   7,
+		Name: "Organizer",
+		Type: "ADMIN",
+	})
+}
+
+func TestWebhookUseCase_RegisterEndpoint(t *testing.T) {
+	endpointRepositoryMock := &mocks.EndpointRepository{}
+	endpointRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(e webhook.Endpoint) bool {
+		return e.OwnerID == 7 && e.EventID == "EVENT-1" && e.URL == "https://organizer.example.com/hooks" && e.Active && len(e.Secret) > 0
+	})).Return(func(ctx context.Context, e webhook.Endpoint) webhook.Endpoint {
+		e.ID = 1
+		return e
+	}, nil)
+
+	uc := webhook.NewWebhookUseCase(webhook.WebhookUseCaseProperty{
+		Logger:             logrus.New(),
+		EndpointRepository: endpointRepositoryMock,
+	})
+
+	resp, err := uc.RegisterEndpoint(newAdminContext(), webhook.RegisterEndpointRequest{
+		EventID: "EVENT-1",
+		URL:     "https://organizer.example.com/hooks",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.ID)
+	assert.True(t, resp.Active)
+	assert.Regexp(t, "^whsec_", resp.Secret)
+
+	_, err = uc.RegisterEndpoint(context.Background(), webhook.RegisterEndpointRequest{})
+	assert.True(t, errors.MatchStatus(err, status.FORBIDDEN))
+
+	for _, url := range []string{"http://organizer.example.com/hooks", "https://169.254.169.254/latest/meta-data", "https://localhost/hooks"} {
+		_, err = uc.RegisterEndpoint(newAdminContext(), webhook.RegisterEndpointRequest{EventID: "EVENT-1", URL: url})
+		assert.True(t, errors.MatchStatus(err, status.BAD_REQUEST), url)
+	}
+	endpointRepositoryMock.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestWebhookUseCase_Dispatch(t *testing.T) {
+	endpointRepositoryMock := &mocks.EndpointRepository{}
+	endpointRepositoryMock.On("FindActiveByEventID", mock.Anything, "EVENT-1").Return([]webhook.Endpoint{{ID: 1}, {ID: 2}}, nil)
+	endpointRepositoryMock.On("FindActiveByEventID", mock.Anything, "EVENT-2").Return([]webhook.Endpoint{}, nil)
+
+	deliveryRepositoryMock := &mocks.DeliveryRepository{}
+	for _, endpointID := range []int64{1, 2} {
+		endpointID := endpointID
+		deliveryRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool {
+			return d.EndpointID == endpointID &&
+				d.Event == pkgwebhook.EventTicketAcquired &&
+				string(d.Payload) == `{"ticket_number":"TICKET-1"}` &&
+				d.Status == webhook.DeliveryStatusPending &&
+				!d.NextAttemptAt.After(time.Now())
+		})).Return(webhook.Delivery{}, nil).Once()
+	}
+
+	uc := webhook.NewWebhookUseCase(webhook.WebhookUseCaseProperty{
+		Logger:             logrus.New(),
+		EndpointRepository: endpointRepositoryMock,
+		DeliveryRepository: deliveryRepositoryMock,
+	})
+
+	data := map[string]string{"ticket_number": "TICKET-1"}
+	assert.NoError(t, uc.Dispatch(context.Background(), "EVENT-1", pkgwebhook.EventTicketAcquired, data))
+	assert.NoError(t, uc.Dispatch(context.Background(), "EVENT-2", pkgwebhook.EventTicketAcquired, data))
+
+	deliveryRepositoryMock.AssertExpectations(t)
+}
+
+func TestWebhookUseCase_DeliverDue(t *testing.T) {
+	createdAt := time.Date(2026, time.October, 1, 10, 0, 0, 0, time.UTC)
+	newDelivery := func(attempts int) webhook.Delivery {
+		return webhook.Delivery{
+			ID:            9,
+			EndpointID:    1,
+			Event:         pkgwebhook.EventTicketAcquired,
+			Payload:       []byte(`{"ticket_number":"TICKET-1"}`),
+			Status:        webhook.DeliveryStatusPending,
+			Attempts:      attempts,
+			NextAttemptAt: createdAt,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+	}
+
+	newUseCase := func(t *testing.T, statusCode int, delivery webhook.Delivery, endpointRepositoryMock *mocks.EndpointRepository, deliveryRepositoryMock *mocks.DeliveryRepository) webhook.WebhookUseCase {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if err := pkgwebhook.Verify("whsec_secret", r.Header.Get(pkgwebhook.TimestampHeader), body, r.Header.Get(pkgwebhook.SignatureHeader), time.Minute, time.Now()); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			var p map[string]interface{}
+			json.Unmarshal(body, &p)
+			assert.Equal(t, map[string]interface{}{
+				"id":         "9",
+				"event":      "ticket.acquired",
+				"created_at": "2026-10-01T10:00:00Z",
+				"data":       map[string]interface{}{"ticket_number": "TICKET-1"},
+			}, p)
+
+			w.WriteHeader(statusCode)
+			w.Write([]byte("received"))
+		}))
+		t.Cleanup(srv.Close)
+
+		endpointRepositoryMock.On("FindByID", mock.Anything, int64(1)).Return(webhook.Endpoint{
+			ID:     1,
+			URL:    srv.URL,
+			Secret: "whsec_secret",
+			Active: true,
+		}, nil)
+		deliveryRepositoryMock.On("ClaimDue", mock.Anything, mock.Anything, 10*time.Second+time.Minute, 50).Return([]webhook.Delivery{delivery}, nil)
+
+		return webhook.NewWebhookUseCase(webhook.WebhookUseCaseProperty{
+			Logger:             logrus.New(),
+			EndpointRepository: endpointRepositoryMock,
+			DeliveryRepository: deliveryRepositoryMock,
+			Client:             pkgwebhook.NewHTTPClient(pkgwebhook.HTTPClientProperty{Client: srv.Client()}),
+			Timeout:            10 * time.Second,
+			MaxAttempts:        3,
+			Backoff:            time.Minute,
+			DisableAfter:       5,
+		})
+	}
+
+	t.Run("mark the delivery as succeeded", func(t *testing.T) {
+		endpointRepositoryMock := &mocks.EndpointRepository{}
+		endpointRepositoryMock.On("RecordSuccess", mock.Anything, int64(1), mock.Anything).Return(nil)
+
+		deliveryRepositoryMock := &mocks.DeliveryRepository{}
+		deliveryRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool {
+			return d.Status == webhook.DeliveryStatusSucceeded &&
+				d.Attempts == 1 &&
+				d.ResponseStatus == http.StatusOK &&
+				d.ResponseBody == "received" &&
+				d.DeliveredAt != nil
+		})).Return(nil)
+
+		uc := newUseCase(t, http.StatusOK, newDelivery(0), endpointRepositoryMock, deliveryRepositoryMock)
+		n, err := uc.DeliverDue(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		endpointRepositoryMock.AssertExpectations(t)
+		deliveryRepositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("retry the delivery after the backoff", func(t *testing.T) {
+		endpointRepositoryMock := &mocks.EndpointRepository{}
+		endpointRepositoryMock.On("RecordFailure", mock.Anything, int64(1), 5, mock.Anything).Return(false, nil)
+
+		deliveryRepositoryMock := &mocks.DeliveryRepository{}
+		deliveryRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool {
+			return d.Status == webhook.DeliveryStatusPending &&
+				d.Attempts == 2 &&
+				d.ResponseStatus == http.StatusInternalServerError &&
+				d.Error == "Webhook: endpoint responded 500" &&
+				d.NextAttemptAt.Sub(d.UpdatedAt) == 2*time.Minute
+		})).Return(nil)
+
+		uc := newUseCase(t, http.StatusInternalServerError, newDelivery(1), endpointRepositoryMock, deliveryRepositoryMock)
+		_, err := uc.DeliverDue(context.Background())
+		assert.NoError(t, err)
+
+		endpointRepositoryMock.AssertExpectations(t)
+		deliveryRepositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("fail the delivery after the last attempt", func(t *testing.T) {
+		endpointRepositoryMock := &mocks.EndpointRepository{}
+		endpointRepositoryMock.On("RecordFailure", mock.Anything, int64(1), 5, mock.Anything).Return(true, nil)
+
+		deliveryRepositoryMock := &mocks.DeliveryRepository{}
+		deliveryRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool {
+			return d.Status == webhook.DeliveryStatusFailed && d.Attempts == 3 && d.DeliveredAt == nil
+		})).Return(nil)
+
+		uc := newUseCase(t, http.StatusGone, newDelivery(2), endpointRepositoryMock, deliveryRepositoryMock)
+		_, err := uc.DeliverDue(context.Background())
+		assert.NoError(t, err)
+
+		endpointRepositoryMock.AssertExpectations(t)
+		deliveryRepositoryMock.AssertExpectations(t)
+	})
+}
+
+func TestWebhookUseCase_GetDeliveries(t *testing.T) {
+	endpointRepositoryMock := &mocks.EndpointRepository{}
+	endpointRepositoryMock.On("FindByOwnerIDAndID", mock.Anything, int64(7), int64(1)).Return(webhook.Endpoint{ID: 1}, nil)
+	endpointRepositoryMock.On("FindByOwnerIDAndID", mock.Anything, int64(7), int64(2)).Return(webhook.Endpoint{}, errors.New(404, status.NOT_FOUND, "webhook endpoint is not found"))
+
+	nextAttemptAt := time.Now()
+	deliveryRepositoryMock := &mocks.DeliveryRepository{}
+	deliveryRepositoryMock.On("FindByEndpointID", mock.Anything, int64(1), webhook.DeliveryStatusPending, int64(100), webhook.DefaultDeliveriesLimit).Return([]webhook.Delivery{{
+		ID:            99,
+		Event:         pkgwebhook.EventTicketAcquired,
+		Payload:       []byte(`{}`),
+		Status:        webhook.DeliveryStatusPending,
+		Attempts:      1,
+		NextAttemptAt: nextAttemptAt,
+	}}, nil)
+
+	uc := webhook.NewWebhookUseCase(webhook.WebhookUseCaseProperty{
+		Logger:             logrus.New(),
+		EndpointRepository: endpointRepositoryMock,
+		DeliveryRepository: deliveryRepositoryMock,
+	})
+
+	resp, err := uc.GetDeliveries(newAdminContext(), 1, webhook.GetDeliveriesRequest{Status: webhook.DeliveryStatusPending, Before: 100})
+	assert.NoError(t, err)
+	if assert.Len(t, resp, 1) {
+		assert.Equal(t, int64(99), resp[0].ID)
+		assert.Equal(t, &nextAttemptAt, resp[0].NextAttemptAt)
+	}
+
+	_, err = uc.GetDeliveries(newAdminContext(), 2, webhook.GetDeliveriesRequest{})
+	assert.True(t, errors.MatchStatus(err, status.NOT_FOUND))
+}
@@ -18,4 +18,11 @@ type SignUpEvent struct {
 	MemberStatus       string    `json:"member_status"`
 	VerificationLink   string    `json:"verification_link"`
 	CreatedAt          time.Time `json:"created_at"`
+	Locale             string    `json:"locale"`
+	// PhoneNumber is optional, the verification is also sent by SMS when it is
+	// set.
+	PhoneNumber string `json:"phone_number"`
+	// VerificationCode is the optional one-time code sent by SMS instead of the
+	// verification link.
+	VerificationCode string `json:"verification_code"`
 }
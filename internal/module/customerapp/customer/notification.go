@@ -0,0 +1,56 @@
+package customer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+)
+
+// NotificationTypeSignUp is the type of the sign-up notification.
+const NotificationTypeSignUp = "sign_up"
+
+// signUpNotification renders the verification of a sign-up on the email and
+// SMS channels and welcomes the customer in the app inbox.
+type signUpNotification struct {
+	event SignUpEvent
+}
+
+// Email implements notification.EmailTemplate.
+func (n signUpNotification) Email(ctx context.Context, r notification.Recipient) (notification.EmailContent, error) {
+	mt := mailtemplate.NewCustomerVerificationTemplate(n.event.Locale)
+	mtBuff, err := mt.Populate(&mailtemplate.VerificationEmailData{
+		RecipientName:    n.event.Name,
+		VerificationLink: n.event.VerificationLink,
+	})
+	if err != nil {
+		return notification.EmailContent{}, err
+	}
+
+	return notification.EmailContent{
+		Subject: mt.Subject(),
+		Body:    mtBuff.Bytes(),
+	}, nil
+}
+
+// SMS implements notification.SMSTemplate. It sends the verification code, or
+// the link when there is no code.
+func (n signUpNotification) SMS(ctx context.Context, r notification.Recipient) (string, error) {
+	localizer := mailtemplate.NewLocalizer(n.event.Locale)
+	if n.event.VerificationCode != "" {
+		return fmt.Sprintf(localizer.T("sms_verification_code"), n.event.VerificationCode), nil
+	}
+
+	return fmt.Sprintf(localizer.T("sms_verification_link"), n.event.Name, n.event.VerificationLink), nil
+}
+
+// Inbox implements notification.InboxTemplate.
+func (n signUpNotification) Inbox(ctx context.Context, r notification.Recipient) (notification.InboxContent, error) {
+	localizer := mailtemplate.NewLocalizer(n.event.Locale)
+
+	return notification.InboxContent{
+		Title: localizer.T("inbox_sign_up_title"),
+		Body:  fmt.Sprintf(localizer.T("inbox_sign_up_body"), n.event.Name),
+	}, nil
+}
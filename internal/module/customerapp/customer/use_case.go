@@ -5,9 +5,8 @@ import (
 	"net/http"
 
 	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
-	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
-	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
 )
 
@@ -17,25 +16,24 @@ type CustomerUseCase interface {
 }
 
 type CustomerUseCaseProperty struct {
-	AppName     string
-	Logger      *logrus.Logger
-	EmailSender string
-	Mailer      mailer.Mailer
+	AppName string
+	Logger  *logrus.Logger
+	// Dispatcher sends the verification on the channels routed for the
+	// sign-up, e.g. email and SMS, and welcomes the customer in the inbox.
+	Dispatcher notification.Dispatcher
 }
 
 type customerUseCase struct {
-	appName     string
-	logger      *logrus.Logger
-	emailSender string
-	mailer      mailer.Mailer
+	appName    string
+	logger     *logrus.Logger
+	dispatcher notification.Dispatcher
 }
 
 func NewCustomerUseCase(props CustomerUseCaseProperty) CustomerUseCase {
 	return &customerUseCase{
-		appName:     props.AppName,
-		logger:      props.Logger,
-		emailSender: props.EmailSender,
-		mailer:      props.Mailer,
+		appName:    props.AppName,
+		logger:     props.Logger,
+		dispatcher: props.Dispatcher,
 	}
 }
 
@@ -44,33 +42,25 @@ func (u *customerUseCase) OnChangeEmail(ctx context.Context, event ChangeEmailEv
 	return nil
 }
 
-// OnSignUp implements CustomerUseCase.
+// OnSignUp implements CustomerUseCase. It fails when a required channel fails,
+// the other channels are only logged by the dispatcher.
 func (u *customerUseCase) OnSignUp(ctx context.Context, event SignUpEvent) error {
-	emailSubject := "Customer Verification"
-	recipients := make([]mailer.Recepient, 1)
-	recipients[0] = mailer.Recepient{
-		Address: event.Email,
-		Name:    event.Name,
-	}
-
-	data := &mailtemplate.VerificationEmailData{
-		RecipientName:    event.Name,
-		VerificationLink: event.VerificationLink,
-	}
-
-	mt := mailtemplate.NewCustomerVerificationTemplate()
-	mtBuff := mt.Populate(data)
-
-	if err := u.mailer.Send(context.TODO(), mailer.Message{
-		From:    u.emailSender,
-		To:      recipients,
-		Subject: emailSubject,
-		MessageBody: mailer.MessageBody{
-			ContentType: "text/html",
-			Body:        mtBuff.Bytes(),
+	if _, err := u.dispatcher.Dispatch(ctx, notification.Intent{
+		Recipient: notification.Recipient{
+			CustomerID:  event.ID,
+			Name:        event.Name,
+			Email:       event.Email,
+			PhoneNumber: event.PhoneNumber,
+			Locale:      event.Locale,
 		},
+		Type: NotificationTypeSignUp,
+		Data: signUpNotification{event: event},
 	}); err != nil {
-		u.logger.WithContext(ctx).WithError(err).WithField("event", event).Error()
+		// the event carries the verification code, only its id is logged.
+		u.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"customer_id": event.ID,
+			"type":        NotificationTypeSignUp,
+		}).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, err.Error())
 	}
 
@@ -0,0 +1,201 @@
+package customer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/customer"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sms"
+	smsMocks "github.com/tsel-ticketmaster/tm-notification/pkg/sms/mocks"
+)
+
+// newDispatcher routes the notifications to every given channel.
+func newDispatcher(channels ...notification.Channel) notification.Dispatcher {
+	names := make([]string, 0, len(channels))
+	for _, c := range channels {
+		names = append(names, c.Name())
+	}
+
+	return notification.NewDispatcher(notification.DispatcherProperty{
+		Logger:   logrus.New(),
+		Channels: channels,
+		Routes: map[string][]string{
+			customer.NotificationTypeSignUp: names,
+		},
+		RequiredChannels: []string{notification.Email},
+	})
+}
+
+func newSignUpEvent() customer.SignUpEvent {
+	return customer.SignUpEvent{
+		ID:               1,
+		Name:             "John Doe",
+		Email:            "john@mail.com",
+		VerificationLink: "https://example.com/verify?token=abc",
+	}
+}
+
+func TestCustomerUseCase_OnSignUp(t *testing.T) {
+	t.Run("send the verification code by sms", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		smsSenderMock := &smsMocks.SMSSender{}
+		smsSenderMock.On("Send", mock.Anything, sms.Message{
+			To:   "+6281234567890",
+			Body: "TicketMaster: 123456 adalah kode verifikasi Anda. Jangan berikan kode ini kepada siapa pun.",
+		}).Return(nil)
+
+		uc := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewSMSChannel(smsSenderMock, "62"),
+			),
+		})
+
+		e := newSignUpEvent()
+		e.Locale = "id-ID"
+		e.PhoneNumber = "0812-3456-7890"
+		e.VerificationCode = "123456"
+		err := uc.OnSignUp(context.Background(), e)
+		assert.NoError(t, err)
+
+		mailerMock.AssertExpectations(t)
+		smsSenderMock.AssertExpectations(t)
+	})
+
+	t.Run("send the verification link by sms without code", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		smsSenderMock := &smsMocks.SMSSender{}
+		smsSenderMock.On("Send", mock.Anything, sms.Message{
+			To:   "+6281234567890",
+			Body: "TicketMaster: Hi John Doe, please verify your account at https://example.com/verify?token=abc",
+		}).Return(nil)
+
+		uc := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewSMSChannel(smsSenderMock, ""),
+			),
+		})
+
+		e := newSignUpEvent()
+		e.PhoneNumber = "+6281234567890"
+		err := uc.OnSignUp(context.Background(), e)
+		assert.NoError(t, err)
+
+		smsSenderMock.AssertExpectations(t)
+	})
+
+	t.Run("skip the sms of an invalid phone number or a failing provider", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		smsSenderMock := &smsMocks.SMSSender{}
+		smsSenderMock.On("Send", mock.Anything, mock.Anything).Return(errors.New("provider is down"))
+
+		uc := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewSMSChannel(smsSenderMock, ""),
+			),
+		})
+
+		e := newSignUpEvent()
+		e.PhoneNumber = "not a phone"
+		assert.NoError(t, uc.OnSignUp(context.Background(), e))
+		smsSenderMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+		e.PhoneNumber = "+6281234567890"
+		assert.NoError(t, uc.OnSignUp(context.Background(), e))
+		smsSenderMock.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("send no sms without phone number", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		smsSenderMock := &smsMocks.SMSSender{}
+
+		uc := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewSMSChannel(smsSenderMock, ""),
+			),
+		})
+
+		assert.NoError(t, uc.OnSignUp(context.Background(), newSignUpEvent()))
+		smsSenderMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("return error and send nothing else when the email fails", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp is down"))
+
+		smsSenderMock := &smsMocks.SMSSender{}
+		logger, hook := test.NewNullLogger()
+		uc := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+			Logger: logger,
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewSMSChannel(smsSenderMock, ""),
+			),
+		})
+
+		e := newSignUpEvent()
+		e.PhoneNumber = "+6281234567890"
+		e.VerificationCode = "123456"
+		assert.Error(t, uc.OnSignUp(context.Background(), e))
+		smsSenderMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+		// the verification code and the phone number are not logged.
+		entry := hook.LastEntry()
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, logrus.Fields{
+				logrus.ErrorKey: entry.Data[logrus.ErrorKey],
+				"customer_id":   int64(1),
+				"type":          customer.NotificationTypeSignUp,
+			}, entry.Data)
+		}
+	})
+
+	t.Run("welcome the customer in the inbox", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		inbox := &fakeInboxWriter{}
+		uc := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewInboxChannel(inbox),
+			),
+		})
+
+		assert.NoError(t, uc.OnSignUp(context.Background(), newSignUpEvent()))
+		assert.Equal(t, []string{"1 sign_up Welcome to TicketMaster: Hi John Doe, please verify your email to start buying tickets."}, inbox.written)
+	})
+}
+
+type fakeInboxWriter struct {
+	written []string
+}
+
+func (f *fakeInboxWriter) Write(ctx context.Context, customerID int64, notificationType, title, body string, data map[string]string) error {
+	f.written = append(f.written, fmt.Sprintf("%d %s %s: %s", customerID, notificationType, title, body))
+	return nil
+}
@@ -0,0 +1,13 @@
+package device
+
+import "time"
+
+// Device is an installation of the customer app receiving push notifications,
+// identified by the token of its push provider.
+type Device struct {
+	Token      string
+	CustomerID int64
+	Platform   string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
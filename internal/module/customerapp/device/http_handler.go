@@ -0,0 +1,87 @@
+package device
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.CustomerSession
+	Validate          *validator.Validate
+	DeviceUseCase     DeviceUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, customerSession *middleware.CustomerSession, validate *validator.Validate, deviceUseCase DeviceUseCase) {
+	handler := &HTTPHandler{
+		SessionMiddleware: customerSession,
+		Validate:          validate,
+		DeviceUseCase:     deviceUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/customer/devices", handler.SessionMiddleware.Verify(handler.RegisterDevice)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-notification/customer/devices/{token}", handler.SessionMiddleware.Verify(handler.UnregisterDevice)).Methods(http.MethodDelete)
+}
+
+// RegisterDevice registers the push token of the customer's device.
+func (handler HTTPHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req RegisterDeviceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	if err := handler.Validate.StructCtx(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := handler.DeviceUseCase.RegisterDevice(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "device is registered",
+		Data:    resp,
+	})
+}
+
+// UnregisterDevice removes the push token, e.g. when the customer signs out.
+func (handler HTTPHandler) UnregisterDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := handler.DeviceUseCase.UnregisterDevice(ctx, mux.Vars(r)["token"]); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "device is unregistered",
+	})
+}
@@ -0,0 +1,15 @@
+package device
+
+import "time"
+
+type RegisterDeviceRequest struct {
+	Token    string `json:"token" validate:"required,max=4096"`
+	Platform string `json:"platform" validate:"required,oneof=android ios web"`
+}
+
+type DeviceResponse struct {
+	Token     string    `json:"token"`
+	Platform  string    `json:"platform"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
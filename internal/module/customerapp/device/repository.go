@@ -0,0 +1,119 @@
+package device
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type DeviceRepository interface {
+	Save(ctx context.Context, d Device) (Device, error)
+	FindByCustomerID(ctx context.Context, customerID int64) ([]Device, error)
+	DeleteByCustomerIDAndToken(ctx context.Context, customerID int64, token string) error
+	DeleteByToken(ctx context.Context, token string) error
+}
+
+type deviceRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewDeviceRepository(logger *logrus.Logger, db *sql.DB) DeviceRepository {
+	return &deviceRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Save implements DeviceRepository. A token registered again, e.g. after
+// another customer signs in on the device, is moved to the customer.
+func (r *deviceRepository) Save(ctx context.Context, d Device) (Device, error) {
+	query := `
+		INSERT INTO device (token, customer_id, platform, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (token) DO UPDATE SET
+			customer_id = EXCLUDED.customer_id,
+			platform = EXCLUDED.platform,
+			updated_at = EXCLUDED.updated_at
+		RETURNING token, customer_id, platform, created_at, updated_at
+	`
+
+	var saved Device
+	err := r.db.QueryRowContext(ctx, query, d.Token, d.CustomerID, d.Platform, d.CreatedAt, d.UpdatedAt).Scan(
+		&saved.Token, &saved.CustomerID, &saved.Platform, &saved.CreatedAt, &saved.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Device{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return saved, nil
+}
+
+// FindByCustomerID implements DeviceRepository.
+func (r *deviceRepository) FindByCustomerID(ctx context.Context, customerID int64) ([]Device, error) {
+	query := `SELECT token, customer_id, platform, created_at, updated_at FROM device WHERE customer_id = $1 ORDER BY updated_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, customerID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	devices := make([]Device, 0)
+	for rows.Next() {
+		var d Device
+		if err := rows.Scan(&d.Token, &d.CustomerID, &d.Platform, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		devices = append(devices, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return devices, nil
+}
+
+// DeleteByCustomerIDAndToken implements DeviceRepository.
+func (r *deviceRepository) DeleteByCustomerIDAndToken(ctx context.Context, customerID int64, token string) error {
+	query := `DELETE FROM device WHERE customer_id = $1 AND token = $2`
+
+	result, err := r.db.ExecContext(ctx, query, customerID, token)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	if affected == 0 {
+		return errors.New(http.StatusNotFound, status.NOT_FOUND, "device is not found")
+	}
+
+	return nil
+}
+
+// DeleteByToken implements DeviceRepository.
+func (r *deviceRepository) DeleteByToken(ctx context.Context, token string) error {
+	query := `DELETE FROM device WHERE token = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, token); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+)
+
+type DeviceUseCase interface {
+	RegisterDevice(ctx context.Context, req RegisterDeviceRequest) (DeviceResponse, error)
+	UnregisterDevice(ctx context.Context, token string) error
+	// Notify sends the notification to every device of the customer, the
+	// tokens rejected by the push provider are removed.
+	Notify(ctx context.Context, customerID int64, n push.Notification) error
+}
+
+type DeviceUseCaseProperty struct {
+	AppName          string
+	Logger           *logrus.Logger
+	DeviceRepository DeviceRepository
+	PushSender       push.Sender
+}
+
+type deviceUseCase struct {
+	appName          string
+	logger           *logrus.Logger
+	deviceRepository DeviceRepository
+	pushSender       push.Sender
+}
+
+func NewDeviceUseCase(props DeviceUseCaseProperty) DeviceUseCase {
+	return &deviceUseCase{
+		appName:          props.AppName,
+		logger:           props.Logger,
+		deviceRepository: props.DeviceRepository,
+		pushSender:       props.PushSender,
+	}
+}
+
+// RegisterDevice implements DeviceUseCase.
+func (u *deviceUseCase) RegisterDevice(ctx context.Context, req RegisterDeviceRequest) (DeviceResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return DeviceResponse{}, err
+	}
+
+	now := time.Now()
+	d, err := u.deviceRepository.Save(ctx, Device{
+		Token:      req.Token,
+		CustomerID: acc.ID,
+		Platform:   req.Platform,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		return DeviceResponse{}, err
+	}
+
+	return DeviceResponse{
+		Token:     d.Token,
+		Platform:  d.Platform,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}, nil
+}
+
+// UnregisterDevice implements DeviceUseCase.
+func (u *deviceUseCase) UnregisterDevice(ctx context.Context, token string) error {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	return u.deviceRepository.DeleteByCustomerIDAndToken(ctx, acc.ID, token)
+}
+
+// Notify implements DeviceUseCase. The error of the last failing device is
+// returned after every device is attempted.
+func (u *deviceUseCase) Notify(ctx context.Context, customerID int64, n push.Notification) error {
+	devices, err := u.deviceRepository.FindByCustomerID(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, d := range devices {
+		n.Token = d.Token
+		n.Platform = d.Platform
+
+		err := u.pushSender.Send(ctx, n)
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, push.ErrInvalidToken) {
+			u.logger.WithContext(ctx).WithError(err).WithField("customer_id", customerID).Info("remove invalid device token")
+			if err := u.deviceRepository.DeleteByToken(ctx, d.Token); err != nil {
+				lastErr = err
+			}
+			continue
+		}
+
+		u.logger.WithContext(ctx).WithError(err).WithField("customer_id", customerID).Error()
+		lastErr = err
+	}
+
+	return lastErr
+}
@@ -0,0 +1,136 @@
+package device_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/device"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/device/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	pushMocks "github.com/tsel-ticketmaster/tm-notification/pkg/push/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+func newCustomerContext() context.Context {
+	return context.WithValue(context.Background(), session.AccountContextKey{}, session.Account{
+		ID:   42,
+		Name: "John Doe",
+		Type: "CUSTOMER",
+	})
+}
+
+func TestDeviceUseCase_RegisterDevice(t *testing.T) {
+	t.Run("save the token of the customer", func(t *testing.T) {
+		now := time.Now()
+		repositoryMock := &mocks.DeviceRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(d device.Device) bool {
+			return d.Token == "device-token" && d.CustomerID == 42 && d.Platform == push.PlatformAndroid
+		})).Return(device.Device{
+			Token:      "device-token",
+			CustomerID: 42,
+			Platform:   push.PlatformAndroid,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}, nil)
+
+		uc := device.NewDeviceUseCase(device.DeviceUseCaseProperty{
+			Logger:           logrus.New(),
+			DeviceRepository: repositoryMock,
+		})
+
+		resp, err := uc.RegisterDevice(newCustomerContext(), device.RegisterDeviceRequest{
+			Token:    "device-token",
+			Platform: push.PlatformAndroid,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "device-token", resp.Token)
+		assert.Equal(t, now, resp.CreatedAt)
+
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("return forbidden without a customer session", func(t *testing.T) {
+		uc := device.NewDeviceUseCase(device.DeviceUseCaseProperty{
+			Logger: logrus.New(),
+		})
+
+		_, err := uc.RegisterDevice(context.Background(), device.RegisterDeviceRequest{})
+		assert.True(t, errors.MatchStatus(err, status.FORBIDDEN))
+	})
+}
+
+func TestDeviceUseCase_UnregisterDevice(t *testing.T) {
+	repositoryMock := &mocks.DeviceRepository{}
+	repositoryMock.On("DeleteByCustomerIDAndToken", mock.Anything, int64(42), "device-token").Return(nil)
+	repositoryMock.On("DeleteByCustomerIDAndToken", mock.Anything, int64(42), "unknown").Return(errors.New(404, status.NOT_FOUND, "device is not found"))
+
+	uc := device.NewDeviceUseCase(device.DeviceUseCaseProperty{
+		Logger:           logrus.New(),
+		DeviceRepository: repositoryMock,
+	})
+
+	assert.NoError(t, uc.UnregisterDevice(newCustomerContext(), "device-token"))
+	assert.True(t, errors.MatchStatus(uc.UnregisterDevice(newCustomerContext(), "unknown"), status.NOT_FOUND))
+}
+
+func TestDeviceUseCase_Notify(t *testing.T) {
+	t.Run("send to every device and remove the invalid tokens", func(t *testing.T) {
+		repositoryMock := &mocks.DeviceRepository{}
+		repositoryMock.On("FindByCustomerID", mock.Anything, int64(42)).Return([]device.Device{
+			{Token: "android-token", CustomerID: 42, Platform: push.PlatformAndroid},
+			{Token: "stale-token", CustomerID: 42, Platform: push.PlatformIOS},
+		}, nil)
+		repositoryMock.On("DeleteByToken", mock.Anything, "stale-token").Return(nil)
+
+		senderMock := &pushMocks.Sender{}
+		senderMock.On("Send", mock.Anything, push.Notification{
+			Token:    "android-token",
+			Platform: push.PlatformAndroid,
+			Title:    "Your ticket is ready",
+		}).Return(nil)
+		senderMock.On("Send", mock.Anything, push.Notification{
+			Token:    "stale-token",
+			Platform: push.PlatformIOS,
+			Title:    "Your ticket is ready",
+		}).Return(fmt.Errorf("%w: Unregistered", push.ErrInvalidToken))
+
+		uc := device.NewDeviceUseCase(device.DeviceUseCaseProperty{
+			Logger:           logrus.New(),
+			DeviceRepository: repositoryMock,
+			PushSender:       senderMock,
+		})
+
+		err := uc.Notify(context.Background(), 42, push.Notification{Title: "Your ticket is ready"})
+		assert.NoError(t, err)
+
+		repositoryMock.AssertExpectations(t)
+		senderMock.AssertExpectations(t)
+	})
+
+	t.Run("return the error of a failing device", func(t *testing.T) {
+		repositoryMock := &mocks.DeviceRepository{}
+		repositoryMock.On("FindByCustomerID", mock.Anything, int64(42)).Return([]device.Device{
+			{Token: "android-token", CustomerID: 42, Platform: push.PlatformAndroid},
+		}, nil)
+
+		senderMock := &pushMocks.Sender{}
+		senderMock.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("unavailable"))
+
+		uc := device.NewDeviceUseCase(device.DeviceUseCaseProperty{
+			Logger:           logrus.New(),
+			DeviceRepository: repositoryMock,
+			PushSender:       senderMock,
+		})
+
+		err := uc.Notify(context.Background(), 42, push.Notification{Title: "Your ticket is ready"})
+		assert.Error(t, err)
+		repositoryMock.AssertNotCalled(t, "DeleteByToken", mock.Anything, mock.Anything)
+	})
+}
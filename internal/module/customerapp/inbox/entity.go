@@ -0,0 +1,15 @@
+package inbox
+
+import "time"
+
+// Notification is an entry of the customer's in-app inbox.
+type Notification struct {
+	ID         int64
+	CustomerID int64
+	Type       string
+	Title      string
+	Body       string
+	Data       map[string]string
+	ReadAt     *time.Time
+	CreatedAt  time.Time
+}
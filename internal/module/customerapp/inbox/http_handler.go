@@ -0,0 +1,234 @@
+package inbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sse"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+const (
+	// heartbeatInterval keeps the idle streams open behind the proxies.
+	heartbeatInterval = 15 * time.Second
+	// reconnectDelay is how long the client waits before resuming a stream.
+	reconnectDelay = 3 * time.Second
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.CustomerSession
+	Validate          *validator.Validate
+	InboxUseCase      InboxUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, customerSession *middleware.CustomerSession, validate *validator.Validate, inboxUseCase InboxUseCase) {
+	handler := &HTTPHandler{
+		SessionMiddleware: customerSession,
+		Validate:          validate,
+		InboxUseCase:      inboxUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/customer/notifications", handler.SessionMiddleware.Verify(handler.GetNotifications)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/customer/notifications/stream", handler.SessionMiddleware.Verify(handler.Stream)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/customer/notifications/unread-count", handler.SessionMiddleware.Verify(handler.GetUnreadCount)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/customer/notifications/read", handler.SessionMiddleware.Verify(handler.MarkAllRead)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-notification/customer/notifications/{id}/read", handler.SessionMiddleware.Verify(handler.MarkRead)).Methods(http.MethodPost)
+}
+
+// GetNotifications lists the latest notifications of the customer, paginated
+// with the `cursor` and `limit` queries, of the unread ones when `unread` is
+// true.
+func (handler HTTPHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	req := GetNotificationsRequest{Cursor: query.Get("cursor")}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+				Status:  status.BAD_REQUEST,
+				Message: "invalid limit",
+			})
+			return
+		}
+		req.Limit = limit
+	}
+	if v := query.Get("unread"); v != "" {
+		unread, err := strconv.ParseBool(v)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+				Status:  status.BAD_REQUEST,
+				Message: "invalid unread",
+			})
+			return
+		}
+		req.Unread = unread
+	}
+
+	if err := handler.Validate.StructCtx(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := handler.InboxUseCase.GetNotifications(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "notifications",
+		Data:    resp,
+	})
+}
+
+// GetUnreadCount returns the number of unread notifications, e.g. for a badge.
+func (handler HTTPHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.InboxUseCase.GetUnreadCount(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "unread notification count",
+		Data:    resp,
+	})
+}
+
+// MarkRead marks a notification as read.
+func (handler HTTPHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid notification id",
+		})
+		return
+	}
+
+	resp, err := handler.InboxUseCase.MarkRead(ctx, id)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "notification is read",
+		Data:    resp,
+	})
+}
+
+// MarkAllRead marks every unread notification as read.
+func (handler HTTPHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.InboxUseCase.MarkAllRead(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "notifications are read",
+		Data:    resp,
+	})
+}
+
+// Stream sends the new notifications as Server-Sent Events. A client resumes
+// with the `Last-Event-ID` header, or with the `last_event_id` query for the
+// clients which cannot set it.
+func (handler HTTPHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	stream, err := handler.InboxUseCase.Stream(ctx, lastEventID)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+	defer stream.Close()
+
+	sw, err := sse.NewWriter(w)
+	if err != nil {
+		return
+	}
+
+	send := func(n NotificationResponse) error {
+		data, _ := json.Marshal(n)
+		return sw.Event(strconv.FormatInt(n.ID, 10), "notification", data)
+	}
+
+	if err := sw.Retry(reconnectDelay); err != nil {
+		return
+	}
+	for _, n := range stream.Replay {
+		if err := send(n); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := sw.Comment("heartbeat"); err != nil {
+				return
+			}
+		case n, ok := <-stream.Notifications:
+			if !ok {
+				return
+			}
+			if err := send(n); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package inbox
+
+import "time"
+
+type GetNotificationsRequest struct {
+	// Cursor is the next cursor of the previous page, the first page is
+	// returned when it is empty.
+	Cursor string
+	Limit  int `validate:"min=0,max=100"`
+	// Unread lists the unread notifications only.
+	Unread bool
+}
+
+type NotificationResponse struct {
+	ID        int64             `json:"id"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data"`
+	ReadAt    *time.Time        `json:"read_at"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor"`
+}
+
+type UnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+type MarkAllReadResponse struct {
+	Count int64 `json:"count"`
+}
+
+// The types of the frames of the notification WebSocket.
+const (
+	// FrameNotification is sent by the server with a new notification.
+	FrameNotification = "notification"
+	// FrameRead is sent by the client to acknowledge a notification as read,
+	// the server echoes it once the notification is marked.
+	FrameRead = "read"
+	// FrameError is sent by the server when a frame of the client fails.
+	FrameError = "error"
+)
+
+// Frame is the JSON frame of the notification WebSocket.
+type Frame struct {
+	Type         string                `json:"type"`
+	ID           int64                 `json:"id,omitempty"`
+	Notification *NotificationResponse `json:"notification,omitempty"`
+	Error        string                `json:"error,omitempty"`
+}
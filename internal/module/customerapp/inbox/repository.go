@@ -0,0 +1,202 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type NotificationRepository interface {
+	Save(ctx context.Context, n Notification) (Notification, error)
+	// FindByCustomerID returns the latest notifications of the customer with an
+	// id lower than before, or the latest ones when before is zero.
+	FindByCustomerID(ctx context.Context, customerID, before int64, limit int, unreadOnly bool) ([]Notification, error)
+	// FindAfterID returns the oldest notifications of the customer with an id
+	// greater than afterID, it replays the notifications missed by a stream.
+	FindAfterID(ctx context.Context, customerID, afterID int64, limit int) ([]Notification, error)
+	CountUnreadByCustomerID(ctx context.Context, customerID int64) (int64, error)
+	// MarkRead keeps the read time of a notification already read.
+	MarkRead(ctx context.Context, customerID, id int64, at time.Time) (Notification, error)
+	MarkAllRead(ctx context.Context, customerID int64, at time.Time) (int64, error)
+}
+
+type notificationRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewNotificationRepository(logger *logrus.Logger, db *sql.DB) NotificationRepository {
+	return &notificationRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+const notificationColumns = `id, customer_id, type, title, body, data, read_at, created_at`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNotification(s scanner) (Notification, error) {
+	var n Notification
+	var data []byte
+	if err := s.Scan(&n.ID, &n.CustomerID, &n.Type, &n.Title, &n.Body, &data, &n.ReadAt, &n.CreatedAt); err != nil {
+		return Notification{}, err
+	}
+
+	n.Data = make(map[string]string)
+	if err := json.Unmarshal(data, &n.Data); err != nil {
+		return Notification{}, err
+	}
+
+	return n, nil
+}
+
+// Save implements NotificationRepository.
+func (r *notificationRepository) Save(ctx context.Context, n Notification) (Notification, error) {
+	query := `
+		INSERT INTO inbox_notification (customer_id, type, title, body, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + notificationColumns
+
+	data := n.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	dataBuff, _ := json.Marshal(data)
+
+	saved, err := scanNotification(r.db.QueryRowContext(ctx, query, n.CustomerID, n.Type, n.Title, n.Body, dataBuff, n.CreatedAt))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Notification{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return saved, nil
+}
+
+// FindByCustomerID implements NotificationRepository.
+func (r *notificationRepository) FindByCustomerID(ctx context.Context, customerID, before int64, limit int, unreadOnly bool) ([]Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + ` FROM inbox_notification
+		WHERE customer_id = $1 AND ($2 = 0 OR id < $2) AND (NOT $3 OR read_at IS NULL)
+		ORDER BY id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, customerID, before, unreadOnly, limit)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	notifications := make([]Notification, 0)
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return notifications, nil
+}
+
+// FindAfterID implements NotificationRepository.
+func (r *notificationRepository) FindAfterID(ctx context.Context, customerID, afterID int64, limit int) ([]Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + ` FROM inbox_notification
+		WHERE customer_id = $1 AND id > $2
+		ORDER BY id ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, customerID, afterID, limit)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	notifications := make([]Notification, 0)
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return notifications, nil
+}
+
+// CountUnreadByCustomerID implements NotificationRepository.
+func (r *notificationRepository) CountUnreadByCustomerID(ctx context.Context, customerID int64) (int64, error) {
+	query := `SELECT COUNT(*) FROM inbox_notification WHERE customer_id = $1 AND read_at IS NULL`
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, customerID).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return count, nil
+}
+
+// MarkRead implements NotificationRepository.
+func (r *notificationRepository) MarkRead(ctx context.Context, customerID, id int64, at time.Time) (Notification, error) {
+	query := `
+		UPDATE inbox_notification SET read_at = COALESCE(read_at, $3)
+		WHERE customer_id = $1 AND id = $2
+		RETURNING ` + notificationColumns
+
+	n, err := scanNotification(r.db.QueryRowContext(ctx, query, customerID, id, at))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Notification{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "notification is not found")
+		}
+
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Notification{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return n, nil
+}
+
+// MarkAllRead implements NotificationRepository.
+func (r *notificationRepository) MarkAllRead(ctx context.Context, customerID int64, at time.Time) (int64, error) {
+	query := `UPDATE inbox_notification SET read_at = $2 WHERE customer_id = $1 AND read_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, customerID, at)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,311 @@
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/realtime"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+const (
+	// DefaultLimit is the number of notifications listed when no limit is
+	// requested.
+	DefaultLimit = 20
+	// ReplayLimit is the number of missed notifications replayed when a
+	// stream resumes, the older ones are left to GetNotifications.
+	ReplayLimit = 100
+)
+
+// NotificationStream delivers the notifications of a customer as they are
+// written.
+type NotificationStream struct {
+	// Replay holds the notifications missed since the last event id, the
+	// oldest first.
+	Replay []NotificationResponse
+	// Notifications receives the new notifications. It is closed when the
+	// stream falls behind, the client then resumes from the last event id. It
+	// is closed right away when more than ReplayLimit notifications are missed,
+	// the client catches up a page of Replay at a time.
+	Notifications <-chan NotificationResponse
+
+	subscription *realtime.Subscription
+	done         chan struct{}
+	once         sync.Once
+}
+
+// Close stops the stream.
+func (s *NotificationStream) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.subscription.Close()
+	})
+}
+
+type InboxUseCase interface {
+	// Write puts a notification in the customer's inbox, it is called by the
+	// other use cases.
+	Write(ctx context.Context, customerID int64, notificationType, title, body string, data map[string]string) error
+	GetNotifications(ctx context.Context, req GetNotificationsRequest) (NotificationListResponse, error)
+	GetUnreadCount(ctx context.Context) (UnreadCountResponse, error)
+	MarkRead(ctx context.Context, id int64) (NotificationResponse, error)
+	MarkAllRead(ctx context.Context) (MarkAllReadResponse, error)
+	// Stream opens the notification stream of the customer, it resumes after
+	// the last event id when it is not empty.
+	Stream(ctx context.Context, lastEventID string) (*NotificationStream, error)
+}
+
+type InboxUseCaseProperty struct {
+	AppName                string
+	Logger                 *logrus.Logger
+	NotificationRepository NotificationRepository
+	// Hub fans the written notifications out to the streams, streaming is not
+	// available when it is nil.
+	Hub realtime.Hub
+}
+
+type inboxUseCase struct {
+	appName                string
+	logger                 *logrus.Logger
+	notificationRepository NotificationRepository
+	hub                    realtime.Hub
+}
+
+func NewInboxUseCase(props InboxUseCaseProperty) InboxUseCase {
+	return &inboxUseCase{
+		appName:                props.AppName,
+		logger:                 props.Logger,
+		notificationRepository: props.NotificationRepository,
+		hub:                    props.Hub,
+	}
+}
+
+func streamKey(customerID int64) string {
+	return strconv.FormatInt(customerID, 10)
+}
+
+// Write implements InboxUseCase.
+func (u *inboxUseCase) Write(ctx context.Context, customerID int64, notificationType, title, body string, data map[string]string) error {
+	n, err := u.notificationRepository.Save(ctx, Notification{
+		CustomerID: customerID,
+		Type:       notificationType,
+		Title:      title,
+		Body:       body,
+		Data:       data,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	u.publish(ctx, n)
+
+	return nil
+}
+
+// publish does not fail the write, a stream missing the notification gets it
+// on resume.
+func (u *inboxUseCase) publish(ctx context.Context, n Notification) {
+	if u.hub == nil {
+		return
+	}
+
+	message, _ := json.Marshal(notificationResponse(n))
+	if err := u.hub.Publish(ctx, streamKey(n.CustomerID), message); err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithField("notification.id", n.ID).Warn()
+	}
+}
+
+// GetNotifications implements InboxUseCase. The cursor is the id of the last
+// notification of the previous page.
+func (u *inboxUseCase) GetNotifications(ctx context.Context, req GetNotificationsRequest) (NotificationListResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return NotificationListResponse{}, err
+	}
+
+	var before int64
+	if req.Cursor != "" {
+		before, err = strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || before <= 0 {
+			return NotificationListResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid cursor")
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	// one more notification tells whether there is a next page.
+	notifications, err := u.notificationRepository.FindByCustomerID(ctx, acc.ID, before, limit+1, req.Unread)
+	if err != nil {
+		return NotificationListResponse{}, err
+	}
+
+	resp := NotificationListResponse{
+		Notifications: make([]NotificationResponse, 0, limit),
+	}
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		resp.NextCursor = strconv.FormatInt(notifications[limit-1].ID, 10)
+	}
+	for _, n := range notifications {
+		resp.Notifications = append(resp.Notifications, notificationResponse(n))
+	}
+
+	return resp, nil
+}
+
+// GetUnreadCount implements InboxUseCase.
+func (u *inboxUseCase) GetUnreadCount(ctx context.Context) (UnreadCountResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return UnreadCountResponse{}, err
+	}
+
+	count, err := u.notificationRepository.CountUnreadByCustomerID(ctx, acc.ID)
+	if err != nil {
+		return UnreadCountResponse{}, err
+	}
+
+	return UnreadCountResponse{Count: count}, nil
+}
+
+// MarkRead implements InboxUseCase.
+func (u *inboxUseCase) MarkRead(ctx context.Context, id int64) (NotificationResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return NotificationResponse{}, err
+	}
+
+	n, err := u.notificationRepository.MarkRead(ctx, acc.ID, id, time.Now())
+	if err != nil {
+		return NotificationResponse{}, err
+	}
+
+	return notificationResponse(n), nil
+}
+
+// MarkAllRead implements InboxUseCase.
+func (u *inboxUseCase) MarkAllRead(ctx context.Context) (MarkAllReadResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return MarkAllReadResponse{}, err
+	}
+
+	count, err := u.notificationRepository.MarkAllRead(ctx, acc.ID, time.Now())
+	if err != nil {
+		return MarkAllReadResponse{}, err
+	}
+
+	return MarkAllReadResponse{Count: count}, nil
+}
+
+// Stream implements InboxUseCase. It subscribes before the replay so that a
+// notification written in between is not missed, the duplicates are dropped.
+func (u *inboxUseCase) Stream(ctx context.Context, lastEventID string) (*NotificationStream, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.hub == nil {
+		return nil, errors.New(http.StatusNotFound, status.NOT_FOUND, "notification stream is not available")
+	}
+
+	var lastID int64
+	if lastEventID != "" {
+		lastID, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || lastID < 0 {
+			return nil, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid last event id")
+		}
+	}
+
+	subscription, err := u.hub.Subscribe(ctx, streamKey(acc.ID))
+	if err != nil {
+		if err == realtime.ErrTooManySubscriptions {
+			return nil, errors.New(http.StatusTooManyRequests, status.TOO_MANY_REQUESTS, "too many notification streams")
+		}
+
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	replay := make([]NotificationResponse, 0)
+	replayed := make(map[int64]bool)
+	truncated := false
+	if lastEventID != "" {
+		// one more than the limit tells whether the replay is truncated.
+		notifications, err := u.notificationRepository.FindAfterID(ctx, acc.ID, lastID, ReplayLimit+1)
+		if err != nil {
+			subscription.Close()
+			return nil, err
+		}
+		if len(notifications) > ReplayLimit {
+			notifications = notifications[:ReplayLimit]
+			truncated = true
+		}
+		for _, n := range notifications {
+			replay = append(replay, notificationResponse(n))
+			replayed[n.ID] = true
+		}
+	}
+
+	c := make(chan NotificationResponse)
+	stream := &NotificationStream{
+		Replay:        replay,
+		Notifications: c,
+		subscription:  subscription,
+		done:          make(chan struct{}),
+	}
+
+	if truncated {
+		// the live notifications would skip the ones after the replay.
+		subscription.Close()
+		close(c)
+		return stream, nil
+	}
+
+	go func() {
+		defer close(c)
+
+		for message := range subscription.C {
+			var n NotificationResponse
+			if err := json.Unmarshal(message, &n); err != nil {
+				u.logger.WithError(err).Warn()
+				continue
+			}
+			if replayed[n.ID] {
+				continue
+			}
+
+			select {
+			case c <- n:
+			case <-stream.done:
+				return
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
+func notificationResponse(n Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
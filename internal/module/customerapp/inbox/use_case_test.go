@@ -0,0 +1,248 @@
+package inbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/inbox"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/inbox/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/realtime"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+func newCustomerContext() context.Context {
+	return context.WithValue(context.Background(), session.AccountContextKey{}, session.Account{
+		ID:   42,
+		Name: "John Doe",
+		Type: "CUSTOMER",
+	})
+}
+
+func newNotifications(ids ...int64) []inbox.Notification {
+	notifications := make([]inbox.Notification, len(ids))
+	for i, id := range ids {
+		notifications[i] = inbox.Notification{ID: id, CustomerID: 42, Type: "acquired_ticket"}
+	}
+	return notifications
+}
+
+func TestInboxUseCase_Write(t *testing.T) {
+	repositoryMock := &mocks.NotificationRepository{}
+	repositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(n inbox.Notification) bool {
+		return n.CustomerID == 42 &&
+			n.Type == "acquired_ticket" &&
+			n.Title == "Your ticket is ready" &&
+			n.Data["ticket_number"] == "TICKET-1" &&
+			!n.CreatedAt.IsZero()
+	})).Return(inbox.Notification{ID: 1}, nil)
+
+	uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+		Logger:                 logrus.New(),
+		NotificationRepository: repositoryMock,
+	})
+
+	err := uc.Write(context.Background(), 42, "acquired_ticket", "Your ticket is ready", "Concert", map[string]string{"ticket_number": "TICKET-1"})
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestInboxUseCase_GetNotifications(t *testing.T) {
+	t.Run("return the next cursor when there are more", func(t *testing.T) {
+		repositoryMock := &mocks.NotificationRepository{}
+		repositoryMock.On("FindByCustomerID", mock.Anything, int64(42), int64(0), 3, false).Return(newNotifications(10, 9, 8), nil)
+
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger:                 logrus.New(),
+			NotificationRepository: repositoryMock,
+		})
+
+		resp, err := uc.GetNotifications(newCustomerContext(), inbox.GetNotificationsRequest{Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Notifications, 2)
+		assert.Equal(t, int64(9), resp.Notifications[1].ID)
+		assert.Equal(t, "9", resp.NextCursor)
+	})
+
+	t.Run("return no cursor on the last page", func(t *testing.T) {
+		repositoryMock := &mocks.NotificationRepository{}
+		repositoryMock.On("FindByCustomerID", mock.Anything, int64(42), int64(9), inbox.DefaultLimit+1, true).Return(newNotifications(8), nil)
+
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger:                 logrus.New(),
+			NotificationRepository: repositoryMock,
+		})
+
+		resp, err := uc.GetNotifications(newCustomerContext(), inbox.GetNotificationsRequest{Cursor: "9", Unread: true})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Notifications, 1)
+		assert.Empty(t, resp.NextCursor)
+	})
+
+	t.Run("reject an invalid cursor", func(t *testing.T) {
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger: logrus.New(),
+		})
+
+		_, err := uc.GetNotifications(newCustomerContext(), inbox.GetNotificationsRequest{Cursor: "abc"})
+		assert.True(t, errors.MatchStatus(err, status.BAD_REQUEST))
+	})
+
+	t.Run("return forbidden without a customer session", func(t *testing.T) {
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger: logrus.New(),
+		})
+
+		_, err := uc.GetNotifications(context.Background(), inbox.GetNotificationsRequest{})
+		assert.True(t, errors.MatchStatus(err, status.FORBIDDEN))
+	})
+}
+
+func TestInboxUseCase_MarkRead(t *testing.T) {
+	readAt := time.Now()
+	repositoryMock := &mocks.NotificationRepository{}
+	repositoryMock.On("MarkRead", mock.Anything, int64(42), int64(1), mock.Anything).Return(inbox.Notification{ID: 1, ReadAt: &readAt}, nil)
+	repositoryMock.On("MarkRead", mock.Anything, int64(42), int64(2), mock.Anything).Return(inbox.Notification{}, errors.New(404, status.NOT_FOUND, "notification is not found"))
+	repositoryMock.On("MarkAllRead", mock.Anything, int64(42), mock.Anything).Return(int64(3), nil)
+	repositoryMock.On("CountUnreadByCustomerID", mock.Anything, int64(42)).Return(int64(0), nil)
+
+	uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+		Logger:                 logrus.New(),
+		NotificationRepository: repositoryMock,
+	})
+
+	resp, err := uc.MarkRead(newCustomerContext(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &readAt, resp.ReadAt)
+
+	_, err = uc.MarkRead(newCustomerContext(), 2)
+	assert.True(t, errors.MatchStatus(err, status.NOT_FOUND))
+
+	all, err := uc.MarkAllRead(newCustomerContext())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), all.Count)
+
+	unread, err := uc.GetUnreadCount(newCustomerContext())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), unread.Count)
+}
+
+func receiveNotification(t *testing.T, stream *inbox.NotificationStream) inbox.NotificationResponse {
+	t.Helper()
+
+	select {
+	case n := <-stream.Notifications:
+		return n
+	case <-time.After(time.Second):
+		t.Fatal("no notification is received")
+		return inbox.NotificationResponse{}
+	}
+}
+
+func TestInboxUseCase_Stream(t *testing.T) {
+	t.Run("replay the missed notifications then stream the new ones", func(t *testing.T) {
+		hub := realtime.NewLocalHub(realtime.LocalHubProperty{})
+		defer hub.Close()
+
+		repositoryMock := &mocks.NotificationRepository{}
+		repositoryMock.On("FindAfterID", mock.Anything, int64(42), int64(7), inbox.ReplayLimit+1).Return(newNotifications(8, 9), nil)
+		repositoryMock.On("Save", mock.Anything, mock.Anything).Return(inbox.Notification{ID: 10, CustomerID: 42, Type: "acquired_ticket"}, nil).Once()
+		repositoryMock.On("Save", mock.Anything, mock.Anything).Return(inbox.Notification{ID: 11, CustomerID: 43, Type: "acquired_ticket"}, nil).Once()
+
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger:                 logrus.New(),
+			NotificationRepository: repositoryMock,
+			Hub:                    hub,
+		})
+
+		stream, err := uc.Stream(newCustomerContext(), "7")
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		assert.Len(t, stream.Replay, 2)
+		assert.Equal(t, int64(8), stream.Replay[0].ID)
+
+		// a replayed notification published late is not sent twice.
+		hub.Publish(context.Background(), "42", []byte(`{"id":9}`))
+		assert.NoError(t, uc.Write(context.Background(), 43, "acquired_ticket", "Your ticket is ready", "Concert", nil))
+		assert.NoError(t, uc.Write(context.Background(), 42, "acquired_ticket", "Your ticket is ready", "Concert", nil))
+
+		n := receiveNotification(t, stream)
+		assert.Equal(t, int64(10), n.ID)
+		assert.Equal(t, "acquired_ticket", n.Type)
+	})
+
+	t.Run("end the stream after a truncated replay", func(t *testing.T) {
+		hub := realtime.NewLocalHub(realtime.LocalHubProperty{MaxSubscriptionsPerKey: 1})
+		defer hub.Close()
+
+		ids := make([]int64, inbox.ReplayLimit+1)
+		for i := range ids {
+			ids[i] = int64(8 + i)
+		}
+		repositoryMock := &mocks.NotificationRepository{}
+		repositoryMock.On("FindAfterID", mock.Anything, int64(42), int64(7), inbox.ReplayLimit+1).Return(newNotifications(ids...), nil)
+
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger:                 logrus.New(),
+			NotificationRepository: repositoryMock,
+			Hub:                    hub,
+		})
+
+		stream, err := uc.Stream(newCustomerContext(), "7")
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		assert.Len(t, stream.Replay, inbox.ReplayLimit)
+		assert.Equal(t, int64(7+inbox.ReplayLimit), stream.Replay[inbox.ReplayLimit-1].ID)
+
+		// the client resumes from the last replayed notification.
+		_, ok := <-stream.Notifications
+		assert.False(t, ok)
+
+		// the subscription is released for the resumed stream.
+		stream, err = uc.Stream(newCustomerContext(), "")
+		assert.NoError(t, err)
+		stream.Close()
+	})
+
+	t.Run("limit the streams of a customer", func(t *testing.T) {
+		hub := realtime.NewLocalHub(realtime.LocalHubProperty{MaxSubscriptionsPerKey: 1})
+		defer hub.Close()
+
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger: logrus.New(),
+			Hub:    hub,
+		})
+
+		stream, err := uc.Stream(newCustomerContext(), "")
+		assert.NoError(t, err)
+		assert.Empty(t, stream.Replay)
+
+		_, err = uc.Stream(newCustomerContext(), "")
+		assert.True(t, errors.MatchStatus(err, status.TOO_MANY_REQUESTS))
+
+		stream.Close()
+		_, ok := <-stream.Notifications
+		assert.False(t, ok)
+
+		stream, err = uc.Stream(newCustomerContext(), "")
+		assert.NoError(t, err)
+		stream.Close()
+	})
+
+	t.Run("reject an invalid last event id", func(t *testing.T) {
+		uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+			Logger: logrus.New(),
+			Hub:    realtime.NewLocalHub(realtime.LocalHubProperty{}),
+		})
+
+		_, err := uc.Stream(newCustomerContext(), "abc")
+		assert.True(t, errors.MatchStatus(err, status.BAD_REQUEST))
+	})
+}
@@ -0,0 +1,182 @@
+package inbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+)
+
+const (
+	// writeWait is the time allowed to write a frame.
+	writeWait = 10 * time.Second
+	// pongWait is the time allowed to read the pong of the peer.
+	pongWait = 60 * time.Second
+	// pingPeriod must be less than pongWait.
+	pingPeriod = pongWait * 9 / 10
+	// maxFrameSize limits the frames of the client, only the small acks are
+	// expected.
+	maxFrameSize = 4096
+)
+
+type WebSocketHandler struct {
+	Logger            *logrus.Logger
+	SessionMiddleware *middleware.CustomerSession
+	Upgrader          websocket.Upgrader
+	InboxUseCase      InboxUseCase
+}
+
+// InitWebSocketHandler serves the notifications over a WebSocket. The handshake
+// of a browser is only accepted from the allowed origins, the mobile apps send
+// no origin. A browser authenticates with the token subprotocol, see
+// middleware.WebSocketTokenProtocol.
+func InitWebSocketHandler(router *mux.Router, logger *logrus.Logger, customerSession *middleware.CustomerSession, allowedOrigins []string, inboxUseCase InboxUseCase) {
+	origins := make(map[string]bool)
+	for _, o := range allowedOrigins {
+		origins[o] = true
+	}
+
+	handler := &WebSocketHandler{
+		Logger:            logger,
+		SessionMiddleware: customerSession,
+		Upgrader: websocket.Upgrader{
+			// the browser fails the handshake unless the offered token
+			// subprotocol is selected.
+			Subprotocols: []string{middleware.WebSocketTokenProtocol},
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || origins["*"] || origins[origin]
+			},
+		},
+		InboxUseCase: inboxUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/customer/notifications/ws", handler.SessionMiddleware.VerifyWebSocket(handler.WebSocket)).Methods(http.MethodGet)
+}
+
+// WebSocket sends the new notifications as JSON frames and marks the
+// notifications acknowledged by the client as read. A client resumes with the
+// `last_event_id` query, the id of the last notification it received.
+func (handler WebSocketHandler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stream, err := handler.InboxUseCase.Stream(ctx, r.URL.Query().Get("last_event_id"))
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+	defer stream.Close()
+
+	conn, err := handler.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has responded.
+		return
+	}
+	defer conn.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	replies := make(chan Frame)
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+
+		conn.SetReadLimit(maxFrameSize)
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					handler.Logger.WithContext(ctx).WithError(err).Warn()
+				}
+				return
+			}
+
+			select {
+			case replies <- handler.reply(r, message):
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	write := func(f Frame) error {
+		conn.SetWriteDeadline(time.Now().Add(writeWait))
+		return conn.WriteJSON(f)
+	}
+	notify := func(n NotificationResponse) error {
+		return write(Frame{Type: FrameNotification, ID: n.ID, Notification: &n})
+	}
+
+	for _, n := range stream.Replay {
+		if err := notify(n); err != nil {
+			return
+		}
+	}
+
+	ping := time.NewTicker(pingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-readerDone:
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		case f := <-replies:
+			if err := write(f); err != nil {
+				return
+			}
+		case n, ok := <-stream.Notifications:
+			if !ok {
+				// the client resumes from the last notification.
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "stream is behind"), time.Now().Add(writeWait))
+				return
+			}
+			if err := notify(n); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// reply handles a frame of the client.
+func (handler WebSocketHandler) reply(r *http.Request, message []byte) Frame {
+	var f Frame
+	if err := json.Unmarshal(message, &f); err != nil {
+		return Frame{Type: FrameError, Error: "invalid frame"}
+	}
+
+	switch f.Type {
+	case FrameRead:
+		if _, err := handler.InboxUseCase.MarkRead(r.Context(), f.ID); err != nil {
+			ae := errors.Destruct(err)
+			if ae.Message == "" {
+				return Frame{Type: FrameError, ID: f.ID, Error: ae.Status}
+			}
+			return Frame{Type: FrameError, ID: f.ID, Error: ae.Message}
+		}
+		return Frame{Type: FrameRead, ID: f.ID}
+	default:
+		return Frame{Type: FrameError, ID: f.ID, Error: "unknown frame type"}
+	}
+}
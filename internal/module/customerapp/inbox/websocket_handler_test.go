@@ -0,0 +1,82 @@
+package inbox_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/inbox"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/inbox/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/realtime"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+func TestWebSocketHandler_WebSocket(t *testing.T) {
+	hub := realtime.NewLocalHub(realtime.LocalHubProperty{})
+	defer hub.Close()
+
+	repositoryMock := &mocks.NotificationRepository{}
+	repositoryMock.On("FindAfterID", mock.Anything, int64(42), int64(7), inbox.ReplayLimit+1).Return(newNotifications(8), nil)
+	repositoryMock.On("Save", mock.Anything, mock.Anything).Return(inbox.Notification{ID: 9, CustomerID: 42, Type: "acquired_ticket"}, nil)
+	repositoryMock.On("MarkRead", mock.Anything, int64(42), int64(9), mock.Anything).Return(inbox.Notification{ID: 9}, nil)
+	repositoryMock.On("MarkRead", mock.Anything, int64(42), int64(99), mock.Anything).Return(inbox.Notification{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "notification is not found"))
+
+	uc := inbox.NewInboxUseCase(inbox.InboxUseCaseProperty{
+		Logger:                 logrus.New(),
+		NotificationRepository: repositoryMock,
+		Hub:                    hub,
+	})
+	handler := inbox.WebSocketHandler{
+		Logger:       logrus.New(),
+		Upgrader:     websocket.Upgrader{Subprotocols: []string{middleware.WebSocketTokenProtocol}},
+		InboxUseCase: uc,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.WebSocket(w, r.WithContext(newCustomerContext()))
+	}))
+	defer srv.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{middleware.WebSocketTokenProtocol, "token"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"?last_event_id=7", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, middleware.WebSocketTokenProtocol, conn.Subprotocol())
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	readFrame := func() inbox.Frame {
+		var f inbox.Frame
+		assert.NoError(t, conn.ReadJSON(&f))
+		return f
+	}
+
+	f := readFrame()
+	assert.Equal(t, inbox.FrameNotification, f.Type)
+	assert.Equal(t, int64(8), f.Notification.ID)
+
+	assert.NoError(t, uc.Write(context.Background(), 42, "acquired_ticket", "Your ticket is ready", "Concert", nil))
+	f = readFrame()
+	assert.Equal(t, inbox.FrameNotification, f.Type)
+	assert.Equal(t, int64(9), f.ID)
+
+	assert.NoError(t, conn.WriteJSON(inbox.Frame{Type: inbox.FrameRead, ID: 9}))
+	f = readFrame()
+	assert.Equal(t, inbox.Frame{Type: inbox.FrameRead, ID: 9}, f)
+
+	assert.NoError(t, conn.WriteJSON(inbox.Frame{Type: inbox.FrameRead, ID: 99}))
+	f = readFrame()
+	assert.Equal(t, inbox.Frame{Type: inbox.FrameError, ID: 99, Error: "notification is not found"}, f)
+
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	f = readFrame()
+	assert.Equal(t, inbox.Frame{Type: inbox.FrameError, Error: "invalid frame"}, f)
+}
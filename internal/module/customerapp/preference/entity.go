@@ -0,0 +1,17 @@
+package preference
+
+import "time"
+
+// AllTypes is the type of a preference applying to every type of
+// notification, a preference of the type itself takes precedence.
+const AllTypes = "*"
+
+// Preference tells whether the customer receives a type of notification on a
+// channel.
+type Preference struct {
+	CustomerID int64
+	Type       string
+	Channel    string
+	Enabled    bool
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,90 @@
+package preference
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.CustomerSession
+	Validate          *validator.Validate
+	PreferenceUseCase PreferenceUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, customerSession *middleware.CustomerSession, validate *validator.Validate, preferenceUseCase PreferenceUseCase) {
+	handler := &HTTPHandler{
+		SessionMiddleware: customerSession,
+		Validate:          validate,
+		PreferenceUseCase: preferenceUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/customer/notification-preferences", handler.SessionMiddleware.Verify(handler.GetPreferences)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/customer/notification-preferences", handler.SessionMiddleware.Verify(handler.UpdatePreferences)).Methods(http.MethodPut)
+}
+
+// GetPreferences lists the notification preferences of the customer.
+func (handler HTTPHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.PreferenceUseCase.GetPreferences(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "notification preferences",
+		Data:    resp,
+	})
+}
+
+// UpdatePreferences enables or disables the channels of the notifications,
+// the preferences not in the request are kept.
+func (handler HTTPHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	if err := handler.Validate.StructCtx(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp, err := handler.PreferenceUseCase.UpdatePreferences(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "notification preferences are updated",
+		Data:    resp,
+	})
+}
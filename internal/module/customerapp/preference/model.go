@@ -0,0 +1,22 @@
+package preference
+
+import "time"
+
+type PreferenceRequest struct {
+	// Type is the type of notification, e.g. `acquired_ticket`, or `*` for
+	// every type.
+	Type    string `json:"type" validate:"required,max=64"`
+	Channel string `json:"channel" validate:"required,oneof=email sms push whatsapp inbox"`
+	Enabled *bool  `json:"enabled" validate:"required"`
+}
+
+type UpdatePreferencesRequest struct {
+	Preferences []PreferenceRequest `json:"preferences" validate:"required,min=1,max=50,dive"`
+}
+
+type PreferenceResponse struct {
+	Type      string    `json:"type"`
+	Channel   string    `json:"channel"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
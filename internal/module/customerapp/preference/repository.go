@@ -0,0 +1,90 @@
+package preference
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type PreferenceRepository interface {
+	// Save upserts the preferences at once.
+	Save(ctx context.Context, preferences []Preference) error
+	FindByCustomerID(ctx context.Context, customerID int64) ([]Preference, error)
+}
+
+type preferenceRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewPreferenceRepository(logger *logrus.Logger, db *sql.DB) PreferenceRepository {
+	return &preferenceRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Save implements PreferenceRepository.
+func (r *preferenceRepository) Save(ctx context.Context, preferences []Preference) error {
+	query := `
+		INSERT INTO notification_preference (customer_id, type, channel, enabled, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (customer_id, type, channel) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer tx.Rollback()
+
+	for _, p := range preferences {
+		if _, err := tx.ExecContext(ctx, query, p.CustomerID, p.Type, p.Channel, p.Enabled, p.UpdatedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// FindByCustomerID implements PreferenceRepository.
+func (r *preferenceRepository) FindByCustomerID(ctx context.Context, customerID int64) ([]Preference, error) {
+	query := `SELECT customer_id, type, channel, enabled, updated_at FROM notification_preference WHERE customer_id = $1 ORDER BY type, channel`
+
+	rows, err := r.db.QueryContext(ctx, query, customerID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	preferences := make([]Preference, 0)
+	for rows.Next() {
+		var p Preference
+		if err := rows.Scan(&p.CustomerID, &p.Type, &p.Channel, &p.Enabled, &p.UpdatedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		preferences = append(preferences, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,111 @@
+package preference
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+)
+
+type PreferenceUseCase interface {
+	GetPreferences(ctx context.Context) ([]PreferenceResponse, error)
+	UpdatePreferences(ctx context.Context, req UpdatePreferencesRequest) ([]PreferenceResponse, error)
+	// DisabledChannels returns the channels the customer opted out of for the
+	// type of notification, it is used by the notification dispatcher.
+	DisabledChannels(ctx context.Context, customerID int64, notificationType string) (map[string]bool, error)
+}
+
+type PreferenceUseCaseProperty struct {
+	AppName              string
+	Logger               *logrus.Logger
+	PreferenceRepository PreferenceRepository
+}
+
+type preferenceUseCase struct {
+	appName              string
+	logger               *logrus.Logger
+	preferenceRepository PreferenceRepository
+}
+
+func NewPreferenceUseCase(props PreferenceUseCaseProperty) PreferenceUseCase {
+	return &preferenceUseCase{
+		appName:              props.AppName,
+		logger:               props.Logger,
+		preferenceRepository: props.PreferenceRepository,
+	}
+}
+
+// GetPreferences implements PreferenceUseCase. A channel without preference is
+// enabled.
+func (u *preferenceUseCase) GetPreferences(ctx context.Context) ([]PreferenceResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := u.preferenceRepository.FindByCustomerID(ctx, acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]PreferenceResponse, len(preferences))
+	for i, p := range preferences {
+		resp[i] = PreferenceResponse{
+			Type:      p.Type,
+			Channel:   p.Channel,
+			Enabled:   p.Enabled,
+			UpdatedAt: p.UpdatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+// UpdatePreferences implements PreferenceUseCase.
+func (u *preferenceUseCase) UpdatePreferences(ctx context.Context, req UpdatePreferencesRequest) ([]PreferenceResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	preferences := make([]Preference, len(req.Preferences))
+	for i, p := range req.Preferences {
+		preferences[i] = Preference{
+			CustomerID: acc.ID,
+			Type:       p.Type,
+			Channel:    p.Channel,
+			Enabled:    *p.Enabled,
+			UpdatedAt:  now,
+		}
+	}
+
+	if err := u.preferenceRepository.Save(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return u.GetPreferences(ctx)
+}
+
+// DisabledChannels implements PreferenceUseCase.
+func (u *preferenceUseCase) DisabledChannels(ctx context.Context, customerID int64, notificationType string) (map[string]bool, error) {
+	preferences, err := u.preferenceRepository.FindByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	disabled := make(map[string]bool)
+	for _, p := range preferences {
+		if p.Type == AllTypes {
+			disabled[p.Channel] = !p.Enabled
+		}
+	}
+	for _, p := range preferences {
+		if p.Type == notificationType {
+			disabled[p.Channel] = !p.Enabled
+		}
+	}
+
+	return disabled, nil
+}
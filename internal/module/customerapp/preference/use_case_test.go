@@ -0,0 +1,86 @@
+package preference_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/preference"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/preference/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+func newCustomerContext() context.Context {
+	return context.WithValue(context.Background(), session.AccountContextKey{}, session.Account{
+		ID:   42,
+		Name: "John Doe",
+		Type: "CUSTOMER",
+	})
+}
+
+func TestPreferenceUseCase_UpdatePreferences(t *testing.T) {
+	t.Run("save the preferences of the customer", func(t *testing.T) {
+		disabled := false
+		saved := []preference.Preference{{CustomerID: 42, Type: preference.AllTypes, Channel: "sms", Enabled: false, UpdatedAt: time.Now()}}
+
+		repositoryMock := &mocks.PreferenceRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(preferences []preference.Preference) bool {
+			return len(preferences) == 1 &&
+				preferences[0].CustomerID == 42 &&
+				preferences[0].Type == preference.AllTypes &&
+				preferences[0].Channel == "sms" &&
+				!preferences[0].Enabled &&
+				!preferences[0].UpdatedAt.IsZero()
+		})).Return(nil)
+		repositoryMock.On("FindByCustomerID", mock.Anything, int64(42)).Return(saved, nil)
+
+		uc := preference.NewPreferenceUseCase(preference.PreferenceUseCaseProperty{
+			Logger:               logrus.New(),
+			PreferenceRepository: repositoryMock,
+		})
+
+		resp, err := uc.UpdatePreferences(newCustomerContext(), preference.UpdatePreferencesRequest{
+			Preferences: []preference.PreferenceRequest{{Type: preference.AllTypes, Channel: "sms", Enabled: &disabled}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []preference.PreferenceResponse{{Type: preference.AllTypes, Channel: "sms", Enabled: false, UpdatedAt: saved[0].UpdatedAt}}, resp)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("return forbidden without a customer session", func(t *testing.T) {
+		uc := preference.NewPreferenceUseCase(preference.PreferenceUseCaseProperty{
+			Logger: logrus.New(),
+		})
+
+		_, err := uc.UpdatePreferences(context.Background(), preference.UpdatePreferencesRequest{})
+		assert.True(t, errors.MatchStatus(err, status.FORBIDDEN))
+	})
+}
+
+func TestPreferenceUseCase_DisabledChannels(t *testing.T) {
+	repositoryMock := &mocks.PreferenceRepository{}
+	repositoryMock.On("FindByCustomerID", mock.Anything, int64(42)).Return([]preference.Preference{
+		{Type: "acquired_ticket", Channel: "sms", Enabled: true},
+		{Type: preference.AllTypes, Channel: "sms", Enabled: false},
+		{Type: preference.AllTypes, Channel: "push", Enabled: false},
+		{Type: "sign_up", Channel: "inbox", Enabled: false},
+	}, nil)
+
+	uc := preference.NewPreferenceUseCase(preference.PreferenceUseCaseProperty{
+		Logger:               logrus.New(),
+		PreferenceRepository: repositoryMock,
+	})
+
+	disabled, err := uc.DisabledChannels(context.Background(), 42, "acquired_ticket")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"sms": false, "push": true}, disabled)
+
+	disabled, err = uc.DisabledChannels(context.Background(), 42, "sign_up")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"sms": true, "push": true, "inbox": true}, disabled)
+}
@@ -0,0 +1,77 @@
+package ticket
+
+import "time"
+
+// IssuedTicket is the record of a ticket pdf issued to a customer, it keeps
+// what is needed to render the ticket again.
+type IssuedTicket struct {
+	Number        string
+	TicketID      int64
+	CustomerID    int64
+	CustomerName  string
+	CustomerEmail string
+	EventID       string
+	ShowID        string
+	OrderID       string
+	Tier          string
+	EventName     string
+	ShowVenue     string
+	ShowCountry   string
+	ShowCity      string
+	ShowTime      time.Time
+	ShowTimezone  string
+	Locale        string
+	ObjectKey     string
+	IssuedAt      time.Time
+	UpdatedAt     time.Time
+}
+
+// The statuses of a pending order.
+const (
+	OrderStatusPending = "pending"
+	OrderStatusSent    = "sent"
+)
+
+// PendingOrder is an order whose tickets are collected to be sent in one
+// notification, it is sent once its expected count of tickets is recorded or
+// it is due.
+type PendingOrder struct {
+	OrderID string
+	// Expected is the number of tickets of the order, it is zero when unknown.
+	Expected int
+	Status   string
+	DueAt    time.Time
+	// ClaimedUntil hides the order from the other workers while it is sent.
+	ClaimedUntil *time.Time
+	SentAt       *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Tickets      []PendingOrderTicket
+}
+
+// Complete tells whether every expected ticket of the order is recorded.
+func (o PendingOrder) Complete() bool {
+	return o.Expected > 0 && len(o.Tickets) >= o.Expected
+}
+
+// Has tells whether the ticket is recorded in the order.
+func (o PendingOrder) Has(number string) bool {
+	for _, t := range o.Tickets {
+		if t.TicketNumber == number {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PendingOrderTicket is an issued ticket of a pending order, it keeps what is
+// needed to notify the customer.
+type PendingOrderTicket struct {
+	OrderID      string
+	TicketNumber string
+	Event        AcquireTicketEvent
+	Code         string
+	ObjectKey    string
+	CreatedAt    time.Time
+}
@@ -16,9 +16,15 @@ type AcquireTicketEvent struct {
 	ShowCity             string
 	ShowFormattedAddress string
 	ShowTime             time.Time
+	ShowTimezone         string
 	CustomerName         string
 	CustomerEmail        string
 	CustomerID           int64
+	CustomerPhoneNumber  string
 	CreatedAt            time.Time
 	OrderID              string
+	Locale               string
+	// OrderTicketCount is the number of tickets in the order, the tickets are
+	// sent once all of them are acquired. It is optional.
+	OrderTicketCount int
 }
@@ -0,0 +1,91 @@
+package ticket
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.CustomerSession
+	TicketUseCase     TicketUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, customerSession *middleware.CustomerSession, ticketUseCase TicketUseCase) {
+	handler := &HTTPHandler{
+		SessionMiddleware: customerSession,
+		TicketUseCase:     ticketUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/customer/tickets", handler.SessionMiddleware.Verify(handler.GetTickets)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/customer/tickets/{number}/pdf", handler.SessionMiddleware.Verify(handler.DownloadTicket)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/customer/tickets/{number}/reissue", handler.SessionMiddleware.Verify(handler.ReissueTicket)).Methods(http.MethodPost)
+}
+
+func (handler HTTPHandler) GetTickets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.TicketUseCase.GetTickets(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of issued tickets",
+		Data:    resp,
+	})
+}
+
+// DownloadTicket streams the ticket pdf.
+func (handler HTTPHandler) DownloadTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	number := mux.Vars(r)["number"]
+
+	body, err := handler.TicketUseCase.DownloadTicket(ctx, number)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, number))
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, body)
+}
+
+func (handler HTTPHandler) ReissueTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.TicketUseCase.ReissueTicket(ctx, mux.Vars(r)["number"])
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "ticket is reissued",
+		Data:    resp,
+	})
+}
@@ -0,0 +1,38 @@
+package ticket
+
+import "time"
+
+type TicketResponse struct {
+	Number       string    `json:"number"`
+	OrderID      string    `json:"order_id"`
+	EventID      string    `json:"event_id"`
+	ShowID       string    `json:"show_id"`
+	Tier         string    `json:"tier"`
+	EventName    string    `json:"event_name"`
+	ShowVenue    string    `json:"show_venue"`
+	ShowCountry  string    `json:"show_country"`
+	ShowCity     string    `json:"show_city"`
+	ShowTime     time.Time `json:"show_time"`
+	ShowTimezone string    `json:"show_timezone"`
+	IssuedAt     time.Time `json:"issued_at"`
+}
+
+type ReissueTicketResponse struct {
+	Number  string `json:"number"`
+	PDFLink string `json:"pdf_link"`
+}
+
+// AcquiredTicketWebhook is the data of the webhook sent to the organizer, it
+// carries no personal data of the customer.
+type AcquiredTicketWebhook struct {
+	TicketNumber string    `json:"ticket_number"`
+	EventID      string    `json:"event_id"`
+	ShowID       string    `json:"show_id"`
+	OrderID      string    `json:"order_id"`
+	Tier         string    `json:"tier"`
+	EventName    string    `json:"event_name"`
+	ShowVenue    string    `json:"show_venue"`
+	ShowTime     time.Time `json:"show_time"`
+	ShowTimezone string    `json:"show_timezone"`
+	AcquiredAt   time.Time `json:"acquired_at"`
+}
@@ -0,0 +1,221 @@
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+// The types of the notifications of the tickets.
+const (
+	NotificationTypeAcquiredTicket = "acquired_ticket"
+	NotificationTypeAcquiredOrder  = "acquired_order"
+	NotificationTypeShowReminder   = "show_reminder"
+)
+
+// acquiredNotification renders the issued tickets of one customer on every
+// channel. A single ticket gets the acquired ticket notification while the
+// tickets of an order are listed in one acquired order notification.
+type acquiredNotification struct {
+	event       AcquireTicketEvent
+	data        *mailtemplate.AcquiredOrderNotificationData
+	attachments []mailer.Attachment
+	// whatsAppTemplate is the name of the WhatsApp confirmation template.
+	whatsAppTemplate string
+}
+
+func (n acquiredNotification) notificationType() string {
+	if len(n.data.Tickets) > 1 {
+		return NotificationTypeAcquiredOrder
+	}
+
+	return NotificationTypeAcquiredTicket
+}
+
+// Email implements notification.EmailTemplate.
+func (n acquiredNotification) Email(ctx context.Context, r notification.Recipient) (notification.EmailContent, error) {
+	var mt mailtemplate.MailTemplate
+	var mtData mailtemplate.Data
+	if len(n.data.Tickets) == 1 {
+		mt = mailtemplate.NewAcquiredTicketNotificationTemplate(n.event.Locale)
+		mtData = &mailtemplate.AcquiredTicketNotificationData{
+			CustomerName:        n.event.CustomerName,
+			TicketPDFLink:       n.data.Tickets[0].TicketPDFLink,
+			GoogleWalletLink:    n.data.Tickets[0].GoogleWalletLink,
+			AppleWalletAttached: n.data.AppleWalletAttached,
+		}
+	} else {
+		mt = mailtemplate.NewAcquiredOrderNotificationTemplate(n.event.Locale)
+		mtData = n.data
+	}
+
+	mtBuff, err := mt.Populate(mtData)
+	if err != nil {
+		return notification.EmailContent{}, err
+	}
+
+	return notification.EmailContent{
+		Subject:     mt.Subject(),
+		Body:        mtBuff.Bytes(),
+		Attachments: n.attachments,
+	}, nil
+}
+
+// SMS implements notification.SMSTemplate. It confirms the ticket with its
+// link, or the number of tickets of an order.
+func (n acquiredNotification) SMS(ctx context.Context, r notification.Recipient) (string, error) {
+	e := n.event
+	localizer := mailtemplate.NewLocalizer(e.Locale)
+	showTime := localizer.FormatDateTime(e.ShowTime, e.ShowTimezone)
+	if len(n.data.Tickets) > 1 {
+		return fmt.Sprintf(localizer.T("sms_order_confirmation"), len(n.data.Tickets), e.EventName, showTime), nil
+	}
+
+	return fmt.Sprintf(localizer.T("sms_ticket_confirmation"), e.Number, e.EventName, showTime, n.data.Tickets[0].TicketPDFLink), nil
+}
+
+// Push implements notification.PushTemplate.
+func (n acquiredNotification) Push(ctx context.Context, r notification.Recipient) (push.Notification, error) {
+	e := n.event
+	localizer := mailtemplate.NewLocalizer(e.Locale)
+	showTime := localizer.FormatDateTime(e.ShowTime, e.ShowTimezone)
+
+	p := push.Notification{
+		Title: localizer.T("push_ticket_title"),
+		Body:  fmt.Sprintf(localizer.T("push_ticket_body"), e.EventName, showTime),
+		Data: map[string]string{
+			"type":          n.notificationType(),
+			"ticket_number": e.Number,
+			"order_id":      e.OrderID,
+		},
+	}
+	if len(n.data.Tickets) > 1 {
+		p.Title = localizer.T("push_order_title")
+		p.Body = fmt.Sprintf(localizer.T("push_order_body"), len(n.data.Tickets), e.EventName, showTime)
+		delete(p.Data, "ticket_number")
+	}
+
+	return p, nil
+}
+
+// WhatsApp implements notification.WhatsAppTemplate. It sends the confirmation
+// template followed by the pdf of every ticket.
+func (n acquiredNotification) WhatsApp(ctx context.Context, r notification.Recipient) ([]whatsapp.Message, error) {
+	e := n.event
+	localizer := mailtemplate.NewLocalizer(e.Locale)
+	numbers := make([]string, len(n.data.Tickets))
+	for i, t := range n.data.Tickets {
+		numbers[i] = t.TicketNumber
+	}
+
+	reference := e.Number
+	if len(n.data.Tickets) > 1 {
+		reference = e.OrderID
+	}
+
+	messages := make([]whatsapp.Message, 0, len(n.data.Tickets)+1)
+	messages = append(messages, whatsapp.Message{
+		Template: &whatsapp.Template{
+			Name:     n.whatsAppTemplate,
+			Language: localizer.Locale(),
+			Parameters: []string{
+				e.CustomerName,
+				e.EventName,
+				location(e.ShowVenue, e.ShowFormattedAddress),
+				localizer.FormatDateTime(e.ShowTime, e.ShowTimezone),
+				strings.Join(numbers, ", "),
+			},
+		},
+		Reference: reference,
+	})
+	for _, t := range n.data.Tickets {
+		messages = append(messages, whatsapp.Message{
+			Document: &whatsapp.Document{
+				Link:     t.TicketPDFLink,
+				Filename: fmt.Sprintf("%s.pdf", t.TicketNumber),
+				Caption:  fmt.Sprintf("%s - %s", t.EventName, t.Tier),
+			},
+			Reference: t.TicketNumber,
+		})
+	}
+
+	return messages, nil
+}
+
+// Inbox implements notification.InboxTemplate.
+func (n acquiredNotification) Inbox(ctx context.Context, r notification.Recipient) (notification.InboxContent, error) {
+	e := n.event
+	localizer := mailtemplate.NewLocalizer(e.Locale)
+	showTime := localizer.FormatDateTime(e.ShowTime, e.ShowTimezone)
+
+	content := notification.InboxContent{
+		Title: localizer.T("inbox_ticket_title"),
+		Body:  fmt.Sprintf(localizer.T("inbox_ticket_body"), e.Number, e.EventName, showTime),
+		Data: map[string]string{
+			"ticket_number": e.Number,
+			"order_id":      e.OrderID,
+			"event_id":      e.EventID,
+		},
+	}
+	if len(n.data.Tickets) > 1 {
+		content.Title = localizer.T("inbox_order_title")
+		content.Body = fmt.Sprintf(localizer.T("inbox_order_body"), len(n.data.Tickets), e.EventName, showTime)
+		delete(content.Data, "ticket_number")
+	}
+
+	return content, nil
+}
+
+// reminderNotification reminds a customer of the upcoming show of the tickets,
+// the tickets share the customer and the show.
+type reminderNotification struct {
+	tickets []IssuedTicket
+}
+
+func (n reminderNotification) data() map[string]string {
+	t := n.tickets[0]
+	data := map[string]string{
+		"event_id": t.EventID,
+		"show_id":  t.ShowID,
+	}
+	if len(n.tickets) == 1 {
+		data["ticket_number"] = t.Number
+	}
+
+	return data
+}
+
+// Push implements notification.PushTemplate.
+func (n reminderNotification) Push(ctx context.Context, r notification.Recipient) (push.Notification, error) {
+	t := n.tickets[0]
+	localizer := mailtemplate.NewLocalizer(t.Locale)
+	showTime := localizer.FormatDateTime(t.ShowTime, t.ShowTimezone)
+
+	data := n.data()
+	data["type"] = NotificationTypeShowReminder
+
+	return push.Notification{
+		Title: localizer.T("push_reminder_title"),
+		Body:  fmt.Sprintf(localizer.T("push_reminder_body"), t.EventName, t.ShowVenue, showTime),
+		Data:  data,
+	}, nil
+}
+
+// Inbox implements notification.InboxTemplate.
+func (n reminderNotification) Inbox(ctx context.Context, r notification.Recipient) (notification.InboxContent, error) {
+	t := n.tickets[0]
+	localizer := mailtemplate.NewLocalizer(t.Locale)
+	showTime := localizer.FormatDateTime(t.ShowTime, t.ShowTimezone)
+
+	return notification.InboxContent{
+		Title: localizer.T("inbox_reminder_title"),
+		Body:  fmt.Sprintf(localizer.T("inbox_reminder_body"), t.EventName, t.ShowVenue, showTime),
+		Data:  n.data(),
+	}, nil
+}
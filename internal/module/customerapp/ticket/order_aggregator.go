@@ -0,0 +1,185 @@
+package ticket
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Settings of the order aggregator.
+const (
+	// OrderPollInterval is how often the due orders are looked up.
+	OrderPollInterval = 5 * time.Second
+	// OrderRetention is how long a sent order is kept to recognize its
+	// redelivered tickets.
+	OrderRetention = 7 * 24 * time.Hour
+)
+
+// orderClaimBatchSize is the number of due orders sent at once.
+const orderClaimBatchSize = 50
+
+// orderClaimLease is how long a claimed order is hidden from the other
+// workers, a failed order is retried after it.
+const orderClaimLease = time.Minute
+
+// acquiredTicket is an issued ticket waiting to be sent to the customer.
+type acquiredTicket struct {
+	event  AcquireTicketEvent
+	ticket IssuedTicket
+	code   string
+}
+
+// orderAggregator collects the tickets sharing an order id in the database, the
+// order is sent once its expected count of tickets arrives or the window since
+// its first ticket elapses. The replicas share the orders, an order is sent by
+// the one claiming it.
+type orderAggregator struct {
+	logger       *logrus.Logger
+	repository   PendingOrderRepository
+	window       time.Duration
+	pollInterval time.Duration
+	send         func(ctx context.Context, tickets []acquiredTicket) error
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+func newOrderAggregator(logger *logrus.Logger, repository PendingOrderRepository, window time.Duration, send func(ctx context.Context, tickets []acquiredTicket) error) *orderAggregator {
+	return &orderAggregator{
+		logger:       logger,
+		repository:   repository,
+		window:       window,
+		pollInterval: OrderPollInterval,
+		send:         send,
+		stop:         make(chan struct{}),
+	}
+}
+
+// add records the ticket in its order, the error is only nil once the ticket is
+// recorded or sent. The order is sent right away, within the caller, when the
+// ticket completes it.
+func (a *orderAggregator) add(ctx context.Context, t acquiredTicket) error {
+	now := time.Now()
+	pending := PendingOrderTicket{
+		OrderID:      t.event.OrderID,
+		TicketNumber: t.ticket.Number,
+		Event:        t.event,
+		Code:         t.code,
+		ObjectKey:    t.ticket.ObjectKey,
+		CreatedAt:    now,
+	}
+
+	o, err := a.repository.Add(ctx, pending, t.event.OrderTicketCount, now.Add(a.window))
+	if err != nil {
+		return err
+	}
+
+	if o.Status == OrderStatusSent {
+		if o.Has(pending.TicketNumber) {
+			// the event is redelivered after its order is sent.
+			return nil
+		}
+
+		// a ticket arriving after its order is sent goes on its own.
+		if err := a.send(ctx, []acquiredTicket{t}); err != nil {
+			return err
+		}
+		return a.repository.AddSent(ctx, pending)
+	}
+
+	if o.Complete() {
+		a.sendOrder(ctx, o.OrderID)
+	}
+
+	return nil
+}
+
+// sendOrder sends the order when it is claimed, a failure is left to be retried
+// by the poller.
+func (a *orderAggregator) sendOrder(ctx context.Context, orderID string) {
+	o, ok, err := a.repository.Claim(ctx, orderID, time.Now(), orderClaimLease)
+	if err != nil || !ok {
+		return
+	}
+
+	a.sendClaimed(ctx, o)
+}
+
+// sendDue sends the due orders and returns how many are claimed.
+func (a *orderAggregator) sendDue(ctx context.Context) (int, error) {
+	orders, err := a.repository.ClaimDue(ctx, time.Now(), orderClaimLease, orderClaimBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, o := range orders {
+		a.sendClaimed(ctx, o)
+	}
+
+	return len(orders), nil
+}
+
+func (a *orderAggregator) sendClaimed(ctx context.Context, o PendingOrder) {
+	logger := a.logger.WithContext(ctx).WithField("order_id", o.OrderID)
+
+	tickets := make([]acquiredTicket, 0, len(o.Tickets))
+	for _, t := range o.Tickets {
+		tickets = append(tickets, acquiredTicket{
+			event:  t.Event,
+			ticket: IssuedTicket{Number: t.TicketNumber, OrderID: t.OrderID, ObjectKey: t.ObjectKey},
+			code:   t.Code,
+		})
+	}
+	if len(tickets) == 0 {
+		return
+	}
+
+	if err := a.send(ctx, tickets); err != nil {
+		logger.WithError(err).Error()
+		return
+	}
+
+	if err := a.repository.MarkSent(ctx, o.OrderID, time.Now()); err != nil {
+		logger.WithError(err).Error()
+	}
+}
+
+// start sends the due orders periodically in background.
+func (a *orderAggregator) start() {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+
+		ticker := time.NewTicker(a.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.stop:
+				return
+			case <-ticker.C:
+			}
+
+			ctx := context.Background()
+			// keep going while there are more due orders than a batch.
+			for {
+				n, err := a.sendDue(ctx)
+				if err != nil || n < orderClaimBatchSize {
+					break
+				}
+			}
+
+			if err := a.repository.DeleteSentBefore(ctx, time.Now().Add(-OrderRetention)); err != nil {
+				a.logger.WithError(err).Warn()
+			}
+		}
+	}()
+}
+
+// close stops the background sending, the pending orders are sent by the next
+// poll of any replica.
+func (a *orderAggregator) close() {
+	close(a.stop)
+	a.wg.Wait()
+}
@@ -0,0 +1,269 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type PendingOrderRepository interface {
+	// Add records the ticket in its order and returns the order with its
+	// tickets. A new order is due at dueAt. The ticket is not recorded when the
+	// order is already sent.
+	Add(ctx context.Context, t PendingOrderTicket, expected int, dueAt time.Time) (PendingOrder, error)
+	// AddSent records a ticket sent on its own after its order is sent, so its
+	// redelivered event is not sent again.
+	AddSent(ctx context.Context, t PendingOrderTicket) error
+	// Claim returns the order with its tickets when it is pending, complete or
+	// due at now, and not claimed. It is hidden from the other workers by the
+	// lease.
+	Claim(ctx context.Context, orderID string, now time.Time, lease time.Duration) (PendingOrder, bool, error)
+	// ClaimDue claims the orders which are complete or due at now.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]PendingOrder, error)
+	MarkSent(ctx context.Context, orderID string, sentAt time.Time) error
+	// DeleteSentBefore deletes the orders sent before the time along with their
+	// tickets.
+	DeleteSentBefore(ctx context.Context, before time.Time) error
+}
+
+type pendingOrderRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewPendingOrderRepository(logger *logrus.Logger, db *sql.DB) PendingOrderRepository {
+	return &pendingOrderRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+const pendingOrderColumns = `order_id, expected, status, due_at, claimed_until, sent_at, created_at, updated_at`
+
+const pendingOrderTicketColumns = `order_id, ticket_number, event, code, object_key, created_at`
+
+func scanPendingOrder(s scanner) (PendingOrder, error) {
+	var o PendingOrder
+	err := s.Scan(&o.OrderID, &o.Expected, &o.Status, &o.DueAt, &o.ClaimedUntil, &o.SentAt, &o.CreatedAt, &o.UpdatedAt)
+	return o, err
+}
+
+func scanPendingOrderTicket(s scanner) (PendingOrderTicket, error) {
+	var (
+		t     PendingOrderTicket
+		event []byte
+	)
+	if err := s.Scan(&t.OrderID, &t.TicketNumber, &event, &t.Code, &t.ObjectKey, &t.CreatedAt); err != nil {
+		return t, err
+	}
+
+	return t, json.Unmarshal(event, &t.Event)
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Add implements PendingOrderRepository.
+func (r *pendingOrderRepository) Add(ctx context.Context, t PendingOrderTicket, expected int, dueAt time.Time) (PendingOrder, error) {
+	query := `
+		INSERT INTO pending_order (order_id, expected, status, due_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (order_id) DO UPDATE SET
+			expected = GREATEST(pending_order.expected, EXCLUDED.expected),
+			updated_at = EXCLUDED.updated_at
+		RETURNING ` + pendingOrderColumns
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return PendingOrder{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer tx.Rollback()
+
+	// the upsert locks the order until the ticket is recorded.
+	o, err := scanPendingOrder(tx.QueryRowContext(ctx, query, t.OrderID, expected, OrderStatusPending, dueAt, t.CreatedAt))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return PendingOrder{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	if o.Status == OrderStatusPending {
+		// a redelivered event replaces the recorded ticket instead of duplicating it.
+		if err := r.saveTicket(ctx, tx, t, true); err != nil {
+			return PendingOrder{}, err
+		}
+	}
+
+	if o.Tickets, err = r.findTickets(ctx, tx, o.OrderID); err != nil {
+		return PendingOrder{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return PendingOrder{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return o, nil
+}
+
+// AddSent implements PendingOrderRepository.
+func (r *pendingOrderRepository) AddSent(ctx context.Context, t PendingOrderTicket) error {
+	return r.saveTicket(ctx, r.db, t, false)
+}
+
+func (r *pendingOrderRepository) saveTicket(ctx context.Context, q querier, t PendingOrderTicket, replace bool) error {
+	event, err := json.Marshal(t.Event)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	query := `
+		INSERT INTO pending_order_ticket (` + pendingOrderTicketColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (order_id, ticket_number) DO NOTHING
+	`
+	if replace {
+		query = `
+			INSERT INTO pending_order_ticket (` + pendingOrderTicketColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (order_id, ticket_number) DO UPDATE SET
+				event = EXCLUDED.event,
+				code = EXCLUDED.code,
+				object_key = EXCLUDED.object_key
+		`
+	}
+
+	if _, err := q.ExecContext(ctx, query, t.OrderID, t.TicketNumber, event, t.Code, t.ObjectKey, t.CreatedAt); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// Claim implements PendingOrderRepository.
+func (r *pendingOrderRepository) Claim(ctx context.Context, orderID string, now time.Time, lease time.Duration) (PendingOrder, bool, error) {
+	orders, err := r.claim(ctx, orderID, now, lease, 1)
+	if err != nil || len(orders) == 0 {
+		return PendingOrder{}, false, err
+	}
+
+	return orders[0], true, nil
+}
+
+// ClaimDue implements PendingOrderRepository.
+func (r *pendingOrderRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]PendingOrder, error) {
+	return r.claim(ctx, "", now, lease, limit)
+}
+
+// claim claims the claimable orders, of every id when the order id is empty.
+func (r *pendingOrderRepository) claim(ctx context.Context, orderID string, now time.Time, lease time.Duration, limit int) ([]PendingOrder, error) {
+	query := `
+		UPDATE pending_order SET claimed_until = $2, updated_at = $1
+		WHERE order_id IN (
+			SELECT o.order_id FROM pending_order o
+			WHERE o.status = 'pending'
+				AND ($4 = '' OR o.order_id = $4)
+				AND (o.claimed_until IS NULL OR o.claimed_until <= $1)
+				AND (o.due_at <= $1 OR (o.expected > 0 AND o.expected <= (
+					SELECT COUNT(*) FROM pending_order_ticket t WHERE t.order_id = o.order_id
+				)))
+			ORDER BY o.due_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + pendingOrderColumns
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit, orderID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	orders := make([]PendingOrder, 0)
+	for rows.Next() {
+		o, err := scanPendingOrder(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		orders = append(orders, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	for i := range orders {
+		if orders[i].Tickets, err = r.findTickets(ctx, r.db, orders[i].OrderID); err != nil {
+			return nil, err
+		}
+	}
+
+	return orders, nil
+}
+
+func (r *pendingOrderRepository) findTickets(ctx context.Context, q querier, orderID string) ([]PendingOrderTicket, error) {
+	query := `SELECT ` + pendingOrderTicketColumns + ` FROM pending_order_ticket WHERE order_id = $1 ORDER BY created_at, ticket_number`
+
+	rows, err := q.QueryContext(ctx, query, orderID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	tickets := make([]PendingOrderTicket, 0)
+	for rows.Next() {
+		t, err := scanPendingOrderTicket(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		tickets = append(tickets, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return tickets, nil
+}
+
+// MarkSent implements PendingOrderRepository.
+func (r *pendingOrderRepository) MarkSent(ctx context.Context, orderID string, sentAt time.Time) error {
+	query := `UPDATE pending_order SET status = $2, sent_at = $3, claimed_until = NULL, updated_at = $3 WHERE order_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, orderID, OrderStatusSent, sentAt); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// DeleteSentBefore implements PendingOrderRepository.
+func (r *pendingOrderRepository) DeleteSentBefore(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM pending_order WHERE status = $1 AND sent_at < $2`
+
+	if _, err := r.db.ExecContext(ctx, query, OrderStatusSent, before); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+)
+
+type IssuedTicketRepository interface {
+	Save(ctx context.Context, t IssuedTicket) error
+	FindByCustomerID(ctx context.Context, customerID int64) ([]IssuedTicket, error)
+	FindByCustomerIDAndNumber(ctx context.Context, customerID int64, number string) (IssuedTicket, error)
+	// ClaimDueReminders claims the tickets not reminded yet whose show starts
+	// within the reminder after now. The tickets issued within the reminder
+	// before their show are left out, the customer was just notified of them.
+	// They are hidden from the other workers by the lease.
+	ClaimDueReminders(ctx context.Context, now time.Time, reminder, lease time.Duration, limit int) ([]IssuedTicket, error)
+	// MarkReminded records that the customers are reminded of the tickets.
+	MarkReminded(ctx context.Context, numbers []string, remindedAt time.Time) error
+}
+
+type issuedTicketRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewIssuedTicketRepository(logger *logrus.Logger, db *sql.DB) IssuedTicketRepository {
+	return &issuedTicketRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+const issuedTicketColumns = `
+	number, ticket_id, customer_id, customer_name, customer_email, event_id, show_id, order_id, tier,
+	event_name, show_venue, show_country, show_city, show_time, show_timezone, locale, object_key,
+	issued_at, updated_at`
+
+// Save implements IssuedTicketRepository. A ticket issued again, e.g. on a
+// redelivered event or a re-issue, replaces the existing record.
+func (r *issuedTicketRepository) Save(ctx context.Context, t IssuedTicket) error {
+	query := `
+		INSERT INTO issued_ticket (` + issuedTicketColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		ON CONFLICT (number) DO UPDATE SET
+			customer_name = EXCLUDED.customer_name,
+			customer_email = EXCLUDED.customer_email,
+			tier = EXCLUDED.tier,
+			event_name = EXCLUDED.event_name,
+			show_venue = EXCLUDED.show_venue,
+			show_country = EXCLUDED.show_country,
+			show_city = EXCLUDED.show_city,
+			show_time = EXCLUDED.show_time,
+			show_timezone = EXCLUDED.show_timezone,
+			locale = EXCLUDED.locale,
+			object_key = EXCLUDED.object_key,
+			updated_at = EXCLUDED.updated_at,
+			-- a rescheduled show is reminded again.
+			reminded_at = CASE
+				WHEN issued_ticket.show_time = EXCLUDED.show_time THEN issued_ticket.reminded_at
+			END
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		t.Number, t.TicketID, t.CustomerID, t.CustomerName, t.CustomerEmail, t.EventID, t.ShowID, t.OrderID, t.Tier,
+		t.EventName, t.ShowVenue, t.ShowCountry, t.ShowCity, t.ShowTime, t.ShowTimezone, t.Locale, t.ObjectKey,
+		t.IssuedAt, t.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// FindByCustomerID implements IssuedTicketRepository.
+func (r *issuedTicketRepository) FindByCustomerID(ctx context.Context, customerID int64) ([]IssuedTicket, error) {
+	query := `SELECT ` + issuedTicketColumns + ` FROM issued_ticket WHERE customer_id = $1 ORDER BY issued_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, customerID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	tickets := make([]IssuedTicket, 0)
+	for rows.Next() {
+		t, err := scanIssuedTicket(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		tickets = append(tickets, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return tickets, nil
+}
+
+// FindByCustomerIDAndNumber implements IssuedTicketRepository.
+func (r *issuedTicketRepository) FindByCustomerIDAndNumber(ctx context.Context, customerID int64, number string) (IssuedTicket, error) {
+	query := `SELECT ` + issuedTicketColumns + ` FROM issued_ticket WHERE customer_id = $1 AND number = $2`
+
+	t, err := scanIssuedTicket(r.db.QueryRowContext(ctx, query, customerID, number))
+	if err == sql.ErrNoRows {
+		return t, errors.New(http.StatusNotFound, status.NOT_FOUND, "ticket is not found")
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return t, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return t, nil
+}
+
+// ClaimDueReminders implements IssuedTicketRepository.
+func (r *issuedTicketRepository) ClaimDueReminders(ctx context.Context, now time.Time, reminder, lease time.Duration, limit int) ([]IssuedTicket, error) {
+	query := `
+		UPDATE issued_ticket SET reminder_claimed_until = $2
+		WHERE number IN (
+			SELECT number FROM issued_ticket
+			WHERE reminded_at IS NULL
+				AND show_time > $1 AND show_time <= $3
+				AND issued_at < show_time - make_interval(secs => $4)
+				AND (reminder_claimed_until IS NULL OR reminder_claimed_until <= $1)
+			ORDER BY show_time, customer_id, show_id
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + issuedTicketColumns
+
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), now.Add(reminder), reminder.Seconds(), limit)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+	defer rows.Close()
+
+	tickets := make([]IssuedTicket, 0)
+	for rows.Next() {
+		t, err := scanIssuedTicket(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		tickets = append(tickets, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return tickets, nil
+}
+
+// MarkReminded implements IssuedTicketRepository.
+func (r *issuedTicketRepository) MarkReminded(ctx context.Context, numbers []string, remindedAt time.Time) error {
+	query := `UPDATE issued_ticket SET reminded_at = $2, reminder_claimed_until = NULL WHERE number = ANY($1)`
+
+	if _, err := r.db.ExecContext(ctx, query, numbers, remindedAt); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanIssuedTicket(s scanner) (IssuedTicket, error) {
+	t := IssuedTicket{}
+	err := s.Scan(
+		&t.Number, &t.TicketID, &t.CustomerID, &t.CustomerName, &t.CustomerEmail, &t.EventID, &t.ShowID, &t.OrderID, &t.Tier,
+		&t.EventName, &t.ShowVenue, &t.ShowCountry, &t.ShowCity, &t.ShowTime, &t.ShowTimezone, &t.Locale, &t.ObjectKey,
+		&t.IssuedAt, &t.UpdatedAt,
+	)
+
+	return t, err
+}
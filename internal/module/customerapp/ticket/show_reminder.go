@@ -0,0 +1,142 @@
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+)
+
+// ReminderPollInterval is how often the due show reminders are looked up.
+const ReminderPollInterval = time.Minute
+
+// reminderClaimBatchSize is the number of tickets reminded at once.
+const reminderClaimBatchSize = 100
+
+// reminderClaimLease is how long a claimed ticket is hidden from the other
+// workers, a failed reminder is retried after it while the show is ahead.
+const reminderClaimLease = time.Minute
+
+// showReminder notifies the customers of their upcoming shows the reminder
+// before the shows start. The replicas share the tickets, a ticket is reminded
+// by the one claiming it.
+type showReminder struct {
+	logger       *logrus.Logger
+	repository   IssuedTicketRepository
+	dispatcher   notification.Dispatcher
+	reminder     time.Duration
+	pollInterval time.Duration
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+func newShowReminder(logger *logrus.Logger, repository IssuedTicketRepository, dispatcher notification.Dispatcher, reminder time.Duration) *showReminder {
+	return &showReminder{
+		logger:       logger,
+		repository:   repository,
+		dispatcher:   dispatcher,
+		reminder:     reminder,
+		pollInterval: ReminderPollInterval,
+		stop:         make(chan struct{}),
+	}
+}
+
+// sendDue reminds the customers of the due tickets and returns how many are
+// claimed. The tickets of a customer for the same show are reminded at once.
+func (s *showReminder) sendDue(ctx context.Context) (int, error) {
+	tickets, err := s.repository.ClaimDueReminders(ctx, time.Now(), s.reminder, reminderClaimLease, reminderClaimBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, 0)
+	shows := make(map[string][]IssuedTicket)
+	for _, t := range tickets {
+		show := t.ShowID
+		if show == "" {
+			show = t.Number
+		}
+
+		key := fmt.Sprintf("%d/%s", t.CustomerID, show)
+		if _, ok := shows[key]; !ok {
+			keys = append(keys, key)
+		}
+		shows[key] = append(shows[key], t)
+	}
+
+	for _, key := range keys {
+		s.send(ctx, shows[key])
+	}
+
+	return len(tickets), nil
+}
+
+// send reminds one customer of the tickets, a failure is left to be retried
+// once the claim expires.
+func (s *showReminder) send(ctx context.Context, tickets []IssuedTicket) {
+	t := tickets[0]
+	logger := s.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"customer_id": t.CustomerID,
+		"show_id":     t.ShowID,
+	})
+
+	if _, err := s.dispatcher.Dispatch(ctx, notification.Intent{
+		Recipient: notification.Recipient{
+			CustomerID: t.CustomerID,
+			Name:       t.CustomerName,
+			Email:      t.CustomerEmail,
+			Locale:     t.Locale,
+		},
+		Type: NotificationTypeShowReminder,
+		Data: reminderNotification{tickets: tickets},
+	}); err != nil {
+		logger.WithError(err).Error()
+		return
+	}
+
+	numbers := make([]string, len(tickets))
+	for i, t := range tickets {
+		numbers[i] = t.Number
+	}
+	if err := s.repository.MarkReminded(ctx, numbers, time.Now()); err != nil {
+		logger.WithError(err).Error()
+	}
+}
+
+// start reminds the customers periodically in background.
+func (s *showReminder) start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+
+			ctx := context.Background()
+			// keep going while there are more due tickets than a batch.
+			for {
+				n, err := s.sendDue(ctx)
+				if err != nil || n < reminderClaimBatchSize {
+					break
+				}
+			}
+		}
+	}()
+}
+
+// close stops the background reminding, the due tickets are reminded by the
+// next poll of any replica.
+func (s *showReminder) close() {
+	close(s.stop)
+	s.wg.Wait()
+}
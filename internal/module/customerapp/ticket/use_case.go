@@ -3,137 +3,556 @@ package ticket
 import (
 	"bytes"
 	"context"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
-	"cloud.google.com/go/storage"
-	"github.com/chromedp/cdproto/page"
-	"github.com/chromedp/chromedp"
 	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ical"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/pdf"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/storage"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/wallet"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
 )
 
+// DefaultLinkExpiry is how long the emailed ticket link is valid when no expiry
+// is configured.
+const DefaultLinkExpiry = 7 * 24 * time.Hour
+
+// DefaultShowDuration is the length of the show in the calendar invite when no
+// duration is configured.
+const DefaultShowDuration = 3 * time.Hour
+
+// DefaultReminder is how long before the show the customer is reminded when no
+// reminder is configured.
+const DefaultReminder = 24 * time.Hour
+
+// WebhookDispatcher queues a webhook to the endpoints registered for the event.
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, eventID, event string, data interface{}) error
+}
+
 type TicketUseCase interface {
 	OnAcquireTicket(ctx context.Context, e AcquireTicketEvent) error
+	GetTickets(ctx context.Context) ([]TicketResponse, error)
+	DownloadTicket(ctx context.Context, number string) (io.ReadCloser, error)
+	ReissueTicket(ctx context.Context, number string) (ReissueTicketResponse, error)
+	// SendDueOrders sends the orders whose window elapsed and returns how many
+	// are claimed.
+	SendDueOrders(ctx context.Context) (int, error)
+	// SendDueReminders reminds the customers of their shows starting within the
+	// reminder and returns how many tickets are claimed.
+	SendDueReminders(ctx context.Context) (int, error)
+	// Start sends the due orders and reminders periodically in background.
+	Start()
+	// Close stops the background sending.
+	Close()
 }
 
 type TicketUseCaseProperty struct {
-	AppName      string
-	Logger       *logrus.Logger
-	EmailSender  string
-	Mailer       mailer.Mailer
-	CloudStorage *storage.Client
+	AppName string
+	Logger  *logrus.Logger
+	// Dispatcher sends the issued tickets on the channels routed for their
+	// type, e.g. email and SMS.
+	Dispatcher             notification.Dispatcher
+	ObjectStore            storage.ObjectStore
+	IssuedTicketRepository IssuedTicketRepository
+	// LinkExpiry is how long the signed link to the ticket pdf is valid.
+	LinkExpiry   time.Duration
+	PDFRenderer  pdf.TicketRenderer
+	TicketSigner ticketcode.Signer
+	// Barcode prints the ticket code as a Code 128 barcode besides the QR code.
+	Barcode bool
+	// ApplePass attaches an Apple Wallet pass to the email when it is set.
+	ApplePass wallet.PassGenerator
+	// GoogleWallet adds a Google Wallet save link to the email when it is set.
+	GoogleWallet wallet.SaveLinkGenerator
+	// ShowDuration is the length of the show in the calendar invite.
+	ShowDuration time.Duration
+	// Reminder is how long before the show the calendar invite and the show
+	// reminder notification remind the customer.
+	Reminder time.Duration
+	// OrderWindow is how long the tickets of an order are collected to be sent
+	// in one email, every ticket is sent on its own when it is zero.
+	OrderWindow time.Duration
+	// PendingOrderRepository records the tickets of the orders within their
+	// window, it is required with OrderWindow.
+	PendingOrderRepository PendingOrderRepository
+	// WhatsAppTemplate is the name of the confirmation template, its body
+	// variables are the customer name, event name, venue, show time and ticket
+	// numbers.
+	WhatsAppTemplate string
+	// WebhookDispatcher notifies the organizer of the event of the acquired
+	// ticket when it is set.
+	WebhookDispatcher WebhookDispatcher
 }
 
 type ticketUseCase struct {
-	appName      string
-	logger       *logrus.Logger
-	emailSender  string
-	mailer       mailer.Mailer
-	cloudstorage *storage.Client
+	appName                string
+	logger                 *logrus.Logger
+	dispatcher             notification.Dispatcher
+	objectStore            storage.ObjectStore
+	issuedTicketRepository IssuedTicketRepository
+	linkExpiry             time.Duration
+	pdfRenderer            pdf.TicketRenderer
+	ticketSigner           ticketcode.Signer
+	barcode                bool
+	applePass              wallet.PassGenerator
+	googleWallet           wallet.SaveLinkGenerator
+	showDuration           time.Duration
+	reminder               time.Duration
+	orders                 *orderAggregator
+	reminders              *showReminder
+	whatsAppTemplate       string
+	webhookDispatcher      WebhookDispatcher
 }
 
-// OnAcquireTicket implements TicketUseCase.
+// OnAcquireTicket implements TicketUseCase. The ticket is issued right away,
+// when the order window is configured it is sent along with the other
+// tickets of its order.
 func (u *ticketUseCase) OnAcquireTicket(ctx context.Context, e AcquireTicketEvent) error {
-	tmt := mailtemplate.NewTicketTemplate()
-	tmtBuff := tmt.Populate(&mailtemplate.TicketData{
-		CustomerName: e.CustomerName,
-		EventName:    e.EventName,
-		Venue:        e.ShowVenue,
-		Country:      e.ShowCountry,
-		City:         e.ShowCity,
-		Tier:         e.Tier,
+	now := time.Now()
+	t := IssuedTicket{
+		Number:        e.Number,
+		TicketID:      e.ID,
+		CustomerID:    e.CustomerID,
+		CustomerName:  e.CustomerName,
+		CustomerEmail: e.CustomerEmail,
+		EventID:       e.EventID,
+		ShowID:        e.ShowID,
+		OrderID:       e.OrderID,
+		Tier:          e.Tier,
+		EventName:     e.EventName,
+		ShowVenue:     e.ShowVenue,
+		ShowCountry:   e.ShowCountry,
+		ShowCity:      e.ShowCity,
+		ShowTime:      e.ShowTime,
+		ShowTimezone:  e.ShowTimezone,
+		Locale:        e.Locale,
+		ObjectKey:     fmt.Sprintf("%s.pdf", e.Number),
+		IssuedAt:      now,
+		UpdatedAt:     now,
+	}
+
+	_, code, err := u.issue(ctx, t)
+	if err != nil {
+		return err
+	}
+
+	if err := u.issuedTicketRepository.Save(ctx, t); err != nil {
+		return err
+	}
+
+	if u.webhookDispatcher != nil {
+		u.dispatchWebhook(ctx, e, now)
+	}
+
+	acquired := acquiredTicket{event: e, ticket: t, code: code}
+	if u.orders != nil && e.OrderID != "" {
+		return u.orders.add(ctx, acquired)
+	}
+
+	return u.send(ctx, []acquiredTicket{acquired})
+}
+
+// dispatchWebhook notifies the organizer, the receiver is expected to
+// deduplicate by the ticket number as the event may be consumed again. A
+// failure is only logged.
+func (u *ticketUseCase) dispatchWebhook(ctx context.Context, e AcquireTicketEvent, acquiredAt time.Time) {
+	data := AcquiredTicketWebhook{
 		TicketNumber: e.Number,
-		DateTime:     e.ShowTime.Format(time.DateTime),
+		EventID:      e.EventID,
+		ShowID:       e.ShowID,
+		OrderID:      e.OrderID,
+		Tier:         e.Tier,
+		EventName:    e.EventName,
+		ShowVenue:    e.ShowVenue,
+		ShowTime:     e.ShowTime,
+		ShowTimezone: e.ShowTimezone,
+		AcquiredAt:   acquiredAt,
+	}
+
+	if err := u.webhookDispatcher.Dispatch(ctx, e.EventID, webhook.EventTicketAcquired, data); err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithField("event", e).Warn()
+	}
+}
+
+// SendDueOrders implements TicketUseCase.
+func (u *ticketUseCase) SendDueOrders(ctx context.Context) (int, error) {
+	if u.orders == nil {
+		return 0, nil
+	}
+
+	return u.orders.sendDue(ctx)
+}
+
+// SendDueReminders implements TicketUseCase.
+func (u *ticketUseCase) SendDueReminders(ctx context.Context) (int, error) {
+	return u.reminders.sendDue(ctx)
+}
+
+// Start implements TicketUseCase.
+func (u *ticketUseCase) Start() {
+	if u.orders != nil {
+		u.orders.start()
+	}
+	u.reminders.start()
+}
+
+// Close implements TicketUseCase.
+func (u *ticketUseCase) Close() {
+	if u.orders != nil {
+		u.orders.close()
+	}
+	u.reminders.close()
+}
+
+// send notifies one customer of the issued tickets, the links, the calendar
+// invite and the wallet passes are prepared once for every channel.
+func (u *ticketUseCase) send(ctx context.Context, tickets []acquiredTicket) error {
+	e := tickets[0].event
+
+	calendarName := e.Number
+	if len(tickets) > 1 {
+		calendarName = e.OrderID
+	}
+
+	data := &mailtemplate.AcquiredOrderNotificationData{
+		CustomerName: e.CustomerName,
+		OrderID:      e.OrderID,
+	}
+	attachments := make([]mailer.Attachment, 0)
+	attachments = append(attachments, mailer.Attachment{
+		Filename:    fmt.Sprintf("%s.ics", calendarName),
+		ContentType: ical.ContentType,
+		Content:     u.calendar(tickets),
 	})
 
-	ctx, cancel := chromedp.NewContext(ctx)
-	defer cancel()
+	for _, acquired := range tickets {
+		pdfUrl, err := u.objectStore.SignedURL(acquired.ticket.ObjectKey, u.linkExpiry)
+		if err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return err
+		}
+
+		ticket := mailtemplate.OrderTicketData{
+			TicketNumber:  acquired.event.Number,
+			EventName:     acquired.event.EventName,
+			Tier:          acquired.event.Tier,
+			TicketPDFLink: pdfUrl,
+		}
 
-	var pdfBytes []byte
-	err := chromedp.Run(ctx,
-		chromedp.Navigate("about:blank"),
-		chromedp.ActionFunc(func(ctx context.Context) error {
-			frameTree, err := page.GetFrameTree().Do(ctx)
+		walletTicket := wallet.Ticket{
+			Number:       acquired.event.Number,
+			Code:         acquired.code,
+			CustomerName: acquired.event.CustomerName,
+			EventID:      acquired.event.EventID,
+			EventName:    acquired.event.EventName,
+			Venue:        acquired.event.ShowVenue,
+			Address:      acquired.event.ShowFormattedAddress,
+			Tier:         acquired.event.Tier,
+			ShowTime:     acquired.event.ShowTime,
+		}
+
+		// the wallet passes are optional, the email is still sent without them.
+		if u.applePass != nil {
+			pass, err := u.applePass.Generate(walletTicket)
 			if err != nil {
-				u.logger.WithContext(ctx).WithError(err).Error()
-				return err
+				u.logger.WithContext(ctx).WithError(err).WithField("event", acquired.event).Warn()
+			} else {
+				attachments = append(attachments, mailer.Attachment{
+					Filename:    fmt.Sprintf("%s.pkpass", acquired.event.Number),
+					ContentType: "application/vnd.apple.pkpass",
+					Content:     pass,
+				})
+				data.AppleWalletAttached = true
 			}
-			return page.SetDocumentContent(frameTree.Frame.ID, tmtBuff.String()).Do(ctx)
-		}),
-		chromedp.ActionFunc(func(ctx context.Context) error {
-			printResult, _, err := page.PrintToPDF().WithPrintBackground(false).Do(ctx)
+		}
+		if u.googleWallet != nil {
+			link, err := u.googleWallet.SaveLink(walletTicket)
 			if err != nil {
-				u.logger.WithContext(ctx).WithError(err).Error()
-				return err
+				u.logger.WithContext(ctx).WithError(err).WithField("event", acquired.event).Warn()
+			} else {
+				ticket.GoogleWalletLink = link
 			}
+		}
+
+		data.Tickets = append(data.Tickets, ticket)
+	}
+
+	n := acquiredNotification{
+		event:            e,
+		data:             data,
+		attachments:      attachments,
+		whatsAppTemplate: u.whatsAppTemplate,
+	}
+	if _, err := u.dispatcher.Dispatch(ctx, notification.Intent{
+		Recipient: notification.Recipient{
+			CustomerID:  e.CustomerID,
+			Name:        e.CustomerName,
+			Email:       e.CustomerEmail,
+			PhoneNumber: e.CustomerPhoneNumber,
+			Locale:      e.Locale,
+		},
+		Type: n.notificationType(),
+		Data: n,
+	}); err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithField("event", e).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, err.Error())
+	}
+
+	return nil
+}
+
+// calendar builds the calendar invite with an event for each show of the
+// tickets, in the show's timezone or in UTC when it is unknown.
+func (u *ticketUseCase) calendar(tickets []acquiredTicket) []byte {
+	cal := ical.New("-//TicketMaster//Ticket//EN")
+
+	shows := make(map[string]int)
+	for _, acquired := range tickets {
+		e := acquired.event
+
+		description := fmt.Sprintf("%s - %s", e.Tier, e.Number)
+		if e.Tier == "" {
+			description = e.Number
+		}
 
-			pdfBytes = printResult
-			return nil
-		}),
-	)
+		show := e.ShowID
+		if show == "" {
+			show = e.Number
+		}
+		if i, ok := shows[show]; ok {
+			cal.Events[i].Description += "\n" + description
+			continue
+		}
+		shows[show] = len(cal.Events)
+
+		start := e.ShowTime.UTC()
+		if location, err := time.LoadLocation(e.ShowTimezone); e.ShowTimezone != "" && err == nil {
+			start = start.In(location)
+		}
+
+		cal.AddEvent(ical.Event{
+			// the uid stays the same for the ticket so a reissued invite updates
+			// the existing calendar entry.
+			UID:         fmt.Sprintf("%s@tsel-ticketmaster", e.Number),
+			Summary:     e.EventName,
+			Description: description,
+			Location:    location(e.ShowVenue, e.ShowFormattedAddress),
+			Start:       start,
+			Duration:    u.showDuration,
+			Reminder:    u.reminder,
+		})
+	}
+
+	return cal.Bytes()
+}
+
+func location(venue, address string) string {
+	switch {
+	case venue == "":
+		return address
+	case address == "":
+		return venue
+	default:
+		return fmt.Sprintf("%s, %s", venue, address)
+	}
+}
+
+// GetTickets implements TicketUseCase.
+func (u *ticketUseCase) GetTickets(ctx context.Context) ([]TicketResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
 	if err != nil {
-		u.logger.WithContext(ctx).WithError(err).Error()
-		return err
+		return nil, err
+	}
+
+	tickets, err := u.issuedTicketRepository.FindByCustomerID(ctx, acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]TicketResponse, len(tickets))
+	for i, t := range tickets {
+		resp[i] = TicketResponse{
+			Number:       t.Number,
+			OrderID:      t.OrderID,
+			EventID:      t.EventID,
+			ShowID:       t.ShowID,
+			Tier:         t.Tier,
+			EventName:    t.EventName,
+			ShowVenue:    t.ShowVenue,
+			ShowCountry:  t.ShowCountry,
+			ShowCity:     t.ShowCity,
+			ShowTime:     t.ShowTime,
+			ShowTimezone: t.ShowTimezone,
+			IssuedAt:     t.IssuedAt,
+		}
 	}
 
-	filename := fmt.Sprintf("%s.pdf", e.Number)
-	w := u.cloudstorage.Bucket("tsel-ticketmaster").Object(filename).NewWriter(ctx)
-	w.ChunkSize = 0
-	w.ContentType = "application/pdf"
+	return resp, nil
+}
+
+// DownloadTicket implements TicketUseCase. The pdf is rendered again when it
+// is no longer in the object store.
+func (u *ticketUseCase) DownloadTicket(ctx context.Context, number string) (io.ReadCloser, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	if _, err = io.Copy(w, bytes.NewBuffer(pdfBytes)); err != nil {
+	t, err := u.issuedTicketRepository.FindByCustomerIDAndNumber(ctx, acc.ID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := u.objectStore.Get(ctx, t.ObjectKey)
+	if err == nil {
+		return body, nil
+	}
+	if !stderrors.Is(err, storage.ErrObjectNotFound) {
 		u.logger.WithContext(ctx).WithError(err).Error()
-		return fmt.Errorf("io.Copy: %w", err)
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	pdfBytes, _, err := u.issue(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(bytes.NewReader(pdfBytes)), nil
+}
+
+// ReissueTicket implements TicketUseCase.
+func (u *ticketUseCase) ReissueTicket(ctx context.Context, number string) (ReissueTicketResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return ReissueTicketResponse{}, err
 	}
-	if err := w.Close(); err != nil {
+
+	t, err := u.issuedTicketRepository.FindByCustomerIDAndNumber(ctx, acc.ID, number)
+	if err != nil {
+		return ReissueTicketResponse{}, err
+	}
+
+	if _, _, err := u.issue(ctx, t); err != nil {
+		return ReissueTicketResponse{}, err
+	}
+
+	t.UpdatedAt = time.Now()
+	if err := u.issuedTicketRepository.Save(ctx, t); err != nil {
+		return ReissueTicketResponse{}, err
+	}
+
+	pdfUrl, err := u.objectStore.SignedURL(t.ObjectKey, u.linkExpiry)
+	if err != nil {
 		u.logger.WithContext(ctx).WithError(err).Error()
-		return fmt.Errorf("Writer.Close: %w", err)
+		return ReissueTicketResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
 	}
 
-	emailSubject := "Acquired Ticket"
-	recipients := make([]mailer.Recepient, 1)
-	recipients[0] = mailer.Recepient{
-		Address: e.CustomerEmail,
-		Name:    e.CustomerName,
+	return ReissueTicketResponse{
+		Number:  t.Number,
+		PDFLink: pdfUrl,
+	}, nil
+}
+
+// issue renders the ticket pdf and puts it in the object store, it returns the
+// pdf and the signed ticket code printed on it.
+func (u *ticketUseCase) issue(ctx context.Context, t IssuedTicket) ([]byte, string, error) {
+	code, err := u.ticketSigner.Sign(ticketcode.Payload{
+		TicketNumber: t.Number,
+		ShowID:       t.ShowID,
+		CustomerID:   t.CustomerID,
+		EventID:      t.EventID,
+		Tier:         t.Tier,
+	})
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return nil, "", errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, err.Error())
 	}
 
-	pdfUrl := fmt.Sprintf("https://storage.googleapis.com/tsel-ticketmaster/%s", filename)
-	data := &mailtemplate.AcquiredTicketNotificationData{
-		CustomerName:  e.CustomerName,
-		TicketPDFLink: pdfUrl,
+	ticketData := &mailtemplate.TicketData{
+		CustomerName: t.CustomerName,
+		EventName:    t.EventName,
+		Venue:        t.ShowVenue,
+		Country:      t.ShowCountry,
+		City:         t.ShowCity,
+		Tier:         t.Tier,
+		TicketNumber: t.Number,
+		ShowTime:     t.ShowTime,
+		Timezone:     t.ShowTimezone,
+		Code:         code,
+		Barcode:      u.barcode,
+	}
+	if err := mailtemplate.Validate(mailtemplate.Ticket, ticketData); err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithField("ticket", t).Error()
+		return nil, "", errors.New(http.StatusUnprocessableEntity, status.UNPROCESSABLE_ENTITY, err.Error())
 	}
 
-	mt := mailtemplate.NewAcquiredTicketNotificationTemplate()
-	mtBuff := mt.Populate(data)
+	pdfBytes, err := u.pdfRenderer.RenderTicket(ctx, t.Locale, ticketData)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return nil, "", err
+	}
 
-	if err := u.mailer.Send(context.TODO(), mailer.Message{
-		From:    u.emailSender,
-		To:      recipients,
-		Subject: emailSubject,
-		MessageBody: mailer.MessageBody{
-			ContentType: "text/html",
-			Body:        mtBuff.Bytes(),
-		},
+	if err := u.objectStore.Put(ctx, storage.Object{
+		Key:         t.ObjectKey,
+		ContentType: "application/pdf",
+		Body:        bytes.NewReader(pdfBytes),
 	}); err != nil {
-		u.logger.WithContext(ctx).WithError(err).WithField("event", e).Error()
-		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, err.Error())
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return nil, "", err
 	}
 
-	return nil
+	return pdfBytes, code, nil
 }
 
 func NewTicketUseCase(props TicketUseCaseProperty) TicketUseCase {
-	return &ticketUseCase{
-		appName:      props.AppName,
-		logger:       props.Logger,
-		emailSender:  props.EmailSender,
-		mailer:       props.Mailer,
-		cloudstorage: props.CloudStorage,
+	linkExpiry := props.LinkExpiry
+	if linkExpiry <= 0 {
+		linkExpiry = DefaultLinkExpiry
 	}
+
+	showDuration := props.ShowDuration
+	if showDuration <= 0 {
+		showDuration = DefaultShowDuration
+	}
+
+	reminder := props.Reminder
+	if reminder <= 0 {
+		reminder = DefaultReminder
+	}
+
+	u := &ticketUseCase{
+		appName:                props.AppName,
+		logger:                 props.Logger,
+		dispatcher:             props.Dispatcher,
+		objectStore:            props.ObjectStore,
+		issuedTicketRepository: props.IssuedTicketRepository,
+		linkExpiry:             linkExpiry,
+		pdfRenderer:            props.PDFRenderer,
+		ticketSigner:           props.TicketSigner,
+		barcode:                props.Barcode,
+		applePass:              props.ApplePass,
+		googleWallet:           props.GoogleWallet,
+		showDuration:           showDuration,
+		reminder:               reminder,
+		whatsAppTemplate:       props.WhatsAppTemplate,
+		webhookDispatcher:      props.WebhookDispatcher,
+	}
+	u.reminders = newShowReminder(props.Logger, props.IssuedTicketRepository, props.Dispatcher, reminder)
+	if props.OrderWindow > 0 {
+		u.orders = newOrderAggregator(props.Logger, props.PendingOrderRepository, props.OrderWindow, u.send)
+	}
+
+	return u
 }
@@ -0,0 +1,1110 @@
+package ticket_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/ticket"
+	ticketMocks "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/ticket/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ical"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/pdf"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sms"
+	smsMocks "github.com/tsel-ticketmaster/tm-notification/pkg/sms/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/storage"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/wallet"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+// newDispatcher routes the notifications to every given channel.
+func newDispatcher(channels ...notification.Channel) notification.Dispatcher {
+	names := make([]string, 0, len(channels))
+	for _, c := range channels {
+		names = append(names, c.Name())
+	}
+
+	return notification.NewDispatcher(notification.DispatcherProperty{
+		Logger:   logrus.New(),
+		Channels: channels,
+		Routes: map[string][]string{
+			ticket.NotificationTypeAcquiredTicket: names,
+			ticket.NotificationTypeAcquiredOrder:  names,
+		},
+		RequiredChannels: []string{notification.Email},
+	})
+}
+
+func newAcquireTicketEvent() ticket.AcquireTicketEvent {
+	return ticket.AcquireTicketEvent{
+		ID:            1,
+		Number:        "TICKET-1",
+		EventName:     "Concert",
+		ShowVenue:     "GBK",
+		Tier:          "VIP",
+		ShowTime:      time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC),
+		ShowTimezone:  "Asia/Jakarta",
+		CustomerName:  "John Doe",
+		CustomerEmail: "john@mail.com",
+	}
+}
+
+type fakePassGenerator struct{}
+
+func (fakePassGenerator) Generate(t wallet.Ticket) ([]byte, error) {
+	return []byte("pkpass of " + t.Number + " " + t.Code), nil
+}
+
+type fakeSaveLinkGenerator struct{}
+
+func (fakeSaveLinkGenerator) SaveLink(t wallet.Ticket) (string, error) {
+	return "https://pay.google.com/gp/v/save/" + t.Number, nil
+}
+
+func TestTicketUseCase_OnAcquireTicket(t *testing.T) {
+	t.Run("attach the apple wallet pass and link the google wallet", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(func(m mailer.Message) bool {
+			return len(m.Attachments) == 2 &&
+				m.Attachments[1].Filename == "TICKET-1.pkpass" &&
+				m.Attachments[1].ContentType == "application/vnd.apple.pkpass" &&
+				strings.HasPrefix(string(m.Attachments[1].Content), "pkpass of TICKET-1 ") &&
+				strings.Contains(string(m.MessageBody.Body), `href="https://pay.google.com/gp/v/save/TICKET-1"`)
+		})).Return(nil)
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:     logrus.New(),
+			Dispatcher: newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+			ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+				Dir:    t.TempDir(),
+				Secret: "secret",
+			}),
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+			ApplePass:              fakePassGenerator{},
+			GoogleWallet:           fakeSaveLinkGenerator{},
+		})
+
+		err := uc.OnAcquireTicket(context.Background(), newAcquireTicketEvent())
+		assert.NoError(t, err)
+
+		mailerMock.AssertExpectations(t)
+	})
+
+	t.Run("attach the calendar invite in the show timezone", func(t *testing.T) {
+		e := newAcquireTicketEvent()
+		e.ShowFormattedAddress = "Jl. Pintu Satu Senayan, Jakarta"
+
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(func(m mailer.Message) bool {
+			if len(m.Attachments) != 1 {
+				return false
+			}
+			invite := strings.ReplaceAll(string(m.Attachments[0].Content), "\r\n ", "")
+			return m.Attachments[0].Filename == "TICKET-1.ics" &&
+				m.Attachments[0].ContentType == ical.ContentType &&
+				strings.Contains(invite, "UID:TICKET-1@tsel-ticketmaster\r\n") &&
+				strings.Contains(invite, "DTSTART;TZID=Asia/Jakarta:20261010T193000\r\n") &&
+				strings.Contains(invite, "DTEND;TZID=Asia/Jakarta:20261010T213000\r\n") &&
+				strings.Contains(invite, "LOCATION:GBK\\, Jl. Pintu Satu Senayan\\, Jakarta\r\n") &&
+				strings.Contains(invite, "TRIGGER:-PT3H\r\n")
+		})).Return(nil)
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:     logrus.New(),
+			Dispatcher: newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+			ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+				Dir:    t.TempDir(),
+				Secret: "secret",
+			}),
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+			ShowDuration:           2 * time.Hour,
+			Reminder:               3 * time.Hour,
+		})
+
+		err := uc.OnAcquireTicket(context.Background(), e)
+		assert.NoError(t, err)
+
+		mailerMock.AssertExpectations(t)
+	})
+
+	t.Run("attach the calendar invite in UTC without a show timezone", func(t *testing.T) {
+		e := newAcquireTicketEvent()
+		e.ShowTimezone = ""
+
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(func(m mailer.Message) bool {
+			if len(m.Attachments) != 1 {
+				return false
+			}
+			invite := strings.ReplaceAll(string(m.Attachments[0].Content), "\r\n ", "")
+			return strings.Contains(invite, "DTSTART:20261010T123000Z\r\n") &&
+				!strings.Contains(invite, "BEGIN:VTIMEZONE")
+		})).Return(nil)
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:     logrus.New(),
+			Dispatcher: newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+			ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+				Dir:    t.TempDir(),
+				Secret: "secret",
+			}),
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+			ShowDuration:           2 * time.Hour,
+		})
+
+		err := uc.OnAcquireTicket(context.Background(), e)
+		assert.NoError(t, err)
+
+		mailerMock.AssertExpectations(t)
+	})
+
+	t.Run("store the pdf and email its signed link", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		renderer := pdf.NewFakeRenderer()
+		objectStore := storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir:           t.TempDir(),
+			KeyPrefix:     "tickets",
+			PublicBaseURL: "https://files.example.com",
+			Secret:        "secret",
+		})
+
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(func(m mailer.Message) bool {
+			return m.To[0].Address == "john@mail.com" &&
+				m.Subject == "Acquired Ticket" &&
+				strings.Contains(string(m.MessageBody.Body), "https://files.example.com/tickets/TICKET-1.pdf?expires=")
+		})).Return(nil)
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(it ticket.IssuedTicket) bool {
+			return it.Number == "TICKET-1" && it.ObjectKey == "TICKET-1.pdf" && !it.IssuedAt.IsZero()
+		})).Return(nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			Dispatcher:             newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+			ObjectStore:            objectStore,
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(renderer),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+		})
+
+		err := uc.OnAcquireTicket(context.Background(), newAcquireTicketEvent())
+		assert.NoError(t, err)
+
+		body, err := objectStore.Get(context.Background(), "TICKET-1.pdf")
+		assert.NoError(t, err)
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "%PDF-")
+
+		mailerMock.AssertExpectations(t)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("return error when the pdf can not be rendered", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		renderer := pdf.NewFakeRenderer()
+		renderer.Err = fmt.Errorf("chrome is not available")
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:       logrus.New(),
+			Dispatcher:   newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+			PDFRenderer:  pdf.NewHTMLTicketRenderer(renderer),
+			TicketSigner: ticketcode.NewHMACSigner("secret"),
+		})
+
+		err := uc.OnAcquireTicket(context.Background(), newAcquireTicketEvent())
+		assert.Error(t, err)
+
+		rendered := renderer.Rendered()
+		assert.Len(t, rendered, 1)
+		assert.Contains(t, string(rendered[0]), "TICKET-1")
+		assert.Contains(t, string(rendered[0]), "Sat, 10 Oct 2026 19:30 WIB")
+
+		mailerMock.AssertExpectations(t)
+	})
+
+	t.Run("return error when the event misses the ticket data", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		renderer := pdf.NewFakeRenderer()
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:       logrus.New(),
+			Dispatcher:   newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+			PDFRenderer:  pdf.NewHTMLTicketRenderer(renderer),
+			TicketSigner: ticketcode.NewHMACSigner("secret"),
+		})
+
+		e := newAcquireTicketEvent()
+		e.Number = ""
+
+		err := uc.OnAcquireTicket(context.Background(), e)
+		assert.True(t, errors.MatchStatus(err, status.UNPROCESSABLE_ENTITY))
+		assert.Empty(t, renderer.Rendered())
+
+		mailerMock.AssertExpectations(t)
+	})
+}
+
+func newIssuedTicket() ticket.IssuedTicket {
+	return ticket.IssuedTicket{
+		Number:       "TICKET-1",
+		CustomerID:   42,
+		CustomerName: "John Doe",
+		EventName:    "Concert",
+		ShowVenue:    "GBK",
+		Tier:         "VIP",
+		ShowTime:     time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC),
+		ShowTimezone: "Asia/Jakarta",
+		ObjectKey:    "TICKET-1.pdf",
+	}
+}
+
+func newCustomerContext() context.Context {
+	return context.WithValue(context.Background(), session.AccountContextKey{}, session.Account{
+		ID:   42,
+		Name: "John Doe",
+		Type: "CUSTOMER",
+	})
+}
+
+func newOrderEvent(number string, count int) ticket.AcquireTicketEvent {
+	e := newAcquireTicketEvent()
+	e.Number = number
+	e.ShowID = "SHOW-1"
+	e.OrderID = "ORDER-1"
+	e.OrderTicketCount = count
+	return e
+}
+
+// fakePendingOrderRepository keeps the pending orders in memory like the
+// database, it is shared by the use cases of a test like by the replicas.
+type fakePendingOrderRepository struct {
+	mu     sync.Mutex
+	orders map[string]*ticket.PendingOrder
+}
+
+func newFakePendingOrderRepository() *fakePendingOrderRepository {
+	return &fakePendingOrderRepository{orders: make(map[string]*ticket.PendingOrder)}
+}
+
+func (f *fakePendingOrderRepository) Add(ctx context.Context, t ticket.PendingOrderTicket, expected int, dueAt time.Time) (ticket.PendingOrder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	o, ok := f.orders[t.OrderID]
+	if !ok {
+		o = &ticket.PendingOrder{OrderID: t.OrderID, Status: ticket.OrderStatusPending, DueAt: dueAt}
+		f.orders[t.OrderID] = o
+	}
+	if expected > o.Expected {
+		o.Expected = expected
+	}
+	if o.Status == ticket.OrderStatusPending {
+		f.saveTicket(o, t)
+	}
+
+	return f.copy(o), nil
+}
+
+func (f *fakePendingOrderRepository) AddSent(ctx context.Context, t ticket.PendingOrderTicket) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.saveTicket(f.orders[t.OrderID], t)
+	return nil
+}
+
+func (f *fakePendingOrderRepository) saveTicket(o *ticket.PendingOrder, t ticket.PendingOrderTicket) {
+	for i := range o.Tickets {
+		if o.Tickets[i].TicketNumber == t.TicketNumber {
+			o.Tickets[i] = t
+			return
+		}
+	}
+	o.Tickets = append(o.Tickets, t)
+}
+
+func (f *fakePendingOrderRepository) Claim(ctx context.Context, orderID string, now time.Time, lease time.Duration) (ticket.PendingOrder, bool, error) {
+	orders, _ := f.claim(orderID, now, lease, 1)
+	if len(orders) == 0 {
+		return ticket.PendingOrder{}, false, nil
+	}
+	return orders[0], true, nil
+}
+
+func (f *fakePendingOrderRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]ticket.PendingOrder, error) {
+	return f.claim("", now, lease, limit)
+}
+
+func (f *fakePendingOrderRepository) claim(orderID string, now time.Time, lease time.Duration, limit int) ([]ticket.PendingOrder, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	orders := make([]ticket.PendingOrder, 0)
+	for _, o := range f.orders {
+		if len(orders) == limit {
+			break
+		}
+		if o.Status != ticket.OrderStatusPending || (orderID != "" && o.OrderID != orderID) {
+			continue
+		}
+		if o.ClaimedUntil != nil && o.ClaimedUntil.After(now) {
+			continue
+		}
+		if o.DueAt.After(now) && !o.Complete() {
+			continue
+		}
+
+		claimedUntil := now.Add(lease)
+		o.ClaimedUntil = &claimedUntil
+		orders = append(orders, f.copy(o))
+	}
+
+	return orders, nil
+}
+
+func (f *fakePendingOrderRepository) MarkSent(ctx context.Context, orderID string, sentAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	o := f.orders[orderID]
+	o.Status = ticket.OrderStatusSent
+	o.SentAt = &sentAt
+	o.ClaimedUntil = nil
+	return nil
+}
+
+func (f *fakePendingOrderRepository) DeleteSentBefore(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+func (f *fakePendingOrderRepository) copy(o *ticket.PendingOrder) ticket.PendingOrder {
+	c := *o
+	c.Tickets = append([]ticket.PendingOrderTicket(nil), o.Tickets...)
+	return c
+}
+
+func newOrderTicketUseCase(t *testing.T, mailerMock *mocks.Mailer, window time.Duration, pendingOrderRepository ticket.PendingOrderRepository) ticket.TicketUseCase {
+	repositoryMock := &ticketMocks.IssuedTicketRepository{}
+	repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	return ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+		Logger:     logrus.New(),
+		Dispatcher: newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+		ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir:    t.TempDir(),
+			Secret: "secret",
+		}),
+		IssuedTicketRepository: repositoryMock,
+		PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+		TicketSigner:           ticketcode.NewHMACSigner("secret"),
+		OrderWindow:            window,
+		PendingOrderRepository: pendingOrderRepository,
+	})
+}
+
+func isOrderMessage(m mailer.Message) bool {
+	body := string(m.MessageBody.Body)
+	return m.Subject == "Acquired Tickets" &&
+		strings.Contains(body, "/TICKET-1.pdf?expires=") &&
+		strings.Contains(body, "/TICKET-2.pdf?expires=") &&
+		len(m.Attachments) == 1 &&
+		m.Attachments[0].Filename == "ORDER-1.ics" &&
+		strings.Count(string(m.Attachments[0].Content), "BEGIN:VEVENT") == 1
+}
+
+func TestTicketUseCase_OnAcquireTicket_Order(t *testing.T) {
+	t.Run("send one email once every ticket of the order arrives", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(isOrderMessage)).Return(nil)
+
+		uc := newOrderTicketUseCase(t, mailerMock, time.Hour, newFakePendingOrderRepository())
+
+		err := uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 2))
+		assert.NoError(t, err)
+		mailerMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+		err = uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-2", 2))
+		assert.NoError(t, err)
+		mailerMock.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("send one email for the tickets consumed by different replicas", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(isOrderMessage)).Return(nil)
+
+		repository := newFakePendingOrderRepository()
+		assert.NoError(t, newOrderTicketUseCase(t, mailerMock, time.Hour, repository).OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 2)))
+		assert.NoError(t, newOrderTicketUseCase(t, mailerMock, time.Hour, repository).OnAcquireTicket(context.Background(), newOrderEvent("TICKET-2", 2)))
+
+		mailerMock.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("send the recorded tickets once the window elapses", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(isOrderMessage)).Return(nil)
+
+		repository := newFakePendingOrderRepository()
+		uc := newOrderTicketUseCase(t, mailerMock, 50*time.Millisecond, repository)
+
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 0)))
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-2", 0)))
+
+		n, err := uc.SendDueOrders(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n)
+
+		// the order outlives the replica which recorded it.
+		time.Sleep(100 * time.Millisecond)
+		n, err = newOrderTicketUseCase(t, mailerMock, 50*time.Millisecond, repository).SendDueOrders(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		mailerMock.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("retry the order after the lease when it fails", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("smtp is down"))
+
+		repository := newFakePendingOrderRepository()
+		uc := newOrderTicketUseCase(t, mailerMock, time.Hour, repository)
+
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 1)))
+		mailerMock.AssertNumberOfCalls(t, "Send", 1)
+
+		o := repository.orders["ORDER-1"]
+		assert.Equal(t, ticket.OrderStatusPending, o.Status)
+		assert.True(t, o.ClaimedUntil.After(time.Now()))
+
+		n, err := uc.SendDueOrders(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, n, "the order is leased")
+	})
+
+	t.Run("send a redelivered ticket once", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.MatchedBy(func(m mailer.Message) bool {
+			return m.Subject == "Acquired Ticket" && len(m.Attachments) == 1 && m.Attachments[0].Filename == "TICKET-1.ics"
+		})).Return(nil)
+
+		uc := newOrderTicketUseCase(t, mailerMock, time.Hour, newFakePendingOrderRepository())
+
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 1)))
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 1)))
+
+		mailerMock.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("send a ticket arriving after its order on its own", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		uc := newOrderTicketUseCase(t, mailerMock, time.Hour, newFakePendingOrderRepository())
+
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 1)))
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-2", 1)))
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-2", 1)))
+
+		mailerMock.AssertNumberOfCalls(t, "Send", 2)
+	})
+
+	t.Run("fail the event when the ticket is not recorded", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+
+		repositoryMock := &ticketMocks.PendingOrderRepository{}
+		repositoryMock.On("Add", mock.Anything, mock.Anything, 2, mock.Anything).Return(ticket.PendingOrder{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, ""))
+
+		uc := newOrderTicketUseCase(t, mailerMock, time.Hour, repositoryMock)
+
+		err := uc.OnAcquireTicket(context.Background(), newOrderEvent("TICKET-1", 2))
+		assert.Error(t, err)
+		mailerMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("send a ticket without order right away", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		uc := newOrderTicketUseCase(t, mailerMock, time.Hour, newFakePendingOrderRepository())
+
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), newAcquireTicketEvent()))
+		mailerMock.AssertNumberOfCalls(t, "Send", 1)
+	})
+}
+
+func TestTicketUseCase_OnAcquireTicket_SMS(t *testing.T) {
+	newUseCase := func(t *testing.T, smsSender sms.SMSSender, window time.Duration) ticket.TicketUseCase {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		return ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewSMSChannel(smsSender, "62"),
+			),
+			ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+				Dir:           t.TempDir(),
+				PublicBaseURL: "https://files.example.com",
+				Secret:        "secret",
+			}),
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+			OrderWindow:            window,
+			PendingOrderRepository: newFakePendingOrderRepository(),
+		})
+	}
+
+	t.Run("send the ticket link by sms", func(t *testing.T) {
+		smsSenderMock := &smsMocks.SMSSender{}
+		smsSenderMock.On("Send", mock.Anything, mock.MatchedBy(func(m sms.Message) bool {
+			return m.To == "+6281234567890" &&
+				strings.HasPrefix(m.Body, "TicketMaster: Your ticket TICKET-1 for Concert on Sat, 10 Oct 2026 19:30 WIB is confirmed. Download it at https://files.example.com/TICKET-1.pdf?expires=")
+		})).Return(nil)
+
+		e := newAcquireTicketEvent()
+		e.CustomerPhoneNumber = "081234567890"
+		err := newUseCase(t, smsSenderMock, 0).OnAcquireTicket(context.Background(), e)
+		assert.NoError(t, err)
+
+		smsSenderMock.AssertExpectations(t)
+	})
+
+	t.Run("send one sms for the order", func(t *testing.T) {
+		smsSenderMock := &smsMocks.SMSSender{}
+		smsSenderMock.On("Send", mock.Anything, sms.Message{
+			To:   "+6281234567890",
+			Body: "TicketMaster: 2 tiket Anda untuk Concert pada Sab, 10 Okt 2026 19:30 WIB telah terbit. Silakan cek email Anda untuk mengunduhnya.",
+		}).Return(nil)
+
+		uc := newUseCase(t, smsSenderMock, time.Hour)
+		for _, number := range []string{"TICKET-1", "TICKET-2"} {
+			e := newOrderEvent(number, 2)
+			e.Locale = "id"
+			e.CustomerPhoneNumber = "+62 812 3456 7890"
+			assert.NoError(t, uc.OnAcquireTicket(context.Background(), e))
+		}
+
+		smsSenderMock.AssertExpectations(t)
+		smsSenderMock.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("keep the email when the sms fails", func(t *testing.T) {
+		smsSenderMock := &smsMocks.SMSSender{}
+		smsSenderMock.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("provider is down"))
+
+		e := newAcquireTicketEvent()
+		e.CustomerPhoneNumber = "+6281234567890"
+		err := newUseCase(t, smsSenderMock, 0).OnAcquireTicket(context.Background(), e)
+		assert.NoError(t, err)
+	})
+}
+
+type fakePushNotifier struct {
+	customerID    int64
+	notifications []push.Notification
+}
+
+func (f *fakePushNotifier) Notify(ctx context.Context, customerID int64, n push.Notification) error {
+	f.customerID = customerID
+	f.notifications = append(f.notifications, n)
+	return nil
+}
+
+func TestTicketUseCase_OnAcquireTicket_Push(t *testing.T) {
+	mailerMock := &mocks.Mailer{}
+	mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	repositoryMock := &ticketMocks.IssuedTicketRepository{}
+	repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	notifier := &fakePushNotifier{}
+	uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+		Logger: logrus.New(),
+		Dispatcher: newDispatcher(
+			notification.NewEmailChannel(mailerMock, ""),
+			notification.NewPushChannel(notifier),
+		),
+		ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir:    t.TempDir(),
+			Secret: "secret",
+		}),
+		IssuedTicketRepository: repositoryMock,
+		PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+		TicketSigner:           ticketcode.NewHMACSigner("secret"),
+	})
+
+	e := newAcquireTicketEvent()
+	e.CustomerID = 42
+	err := uc.OnAcquireTicket(context.Background(), e)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(42), notifier.customerID)
+	assert.Equal(t, []push.Notification{{
+		Title: "Your ticket is ready",
+		Body:  "Concert, Sat, 10 Oct 2026 19:30 WIB",
+		Data: map[string]string{
+			"type":          "acquired_ticket",
+			"ticket_number": "TICKET-1",
+			"order_id":      "",
+		},
+	}}, notifier.notifications)
+}
+
+type failingPushNotifier struct{}
+
+func (failingPushNotifier) Notify(ctx context.Context, customerID int64, n push.Notification) error {
+	return fmt.Errorf("push: unavailable")
+}
+
+func TestTicketUseCase_SendDueReminders(t *testing.T) {
+	newUseCase := func(repository ticket.IssuedTicketRepository, notifier notification.PushNotifier) ticket.TicketUseCase {
+		return ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: notification.NewDispatcher(notification.DispatcherProperty{
+				Logger:           logrus.New(),
+				Channels:         []notification.Channel{notification.NewPushChannel(notifier)},
+				Routes:           map[string][]string{ticket.NotificationTypeShowReminder: {notification.Push}},
+				RequiredChannels: []string{notification.Push},
+			}),
+			IssuedTicketRepository: repository,
+			Reminder:               2 * time.Hour,
+		})
+	}
+
+	newTicket := func(number string, customerID int64, showID string) ticket.IssuedTicket {
+		t := newIssuedTicket()
+		t.Number = number
+		t.CustomerID = customerID
+		t.ShowID = showID
+		return t
+	}
+
+	t.Run("push one reminder for the tickets of a customer for a show", func(t *testing.T) {
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("ClaimDueReminders", mock.Anything, mock.Anything, 2*time.Hour, mock.Anything, mock.Anything).Return([]ticket.IssuedTicket{
+			newTicket("TICKET-1", 42, "SHOW-1"),
+			newTicket("TICKET-2", 42, "SHOW-1"),
+			newTicket("TICKET-3", 7, "SHOW-1"),
+		}, nil)
+		repositoryMock.On("MarkReminded", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		notifier := &fakePushNotifier{}
+		n, err := newUseCase(repositoryMock, notifier).SendDueReminders(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+
+		assert.Equal(t, []push.Notification{
+			{
+				Title: "Your show is coming up",
+				Body:  "Concert at GBK, Sat, 10 Oct 2026 19:30 WIB",
+				Data:  map[string]string{"type": "show_reminder", "event_id": "", "show_id": "SHOW-1"},
+			},
+			{
+				Title: "Your show is coming up",
+				Body:  "Concert at GBK, Sat, 10 Oct 2026 19:30 WIB",
+				Data:  map[string]string{"type": "show_reminder", "event_id": "", "show_id": "SHOW-1", "ticket_number": "TICKET-3"},
+			},
+		}, notifier.notifications)
+
+		repositoryMock.AssertCalled(t, "MarkReminded", mock.Anything, []string{"TICKET-1", "TICKET-2"}, mock.Anything)
+		repositoryMock.AssertCalled(t, "MarkReminded", mock.Anything, []string{"TICKET-3"}, mock.Anything)
+	})
+
+	t.Run("leave the tickets to be reminded again when the push fails", func(t *testing.T) {
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("ClaimDueReminders", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]ticket.IssuedTicket{
+			newTicket("TICKET-1", 42, "SHOW-1"),
+		}, nil)
+
+		n, err := newUseCase(repositoryMock, failingPushNotifier{}).SendDueReminders(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		repositoryMock.AssertNotCalled(t, "MarkReminded", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("return error when the tickets can not be claimed", func(t *testing.T) {
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("ClaimDueReminders", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, ""))
+
+		_, err := newUseCase(repositoryMock, &fakePushNotifier{}).SendDueReminders(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+type fakeWhatsAppNotifier struct {
+	customerID int64
+	messages   []whatsapp.Message
+}
+
+func (f *fakeWhatsAppNotifier) Notify(ctx context.Context, customerID int64, messages ...whatsapp.Message) error {
+	f.customerID = customerID
+	f.messages = append(f.messages, messages...)
+	return nil
+}
+
+func TestTicketUseCase_OnAcquireTicket_WhatsApp(t *testing.T) {
+	mailerMock := &mocks.Mailer{}
+	mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	repositoryMock := &ticketMocks.IssuedTicketRepository{}
+	repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	notifier := &fakeWhatsAppNotifier{}
+	uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+		Logger: logrus.New(),
+		Dispatcher: newDispatcher(
+			notification.NewEmailChannel(mailerMock, ""),
+			notification.NewWhatsAppChannel(notifier, "62"),
+		),
+		ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir:           t.TempDir(),
+			PublicBaseURL: "https://files.example.com",
+			Secret:        "secret",
+		}),
+		IssuedTicketRepository: repositoryMock,
+		PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+		TicketSigner:           ticketcode.NewHMACSigner("secret"),
+		OrderWindow:            time.Hour,
+		PendingOrderRepository: newFakePendingOrderRepository(),
+		WhatsAppTemplate:       "ticket_confirmation",
+	})
+
+	for _, number := range []string{"TICKET-1", "TICKET-2"} {
+		e := newOrderEvent(number, 2)
+		e.CustomerID = 42
+		e.Locale = "id"
+		e.CustomerPhoneNumber = "081234567890"
+		assert.NoError(t, uc.OnAcquireTicket(context.Background(), e))
+	}
+
+	assert.Equal(t, int64(42), notifier.customerID)
+	if assert.Len(t, notifier.messages, 3) {
+		assert.Equal(t, whatsapp.Message{
+			To: "+6281234567890",
+			Template: &whatsapp.Template{
+				Name:       "ticket_confirmation",
+				Language:   "id",
+				Parameters: []string{"John Doe", "Concert", "GBK", "Sab, 10 Okt 2026 19:30 WIB", "TICKET-1, TICKET-2"},
+			},
+			Reference: "ORDER-1",
+		}, notifier.messages[0])
+
+		for i, number := range []string{"TICKET-1", "TICKET-2"} {
+			m := notifier.messages[i+1]
+			assert.Equal(t, "+6281234567890", m.To)
+			assert.Equal(t, number, m.Reference)
+			assert.Equal(t, number+".pdf", m.Document.Filename)
+			assert.Equal(t, "Concert - VIP", m.Document.Caption)
+			assert.True(t, strings.HasPrefix(m.Document.Link, "https://files.example.com/"+number+".pdf?expires="))
+		}
+	}
+}
+
+type fakeWebhookDispatcher struct {
+	eventID string
+	event   string
+	data    interface{}
+}
+
+func (f *fakeWebhookDispatcher) Dispatch(ctx context.Context, eventID, event string, data interface{}) error {
+	f.eventID = eventID
+	f.event = event
+	f.data = data
+	return fmt.Errorf("database is down")
+}
+
+func TestTicketUseCase_OnAcquireTicket_Webhook(t *testing.T) {
+	mailerMock := &mocks.Mailer{}
+	mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	repositoryMock := &ticketMocks.IssuedTicketRepository{}
+	repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	dispatcher := &fakeWebhookDispatcher{}
+	uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+		Logger:     logrus.New(),
+		Dispatcher: newDispatcher(notification.NewEmailChannel(mailerMock, "")),
+		ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir:    t.TempDir(),
+			Secret: "secret",
+		}),
+		IssuedTicketRepository: repositoryMock,
+		PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+		TicketSigner:           ticketcode.NewHMACSigner("secret"),
+		WebhookDispatcher:      dispatcher,
+	})
+
+	e := newOrderEvent("TICKET-1", 1)
+	e.EventID = "EVENT-1"
+	err := uc.OnAcquireTicket(context.Background(), e)
+	assert.NoError(t, err, "the email is sent even when the webhook is not queued")
+
+	assert.Equal(t, "EVENT-1", dispatcher.eventID)
+	assert.Equal(t, webhook.EventTicketAcquired, dispatcher.event)
+	data := dispatcher.data.(ticket.AcquiredTicketWebhook)
+	assert.Equal(t, "TICKET-1", data.TicketNumber)
+	assert.Equal(t, "ORDER-1", data.OrderID)
+	assert.Equal(t, "SHOW-1", data.ShowID)
+	assert.Equal(t, "VIP", data.Tier)
+	assert.Equal(t, e.ShowTime, data.ShowTime)
+	assert.False(t, data.AcquiredAt.IsZero())
+
+	mailerMock.AssertNumberOfCalls(t, "Send", 1)
+}
+
+type fakeInboxWriter struct {
+	customerID       int64
+	notificationType string
+	title            string
+	body             string
+	data             map[string]string
+}
+
+func (f *fakeInboxWriter) Write(ctx context.Context, customerID int64, notificationType, title, body string, data map[string]string) error {
+	f.customerID = customerID
+	f.notificationType = notificationType
+	f.title = title
+	f.body = body
+	f.data = data
+	return nil
+}
+
+func TestTicketUseCase_OnAcquireTicket_Inbox(t *testing.T) {
+	newUseCase := func(t *testing.T, inbox notification.InboxWriter, window time.Duration) ticket.TicketUseCase {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		return ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger: logrus.New(),
+			Dispatcher: newDispatcher(
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewInboxChannel(inbox),
+			),
+			ObjectStore: storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+				Dir:    t.TempDir(),
+				Secret: "secret",
+			}),
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(pdf.NewFakeRenderer()),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+			OrderWindow:            window,
+			PendingOrderRepository: newFakePendingOrderRepository(),
+		})
+	}
+
+	t.Run("keep the ticket in the inbox", func(t *testing.T) {
+		inbox := &fakeInboxWriter{}
+
+		e := newAcquireTicketEvent()
+		e.CustomerID = 42
+		e.EventID = "EVENT-1"
+		assert.NoError(t, newUseCase(t, inbox, 0).OnAcquireTicket(context.Background(), e))
+
+		assert.Equal(t, int64(42), inbox.customerID)
+		assert.Equal(t, "acquired_ticket", inbox.notificationType)
+		assert.Equal(t, "Your ticket is ready", inbox.title)
+		assert.Equal(t, "Your ticket TICKET-1 for Concert on Sat, 10 Oct 2026 19:30 WIB is ready. Download it from My Tickets.", inbox.body)
+		assert.Equal(t, map[string]string{"ticket_number": "TICKET-1", "order_id": "", "event_id": "EVENT-1"}, inbox.data)
+	})
+
+	t.Run("keep one entry for the order", func(t *testing.T) {
+		inbox := &fakeInboxWriter{}
+
+		uc := newUseCase(t, inbox, time.Hour)
+		for _, number := range []string{"TICKET-1", "TICKET-2"} {
+			e := newOrderEvent(number, 2)
+			e.CustomerID = 42
+			e.Locale = "id"
+			assert.NoError(t, uc.OnAcquireTicket(context.Background(), e))
+		}
+
+		assert.Equal(t, "acquired_order", inbox.notificationType)
+		assert.Equal(t, "2 tiket Anda untuk Concert pada Sab, 10 Okt 2026 19:30 WIB telah terbit. Unduh di Tiket Saya.", inbox.body)
+		assert.Equal(t, map[string]string{"order_id": "ORDER-1", "event_id": ""}, inbox.data)
+	})
+}
+
+func TestTicketUseCase_GetTickets(t *testing.T) {
+	t.Run("return the tickets of the customer", func(t *testing.T) {
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("FindByCustomerID", mock.Anything, int64(42)).Return([]ticket.IssuedTicket{newIssuedTicket()}, nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			IssuedTicketRepository: repositoryMock,
+		})
+
+		resp, err := uc.GetTickets(newCustomerContext())
+		assert.NoError(t, err)
+		assert.Len(t, resp, 1)
+		assert.Equal(t, "TICKET-1", resp[0].Number)
+
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("return forbidden without a customer session", func(t *testing.T) {
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger: logrus.New(),
+		})
+
+		_, err := uc.GetTickets(context.Background())
+		assert.True(t, errors.MatchStatus(err, status.FORBIDDEN))
+	})
+}
+
+// wrappingObjectStore wraps the errors of Get like a store adding its context.
+type wrappingObjectStore struct {
+	storage.ObjectStore
+}
+
+func (s wrappingObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	body, err := s.ObjectStore.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+
+	return body, nil
+}
+
+func TestTicketUseCase_DownloadTicket(t *testing.T) {
+	t.Run("render the pdf again when it is no longer stored", func(t *testing.T) {
+		renderer := pdf.NewFakeRenderer()
+		objectStore := storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir: t.TempDir(),
+		})
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("FindByCustomerIDAndNumber", mock.Anything, int64(42), "TICKET-1").Return(newIssuedTicket(), nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			ObjectStore:            objectStore,
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(renderer),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+		})
+
+		body, err := uc.DownloadTicket(newCustomerContext(), "TICKET-1")
+		assert.NoError(t, err)
+		content, err := io.ReadAll(body)
+		assert.NoError(t, err)
+		assert.NoError(t, body.Close())
+		assert.Contains(t, string(content), "%PDF-")
+		assert.Len(t, renderer.Rendered(), 1)
+
+		body, err = uc.DownloadTicket(newCustomerContext(), "TICKET-1")
+		assert.NoError(t, err)
+		assert.NoError(t, body.Close())
+		assert.Len(t, renderer.Rendered(), 1, "the stored pdf should be served")
+	})
+
+	t.Run("render the pdf again when the store wraps the not found error", func(t *testing.T) {
+		renderer := pdf.NewFakeRenderer()
+		objectStore := wrappingObjectStore{storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+			Dir: t.TempDir(),
+		})}
+
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("FindByCustomerIDAndNumber", mock.Anything, int64(42), "TICKET-1").Return(newIssuedTicket(), nil)
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			ObjectStore:            objectStore,
+			IssuedTicketRepository: repositoryMock,
+			PDFRenderer:            pdf.NewHTMLTicketRenderer(renderer),
+			TicketSigner:           ticketcode.NewHMACSigner("secret"),
+		})
+
+		body, err := uc.DownloadTicket(newCustomerContext(), "TICKET-1")
+		assert.NoError(t, err)
+		assert.NoError(t, body.Close())
+		assert.Len(t, renderer.Rendered(), 1)
+	})
+
+	t.Run("return not found for a ticket of another customer", func(t *testing.T) {
+		repositoryMock := &ticketMocks.IssuedTicketRepository{}
+		repositoryMock.On("FindByCustomerIDAndNumber", mock.Anything, int64(42), "TICKET-2").
+			Return(ticket.IssuedTicket{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "ticket is not found"))
+
+		uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+			Logger:                 logrus.New(),
+			IssuedTicketRepository: repositoryMock,
+		})
+
+		_, err := uc.DownloadTicket(newCustomerContext(), "TICKET-2")
+		assert.True(t, errors.MatchStatus(err, status.NOT_FOUND))
+	})
+}
+
+func TestTicketUseCase_ReissueTicket(t *testing.T) {
+	renderer := pdf.NewFakeRenderer()
+	objectStore := storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+		Dir:           t.TempDir(),
+		PublicBaseURL: "https://files.example.com",
+		Secret:        "secret",
+	})
+
+	repositoryMock := &ticketMocks.IssuedTicketRepository{}
+	repositoryMock.On("FindByCustomerIDAndNumber", mock.Anything, int64(42), "TICKET-1").Return(newIssuedTicket(), nil)
+	repositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(it ticket.IssuedTicket) bool {
+		return it.Number == "TICKET-1" && !it.UpdatedAt.IsZero()
+	})).Return(nil)
+
+	uc := ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+		Logger:                 logrus.New(),
+		ObjectStore:            objectStore,
+		IssuedTicketRepository: repositoryMock,
+		PDFRenderer:            pdf.NewHTMLTicketRenderer(renderer),
+		TicketSigner:           ticketcode.NewHMACSigner("secret"),
+	})
+
+	resp, err := uc.ReissueTicket(newCustomerContext(), "TICKET-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TICKET-1", resp.Number)
+	assert.True(t, strings.HasPrefix(resp.PDFLink, "https://files.example.com/TICKET-1.pdf?expires="))
+	assert.Len(t, renderer.Rendered(), 1)
+
+	repositoryMock.AssertExpectations(t)
+}
@@ -0,0 +1,17 @@
+package whatsapp
+
+import "time"
+
+// SentMessage is a WhatsApp message sent to the customer, its status is
+// updated by the delivery status callbacks.
+type SentMessage struct {
+	ID         string
+	CustomerID int64
+	Reference  string
+	Recipient  string
+	Type       string
+	Status     string
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,78 @@
+package whatsapp
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/response"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+// maxCallbackSize limits the body of the status callback.
+const maxCallbackSize = 1 << 20
+
+type HTTPHandler struct {
+	WhatsAppUseCase WhatsAppUseCase
+}
+
+// InitHTTPHandler registers the callback url of the business API, the
+// callbacks are authenticated by their signature instead of a session.
+func InitHTTPHandler(router *mux.Router, whatsAppUseCase WhatsAppUseCase) {
+	handler := &HTTPHandler{
+		WhatsAppUseCase: whatsAppUseCase,
+	}
+
+	router.HandleFunc("/tm-notification/whatsapp/callback", handler.VerifyCallback).Methods(http.MethodGet)
+	router.HandleFunc("/tm-notification/whatsapp/callback", handler.StatusCallback).Methods(http.MethodPost)
+}
+
+// VerifyCallback echoes the challenge when the callback url is subscribed.
+func (handler HTTPHandler) VerifyCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	challenge, err := handler.WhatsAppUseCase.VerifyCallback(ctx, query.Get("hub.mode"), query.Get("hub.verify_token"), query.Get("hub.challenge"))
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(challenge))
+}
+
+// StatusCallback updates the delivery status of the sent messages.
+func (handler HTTPHandler) StatusCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackSize))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	if err := handler.WhatsAppUseCase.OnStatusCallback(ctx, body, r.Header.Get(whatsapp.SignatureHeader)); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "callback is received",
+	})
+}
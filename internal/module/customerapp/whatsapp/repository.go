@@ -0,0 +1,72 @@
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+type MessageRepository interface {
+	Save(ctx context.Context, m SentMessage) error
+	// UpdateStatus only advances the status of the message, see
+	// whatsapp.PrecedingStatuses. NOT_FOUND is returned when the message is not
+	// found or its status is already later.
+	UpdateStatus(ctx context.Context, id, messageStatus, messageError string, updatedAt time.Time) error
+}
+
+type messageRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewMessageRepository(logger *logrus.Logger, db *sql.DB) MessageRepository {
+	return &messageRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Save implements MessageRepository.
+func (r *messageRepository) Save(ctx context.Context, m SentMessage) error {
+	query := `
+		INSERT INTO whatsapp_message (id, customer_id, reference, recipient, type, status, error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, m.ID, m.CustomerID, m.Reference, m.Recipient, m.Type, m.Status, m.Error, m.CreatedAt, m.UpdatedAt)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// UpdateStatus implements MessageRepository.
+func (r *messageRepository) UpdateStatus(ctx context.Context, id, messageStatus, messageError string, updatedAt time.Time) error {
+	query := `UPDATE whatsapp_message SET status = $2, error = $3, updated_at = $4 WHERE id = $1 AND status = ANY($5)`
+
+	result, err := r.db.ExecContext(ctx, query, id, messageStatus, messageError, updatedAt, whatsapp.PrecedingStatuses(messageStatus))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	if affected == 0 {
+		return errors.New(http.StatusNotFound, status.NOT_FOUND, "message is not found or has a later status")
+	}
+
+	return nil
+}
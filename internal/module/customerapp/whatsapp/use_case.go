@@ -0,0 +1,141 @@
+package whatsapp
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+// VerifyMode is the mode of the verification request of the callback url.
+const VerifyMode = "subscribe"
+
+type WhatsAppUseCase interface {
+	// Notify sends the messages to the customer in order and keeps track of
+	// the sent messages.
+	Notify(ctx context.Context, customerID int64, messages ...whatsapp.Message) error
+	// VerifyCallback answers the verification request of the callback url
+	// with the challenge.
+	VerifyCallback(ctx context.Context, mode, token, challenge string) (string, error)
+	// OnStatusCallback updates the status of the sent messages from the
+	// signed callback body.
+	OnStatusCallback(ctx context.Context, body []byte, signature string) error
+}
+
+type WhatsAppUseCaseProperty struct {
+	AppName           string
+	Logger            *logrus.Logger
+	MessageRepository MessageRepository
+	Sender            whatsapp.Sender
+	AppSecret         string
+	VerifyToken       string
+}
+
+type whatsAppUseCase struct {
+	appName           string
+	logger            *logrus.Logger
+	messageRepository MessageRepository
+	sender            whatsapp.Sender
+	appSecret         string
+	verifyToken       string
+}
+
+func NewWhatsAppUseCase(props WhatsAppUseCaseProperty) WhatsAppUseCase {
+	return &whatsAppUseCase{
+		appName:           props.AppName,
+		logger:            props.Logger,
+		messageRepository: props.MessageRepository,
+		sender:            props.Sender,
+		appSecret:         props.AppSecret,
+		verifyToken:       props.VerifyToken,
+	}
+}
+
+// Notify implements WhatsAppUseCase. The error of the last failing message is
+// returned after every message is attempted.
+func (u *whatsAppUseCase) Notify(ctx context.Context, customerID int64, messages ...whatsapp.Message) error {
+	var lastErr error
+	for _, m := range messages {
+		id, err := u.sender.Send(ctx, m)
+		if err != nil {
+			u.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"customer_id": customerID,
+				"reference":   m.Reference,
+			}).Error()
+			lastErr = err
+			continue
+		}
+
+		now := time.Now()
+		err = u.messageRepository.Save(ctx, SentMessage{
+			ID:         id,
+			CustomerID: customerID,
+			Reference:  m.Reference,
+			Recipient:  m.To,
+			Type:       m.Type(),
+			Status:     whatsapp.StatusSent,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// VerifyCallback implements WhatsAppUseCase.
+func (u *whatsAppUseCase) VerifyCallback(ctx context.Context, mode, token, challenge string) (string, error) {
+	if mode != VerifyMode || u.verifyToken == "" || token != u.verifyToken {
+		return "", errors.New(http.StatusForbidden, status.FORBIDDEN, "invalid verify token")
+	}
+
+	return challenge, nil
+}
+
+// OnStatusCallback implements WhatsAppUseCase. The updates of messages which
+// are not sent by this service, or which would move a message back, e.g. a late
+// delivered after read, are ignored. Every callback is rejected without the app
+// secret.
+func (u *whatsAppUseCase) OnStatusCallback(ctx context.Context, body []byte, signature string) error {
+	if u.appSecret == "" {
+		return errors.New(http.StatusUnauthorized, status.UNAUTHORIZED, "invalid signature")
+	}
+	if err := whatsapp.VerifySignature(u.appSecret, body, signature); err != nil {
+		return errors.New(http.StatusUnauthorized, status.UNAUTHORIZED, "invalid signature")
+	}
+
+	updates, err := whatsapp.ParseStatusCallback(body)
+	if err != nil {
+		return errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid callback body")
+	}
+
+	for _, update := range updates {
+		if update.Status == whatsapp.StatusFailed {
+			u.logger.WithContext(ctx).WithFields(logrus.Fields{
+				"message_id": update.MessageID,
+				"error":      update.Error,
+			}).Warn("whatsapp message is failed")
+		}
+
+		updatedAt := update.Timestamp
+		if updatedAt.IsZero() {
+			updatedAt = time.Now()
+		}
+
+		err := u.messageRepository.UpdateStatus(ctx, update.MessageID, update.Status, update.Error, updatedAt)
+		if errors.MatchStatus(err, status.NOT_FOUND) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package whatsapp_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	customerapp_whatsapp "github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/whatsapp"
+	"github.com/tsel-ticketmaster/tm-notification/internal/module/customerapp/whatsapp/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/status"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+	whatsappMocks "github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp/mocks"
+)
+
+func TestWhatsAppUseCase_Notify(t *testing.T) {
+	template := whatsapp.Message{To: "+6281234567890", Template: &whatsapp.Template{Name: "ticket_confirmation"}, Reference: "ORDER-1"}
+	document := whatsapp.Message{To: "+6281234567890", Document: &whatsapp.Document{Link: "https://files.example.com/TICKET-1.pdf"}, Reference: "TICKET-1"}
+	failing := whatsapp.Message{To: "+6281234567890", Document: &whatsapp.Document{Link: "https://files.example.com/TICKET-2.pdf"}, Reference: "TICKET-2"}
+
+	senderMock := &whatsappMocks.Sender{}
+	senderMock.On("Send", mock.Anything, template).Return("wamid.1", nil)
+	senderMock.On("Send", mock.Anything, failing).Return("", fmt.Errorf("API responded 500"))
+	senderMock.On("Send", mock.Anything, document).Return("wamid.2", nil)
+
+	repositoryMock := &mocks.MessageRepository{}
+	repositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(m customerapp_whatsapp.SentMessage) bool {
+		return m.ID == "wamid.1" && m.CustomerID == 42 && m.Reference == "ORDER-1" && m.Type == whatsapp.TypeTemplate && m.Status == whatsapp.StatusSent
+	})).Return(nil).Once()
+	repositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(m customerapp_whatsapp.SentMessage) bool {
+		return m.ID == "wamid.2" && m.Recipient == "+6281234567890" && m.Type == whatsapp.TypeDocument
+	})).Return(nil).Once()
+
+	uc := customerapp_whatsapp.NewWhatsAppUseCase(customerapp_whatsapp.WhatsAppUseCaseProperty{
+		Logger:            logrus.New(),
+		MessageRepository: repositoryMock,
+		Sender:            senderMock,
+	})
+
+	err := uc.Notify(context.Background(), 42, template, failing, document)
+	assert.EqualError(t, err, "API responded 500")
+
+	senderMock.AssertNumberOfCalls(t, "Send", 3)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestWhatsAppUseCase_VerifyCallback(t *testing.T) {
+	uc := customerapp_whatsapp.NewWhatsAppUseCase(customerapp_whatsapp.WhatsAppUseCaseProperty{
+		Logger:      logrus.New(),
+		VerifyToken: "verify-token",
+	})
+
+	challenge, err := uc.VerifyCallback(context.Background(), customerapp_whatsapp.VerifyMode, "verify-token", "1158201444")
+	assert.NoError(t, err)
+	assert.Equal(t, "1158201444", challenge)
+
+	_, err = uc.VerifyCallback(context.Background(), customerapp_whatsapp.VerifyMode, "other-token", "1158201444")
+	assert.True(t, errors.MatchStatus(err, status.FORBIDDEN))
+}
+
+func TestWhatsAppUseCase_OnStatusCallback(t *testing.T) {
+	body := []byte(`{"entry":[{"changes":[{"value":{"statuses":[
+		{"id":"wamid.1","status":"read","timestamp":"1791546600","recipient_id":"6281234567890"},
+		{"id":"wamid.unknown","status":"delivered","timestamp":"1791546600","recipient_id":"6281234567890"},
+		{"id":"wamid.2","status":"failed","timestamp":"1791546601","recipient_id":"6281234567890","errors":[{"code":131026,"title":"Message undeliverable"}]}
+	]}}]}]}`)
+
+	mac := hmac.New(sha256.New, []byte("app-secret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	t.Run("update the status of the sent messages", func(t *testing.T) {
+		repositoryMock := &mocks.MessageRepository{}
+		repositoryMock.On("UpdateStatus", mock.Anything, "wamid.1", whatsapp.StatusRead, "", time.Unix(1791546600, 0)).Return(nil)
+		repositoryMock.On("UpdateStatus", mock.Anything, "wamid.unknown", whatsapp.StatusDelivered, "", time.Unix(1791546600, 0)).Return(errors.New(404, status.NOT_FOUND, "message is not found"))
+		repositoryMock.On("UpdateStatus", mock.Anything, "wamid.2", whatsapp.StatusFailed, "131026: Message undeliverable", time.Unix(1791546601, 0)).Return(nil)
+
+		uc := customerapp_whatsapp.NewWhatsAppUseCase(customerapp_whatsapp.WhatsAppUseCaseProperty{
+			Logger:            logrus.New(),
+			MessageRepository: repositoryMock,
+			AppSecret:         "app-secret",
+		})
+
+		assert.NoError(t, uc.OnStatusCallback(context.Background(), body, signature))
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("reject an unsigned callback", func(t *testing.T) {
+		repositoryMock := &mocks.MessageRepository{}
+
+		uc := customerapp_whatsapp.NewWhatsAppUseCase(customerapp_whatsapp.WhatsAppUseCaseProperty{
+			Logger:            logrus.New(),
+			MessageRepository: repositoryMock,
+			AppSecret:         "other-secret",
+		})
+
+		err := uc.OnStatusCallback(context.Background(), body, signature)
+		assert.True(t, errors.MatchStatus(err, status.UNAUTHORIZED))
+		repositoryMock.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reject every callback without the app secret", func(t *testing.T) {
+		repositoryMock := &mocks.MessageRepository{}
+
+		uc := customerapp_whatsapp.NewWhatsAppUseCase(customerapp_whatsapp.WhatsAppUseCaseProperty{
+			Logger:            logrus.New(),
+			MessageRepository: repositoryMock,
+		})
+
+		mac := hmac.New(sha256.New, nil)
+		mac.Write(body)
+		err := uc.OnStatusCallback(context.Background(), body, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		assert.True(t, errors.MatchStatus(err, status.UNAUTHORIZED))
+		repositoryMock.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -129,3 +129,47 @@ func (s *CustomerSession) Verify(next http.HandlerFunc) http.HandlerFunc {
 		next(w, r)
 	}
 }
+
+// WebSocketTokenProtocol is the subprotocol carrying the token of a WebSocket
+// handshake. A browser cannot set the header of a handshake, so it offers the
+// subprotocols `access_token` and the token, e.g.
+// `new WebSocket(url, ["access_token", token])`, and the server selects
+// `access_token`.
+const WebSocketTokenProtocol = "access_token"
+
+// VerifyWebSocket will verify the incomming WebSocket handshake like Verify, it
+// also accepts the token from the `Sec-WebSocket-Protocol` header, see
+// WebSocketTokenProtocol. The token is not accepted from the query as the urls
+// end up in the access logs.
+func (s *CustomerSession) VerifyWebSocket(next http.HandlerFunc) http.HandlerFunc {
+	verify := s.Verify(next)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			if token := webSocketToken(r); token != "" {
+				r = r.Clone(r.Context())
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+
+		verify(w, r)
+	}
+}
+
+// webSocketToken returns the subprotocol offered after WebSocketTokenProtocol.
+func webSocketToken(r *http.Request) string {
+	protocols := make([]string, 0)
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			protocols = append(protocols, strings.TrimSpace(protocol))
+		}
+	}
+
+	for i := 0; i < len(protocols)-1; i++ {
+		if protocols[i] == WebSocketTokenProtocol {
+			return protocols[i+1]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,240 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sms"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+// Channel delivers the intents over a medium.
+type Channel interface {
+	Name() string
+	// Send renders the intent and delivers it. It returns ErrNoTemplate or
+	// ErrUnreachable when the intent can not be sent on the channel.
+	Send(ctx context.Context, intent Intent) error
+}
+
+// PushNotifier sends a push notification to the devices of the customer.
+type PushNotifier interface {
+	Notify(ctx context.Context, customerID int64, n push.Notification) error
+}
+
+// WhatsAppNotifier sends WhatsApp messages to the customer.
+type WhatsAppNotifier interface {
+	Notify(ctx context.Context, customerID int64, messages ...whatsapp.Message) error
+}
+
+// InboxWriter puts a notification in the customer's in-app inbox.
+type InboxWriter interface {
+	Write(ctx context.Context, customerID int64, notificationType, title, body string, data map[string]string) error
+}
+
+type emailChannel struct {
+	mailer mailer.Mailer
+	sender string
+}
+
+// NewEmailChannel is a constructor of the email channel, the sender is the
+// from address of the emails.
+func NewEmailChannel(m mailer.Mailer, sender string) Channel {
+	return &emailChannel{
+		mailer: m,
+		sender: sender,
+	}
+}
+
+// Name implements Channel.
+func (c *emailChannel) Name() string {
+	return Email
+}
+
+// Send implements Channel.
+func (c *emailChannel) Send(ctx context.Context, intent Intent) error {
+	t, ok := intent.Data.(EmailTemplate)
+	if !ok {
+		return ErrNoTemplate
+	}
+	if intent.Recipient.Email == "" {
+		return ErrUnreachable
+	}
+
+	content, err := t.Email(ctx, intent.Recipient)
+	if err != nil {
+		return err
+	}
+
+	return c.mailer.Send(ctx, mailer.Message{
+		From: c.sender,
+		To: []mailer.Recepient{{
+			Address: intent.Recipient.Email,
+			Name:    intent.Recipient.Name,
+		}},
+		Subject: content.Subject,
+		MessageBody: mailer.MessageBody{
+			ContentType: "text/html",
+			Body:        content.Body,
+		},
+		Attachments: content.Attachments,
+	})
+}
+
+type smsChannel struct {
+	sender      sms.SMSSender
+	callingCode string
+}
+
+// NewSMSChannel is a constructor of the SMS channel, the calling code is the
+// country calling code of the phone numbers without one, e.g. `62`.
+func NewSMSChannel(sender sms.SMSSender, callingCode string) Channel {
+	return &smsChannel{
+		sender:      sender,
+		callingCode: callingCode,
+	}
+}
+
+// Name implements Channel.
+func (c *smsChannel) Name() string {
+	return SMS
+}
+
+// Send implements Channel.
+func (c *smsChannel) Send(ctx context.Context, intent Intent) error {
+	t, ok := intent.Data.(SMSTemplate)
+	if !ok {
+		return ErrNoTemplate
+	}
+	if intent.Recipient.PhoneNumber == "" {
+		return ErrUnreachable
+	}
+
+	phoneNumber, err := sms.NormalizePhoneNumber(intent.Recipient.PhoneNumber, c.callingCode)
+	if err != nil {
+		return err
+	}
+
+	body, err := t.SMS(ctx, intent.Recipient)
+	if err != nil {
+		return err
+	}
+
+	return c.sender.Send(ctx, sms.Message{
+		To:   phoneNumber,
+		Body: body,
+	})
+}
+
+type pushChannel struct {
+	notifier PushNotifier
+}
+
+// NewPushChannel is a constructor of the push channel.
+func NewPushChannel(notifier PushNotifier) Channel {
+	return &pushChannel{
+		notifier: notifier,
+	}
+}
+
+// Name implements Channel.
+func (c *pushChannel) Name() string {
+	return Push
+}
+
+// Send implements Channel.
+func (c *pushChannel) Send(ctx context.Context, intent Intent) error {
+	t, ok := intent.Data.(PushTemplate)
+	if !ok {
+		return ErrNoTemplate
+	}
+	if intent.Recipient.CustomerID == 0 {
+		return ErrUnreachable
+	}
+
+	n, err := t.Push(ctx, intent.Recipient)
+	if err != nil {
+		return err
+	}
+
+	return c.notifier.Notify(ctx, intent.Recipient.CustomerID, n)
+}
+
+type whatsAppChannel struct {
+	notifier    WhatsAppNotifier
+	callingCode string
+}
+
+// NewWhatsAppChannel is a constructor of the WhatsApp channel, the calling code
+// is the country calling code of the phone numbers without one, e.g. `62`.
+func NewWhatsAppChannel(notifier WhatsAppNotifier, callingCode string) Channel {
+	return &whatsAppChannel{
+		notifier:    notifier,
+		callingCode: callingCode,
+	}
+}
+
+// Name implements Channel.
+func (c *whatsAppChannel) Name() string {
+	return WhatsApp
+}
+
+// Send implements Channel.
+func (c *whatsAppChannel) Send(ctx context.Context, intent Intent) error {
+	t, ok := intent.Data.(WhatsAppTemplate)
+	if !ok {
+		return ErrNoTemplate
+	}
+	if intent.Recipient.PhoneNumber == "" {
+		return ErrUnreachable
+	}
+
+	phoneNumber, err := sms.NormalizePhoneNumber(intent.Recipient.PhoneNumber, c.callingCode)
+	if err != nil {
+		return err
+	}
+
+	messages, err := t.WhatsApp(ctx, intent.Recipient)
+	if err != nil {
+		return err
+	}
+	for i := range messages {
+		messages[i].To = phoneNumber
+	}
+
+	return c.notifier.Notify(ctx, intent.Recipient.CustomerID, messages...)
+}
+
+type inboxChannel struct {
+	writer InboxWriter
+}
+
+// NewInboxChannel is a constructor of the inbox channel.
+func NewInboxChannel(writer InboxWriter) Channel {
+	return &inboxChannel{
+		writer: writer,
+	}
+}
+
+// Name implements Channel.
+func (c *inboxChannel) Name() string {
+	return Inbox
+}
+
+// Send implements Channel.
+func (c *inboxChannel) Send(ctx context.Context, intent Intent) error {
+	t, ok := intent.Data.(InboxTemplate)
+	if !ok {
+		return ErrNoTemplate
+	}
+	if intent.Recipient.CustomerID == 0 {
+		return ErrUnreachable
+	}
+
+	content, err := t.Inbox(ctx, intent.Recipient)
+	if err != nil {
+		return err
+	}
+
+	return c.writer.Write(ctx, intent.Recipient.CustomerID, intent.Type, content.Title, content.Body, content.Data)
+}
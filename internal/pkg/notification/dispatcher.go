@@ -0,0 +1,170 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PreferenceStore tells the channels a customer opted out of.
+type PreferenceStore interface {
+	DisabledChannels(ctx context.Context, customerID int64, notificationType string) (map[string]bool, error)
+}
+
+// DefaultRoute is the route of an intent type without one, a new type is only
+// emailed until it is routed.
+var DefaultRoute = []string{Email}
+
+// DefaultRequiredChannels are required when DispatcherProperty.RequiredChannels
+// is nil, so a failed email is retried like before the dispatcher.
+var DefaultRequiredChannels = []string{Email}
+
+// Dispatcher is collection of behavior of notification dispatcher.
+type Dispatcher interface {
+	// Dispatch sends the intent over its channels and returns the outcome of
+	// each one. The error is the failure of a required channel, the failures of
+	// the optional channels are only reported in the outcomes.
+	Dispatch(ctx context.Context, intent Intent) (Outcomes, error)
+}
+
+type DispatcherProperty struct {
+	Logger   *logrus.Logger
+	Channels []Channel
+	// Routes are the channels of each type of intent, in order. A type without
+	// a route is sent on DefaultRoute.
+	Routes map[string][]string
+	// RequiredChannels can not be opted out of and are sent before the other
+	// channels, which are skipped when a required channel fails. It defaults
+	// to DefaultRequiredChannels, an empty slice requires no channel.
+	RequiredChannels []string
+	// PreferenceStore is optional, every routed channel is sent when it is nil.
+	// The intent fails without sending anything when the preferences can not be
+	// loaded.
+	PreferenceStore PreferenceStore
+}
+
+type dispatcher struct {
+	logger          *logrus.Logger
+	channels        map[string]Channel
+	routes          map[string][]string
+	required        map[string]bool
+	preferenceStore PreferenceStore
+}
+
+// NewDispatcher is a constructor.
+func NewDispatcher(props DispatcherProperty) Dispatcher {
+	d := &dispatcher{
+		logger:          props.Logger,
+		channels:        make(map[string]Channel),
+		routes:          props.Routes,
+		required:        make(map[string]bool),
+		preferenceStore: props.PreferenceStore,
+	}
+	for _, c := range props.Channels {
+		d.channels[c.Name()] = c
+	}
+	requiredChannels := props.RequiredChannels
+	if requiredChannels == nil {
+		requiredChannels = DefaultRequiredChannels
+	}
+	for _, name := range requiredChannels {
+		d.required[name] = true
+	}
+
+	return d
+}
+
+// Dispatch implements Dispatcher.
+func (d *dispatcher) Dispatch(ctx context.Context, intent Intent) (Outcomes, error) {
+	names, ok := d.routes[intent.Type]
+	if !ok {
+		names = DefaultRoute
+	}
+
+	outcomes := make(Outcomes, 0, len(names))
+	for _, name := range names {
+		// a routed channel may not be configured in this deployment.
+		if _, ok := d.channels[name]; ok {
+			outcomes = append(outcomes, Outcome{Channel: name})
+		}
+	}
+
+	disabled, err := d.disabledChannels(ctx, intent)
+	if err != nil {
+		// nothing is sent, so the retried intent reaches every channel once.
+		d.logger.WithContext(ctx).WithError(err).WithField("notification.type", intent.Type).Error()
+		for i := range outcomes {
+			outcomes[i] = Outcome{Channel: outcomes[i].Channel, Status: StatusFailed, Err: err}
+		}
+		return outcomes, err
+	}
+
+	required := make([]int, 0)
+	optional := make([]int, 0)
+	for i, outcome := range outcomes {
+		switch {
+		case d.required[outcome.Channel]:
+			required = append(required, i)
+		case disabled[outcome.Channel]:
+			outcomes[i] = Outcome{Channel: outcome.Channel, Status: StatusSkipped, Err: ErrOptedOut}
+		default:
+			optional = append(optional, i)
+		}
+	}
+
+	d.send(ctx, intent, outcomes, required)
+	for _, i := range required {
+		if outcomes[i].Status != StatusFailed {
+			continue
+		}
+
+		for _, j := range optional {
+			outcomes[j] = Outcome{Channel: outcomes[j].Channel, Status: StatusSkipped, Err: ErrRequiredFailed}
+		}
+		return outcomes, outcomes[i].Err
+	}
+
+	d.send(ctx, intent, outcomes, optional)
+
+	return outcomes, nil
+}
+
+func (d *dispatcher) disabledChannels(ctx context.Context, intent Intent) (map[string]bool, error) {
+	if d.preferenceStore == nil || intent.Recipient.CustomerID == 0 {
+		return nil, nil
+	}
+
+	return d.preferenceStore.DisabledChannels(ctx, intent.Recipient.CustomerID, intent.Type)
+}
+
+// send fans the intent out to the channels of the outcomes at the indexes.
+func (d *dispatcher) send(ctx context.Context, intent Intent, outcomes Outcomes, indexes []int) {
+	var wg sync.WaitGroup
+	for _, i := range indexes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outcomes[i] = d.sendOne(ctx, d.channels[outcomes[i].Channel], intent)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (d *dispatcher) sendOne(ctx context.Context, c Channel, intent Intent) Outcome {
+	err := c.Send(ctx, intent)
+	switch {
+	case err == nil:
+		return Outcome{Channel: c.Name(), Status: StatusSent}
+	case errors.Is(err, ErrNoTemplate), errors.Is(err, ErrUnreachable):
+		return Outcome{Channel: c.Name(), Status: StatusSkipped, Err: err}
+	default:
+		d.logger.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"notification.channel":     c.Name(),
+			"notification.type":        intent.Type,
+			"notification.customer_id": intent.Recipient.CustomerID,
+		}).Warn()
+		return Outcome{Channel: c.Name(), Status: StatusFailed, Err: err}
+	}
+}
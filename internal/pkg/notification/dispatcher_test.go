@@ -0,0 +1,261 @@
+package notification_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/notification"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer/mocks"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sms"
+	smsMocks "github.com/tsel-ticketmaster/tm-notification/pkg/sms/mocks"
+)
+
+type greeting struct{}
+
+func (greeting) Email(ctx context.Context, r notification.Recipient) (notification.EmailContent, error) {
+	return notification.EmailContent{Subject: "Hello", Body: []byte("<p>Hi " + r.Name + "</p>")}, nil
+}
+
+func (greeting) SMS(ctx context.Context, r notification.Recipient) (string, error) {
+	return "Hi " + r.Name, nil
+}
+
+func (greeting) Push(ctx context.Context, r notification.Recipient) (push.Notification, error) {
+	return push.Notification{Title: "Hello", Body: "Hi " + r.Name}, nil
+}
+
+type fakePushNotifier struct {
+	mu            sync.Mutex
+	notifications []push.Notification
+	err           error
+}
+
+func (f *fakePushNotifier) Notify(ctx context.Context, customerID int64, n push.Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.notifications = append(f.notifications, n)
+	return f.err
+}
+
+type fakePreferenceStore struct {
+	disabled map[string]bool
+	err      error
+}
+
+func (f fakePreferenceStore) DisabledChannels(ctx context.Context, customerID int64, notificationType string) (map[string]bool, error) {
+	return f.disabled, f.err
+}
+
+func newIntent() notification.Intent {
+	return notification.Intent{
+		Recipient: notification.Recipient{
+			CustomerID:  42,
+			Name:        "John Doe",
+			Email:       "john@mail.com",
+			PhoneNumber: "081234567890",
+		},
+		Type: "greeting",
+		Data: greeting{},
+	}
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	t.Run("render and send the intent on every routed channel", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mailer.Message{
+			From:        "noreply@ticketmaster.id",
+			To:          []mailer.Recepient{{Address: "john@mail.com", Name: "John Doe"}},
+			Subject:     "Hello",
+			MessageBody: mailer.MessageBody{ContentType: "text/html", Body: []byte("<p>Hi John Doe</p>")},
+		}).Return(nil)
+
+		smsSenderMock := &smsMocks.SMSSender{}
+		smsSenderMock.On("Send", mock.Anything, sms.Message{To: "+6281234567890", Body: "Hi John Doe"}).Return(nil)
+
+		notifier := &fakePushNotifier{}
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger: logrus.New(),
+			Channels: []notification.Channel{
+				notification.NewEmailChannel(mailerMock, "noreply@ticketmaster.id"),
+				notification.NewSMSChannel(smsSenderMock, "62"),
+				notification.NewPushChannel(notifier),
+				notification.NewInboxChannel(nil),
+			},
+			Routes: map[string][]string{
+				"greeting": {notification.SMS, notification.Email, notification.Inbox, notification.WhatsApp},
+			},
+		})
+
+		outcomes, err := d.Dispatch(context.Background(), newIntent())
+		assert.NoError(t, err)
+		assert.Equal(t, notification.Outcomes{
+			{Channel: notification.SMS, Status: notification.StatusSent},
+			{Channel: notification.Email, Status: notification.StatusSent},
+			{Channel: notification.Inbox, Status: notification.StatusSkipped, Err: notification.ErrNoTemplate},
+		}, outcomes)
+		assert.Empty(t, notifier.notifications, "push is not routed")
+
+		mailerMock.AssertExpectations(t)
+		smsSenderMock.AssertExpectations(t)
+	})
+
+	t.Run("email an intent without route", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		notifier := &fakePushNotifier{}
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger: logrus.New(),
+			Channels: []notification.Channel{
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewPushChannel(notifier),
+			},
+			RequiredChannels: []string{},
+		})
+
+		outcomes, err := d.Dispatch(context.Background(), newIntent())
+		assert.NoError(t, err)
+		assert.Equal(t, notification.Outcomes{{Channel: notification.Email, Status: notification.StatusSent}}, outcomes)
+		assert.Empty(t, notifier.notifications)
+	})
+
+	t.Run("skip a channel the recipient is unreachable on", func(t *testing.T) {
+		notifier := &fakePushNotifier{}
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger:   logrus.New(),
+			Channels: []notification.Channel{notification.NewPushChannel(notifier)},
+			Routes:   map[string][]string{"greeting": {notification.Push}},
+		})
+
+		intent := newIntent()
+		intent.Recipient.CustomerID = 0
+		outcomes, err := d.Dispatch(context.Background(), intent)
+		assert.NoError(t, err)
+		assert.Equal(t, notification.Outcome{Channel: notification.Push, Status: notification.StatusSkipped, Err: notification.ErrUnreachable}, outcomes[0])
+		assert.Empty(t, notifier.notifications)
+	})
+
+	t.Run("require the email by default", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("smtp is down"))
+
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger:   logrus.New(),
+			Channels: []notification.Channel{notification.NewEmailChannel(mailerMock, "")},
+			PreferenceStore: fakePreferenceStore{disabled: map[string]bool{
+				notification.Email: true,
+			}},
+		})
+
+		outcomes, err := d.Dispatch(context.Background(), newIntent())
+		assert.EqualError(t, err, "smtp is down")
+		assert.Equal(t, notification.StatusFailed, outcomes[0].Status)
+	})
+
+	t.Run("skip the opted out channels except the required ones", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+		smsSenderMock := &smsMocks.SMSSender{}
+		notifier := &fakePushNotifier{}
+
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger: logrus.New(),
+			Channels: []notification.Channel{
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewSMSChannel(smsSenderMock, "62"),
+				notification.NewPushChannel(notifier),
+			},
+			RequiredChannels: []string{notification.Email},
+			PreferenceStore: fakePreferenceStore{disabled: map[string]bool{
+				notification.Email: true,
+				notification.SMS:   true,
+			}},
+			Routes: map[string][]string{"greeting": {notification.Email, notification.SMS, notification.Push}},
+		})
+
+		outcomes, err := d.Dispatch(context.Background(), newIntent())
+		assert.NoError(t, err)
+		assert.Equal(t, notification.Outcomes{
+			{Channel: notification.Email, Status: notification.StatusSent},
+			{Channel: notification.SMS, Status: notification.StatusSkipped, Err: notification.ErrOptedOut},
+			{Channel: notification.Push, Status: notification.StatusSent},
+		}, outcomes)
+		smsSenderMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("send nothing without the preferences", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		notifier := &fakePushNotifier{}
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger: logrus.New(),
+			Channels: []notification.Channel{
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewPushChannel(notifier),
+			},
+			Routes:          map[string][]string{"greeting": {notification.Email, notification.Push}},
+			PreferenceStore: fakePreferenceStore{err: fmt.Errorf("database is down")},
+		})
+
+		outcomes, err := d.Dispatch(context.Background(), newIntent())
+		assert.EqualError(t, err, "database is down")
+		assert.Equal(t, notification.StatusFailed, outcomes[0].Status)
+		assert.Equal(t, notification.StatusFailed, outcomes[1].Status)
+		mailerMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		assert.Empty(t, notifier.notifications)
+	})
+
+	t.Run("skip the optional channels when a required channel fails", func(t *testing.T) {
+		mailerMock := &mocks.Mailer{}
+		mailerMock.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("smtp is down"))
+
+		notifier := &fakePushNotifier{}
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger: logrus.New(),
+			Channels: []notification.Channel{
+				notification.NewEmailChannel(mailerMock, ""),
+				notification.NewPushChannel(notifier),
+			},
+			RequiredChannels: []string{notification.Email},
+			Routes:           map[string][]string{"greeting": {notification.Email, notification.Push}},
+		})
+
+		outcomes, err := d.Dispatch(context.Background(), newIntent())
+		assert.EqualError(t, err, "smtp is down")
+		assert.Equal(t, notification.StatusFailed, outcomes[0].Status)
+		assert.Equal(t, notification.Outcome{Channel: notification.Push, Status: notification.StatusSkipped, Err: notification.ErrRequiredFailed}, outcomes[1])
+		assert.Empty(t, notifier.notifications)
+	})
+
+	t.Run("report the failure of an optional channel", func(t *testing.T) {
+		notifier := &fakePushNotifier{err: fmt.Errorf("fcm is down")}
+		smsSenderMock := &smsMocks.SMSSender{}
+
+		d := notification.NewDispatcher(notification.DispatcherProperty{
+			Logger: logrus.New(),
+			Channels: []notification.Channel{
+				notification.NewSMSChannel(smsSenderMock, "62"),
+				notification.NewPushChannel(notifier),
+			},
+			Routes: map[string][]string{"greeting": {notification.SMS, notification.Push}},
+		})
+
+		intent := newIntent()
+		intent.Recipient.PhoneNumber = "not a phone"
+		outcomes, err := d.Dispatch(context.Background(), intent)
+		assert.NoError(t, err)
+
+		assert.Equal(t, notification.StatusFailed, outcomes[0].Status)
+		push, ok := outcomes.Get(notification.Push)
+		assert.True(t, ok)
+		assert.Equal(t, notification.Outcome{Channel: notification.Push, Status: notification.StatusFailed, Err: fmt.Errorf("fcm is down")}, push)
+	})
+}
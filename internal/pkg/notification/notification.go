@@ -0,0 +1,128 @@
+// Package notification dispatches the notifications of the customers over the
+// channels routed for their type, e.g. email, SMS and push.
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+// The channels of a notification.
+const (
+	Email    = "email"
+	SMS      = "sms"
+	Push     = "push"
+	WhatsApp = "whatsapp"
+	Inbox    = "inbox"
+)
+
+// Notification error
+var (
+	// ErrNoTemplate is returned by a channel when the intent is not rendered
+	// for the channel.
+	ErrNoTemplate = fmt.Errorf("Notification: No template for the channel")
+	// ErrUnreachable is returned by a channel when the recipient has no
+	// address on the channel, e.g. no phone number.
+	ErrUnreachable = fmt.Errorf("Notification: The recipient is unreachable on the channel")
+	// ErrOptedOut skips a channel the recipient opted out of.
+	ErrOptedOut = fmt.Errorf("Notification: The recipient opted out of the channel")
+	// ErrRequiredFailed skips the optional channels when a required channel
+	// fails, so a retried intent does not send them twice.
+	ErrRequiredFailed = fmt.Errorf("Notification: A required channel failed")
+)
+
+// Recipient is the customer receiving the notification.
+type Recipient struct {
+	// CustomerID is zero when the recipient has no account yet, it is required
+	// by the push and inbox channels.
+	CustomerID  int64
+	Name        string
+	Email       string
+	PhoneNumber string
+	Locale      string
+}
+
+// Intent is a notification to be sent to a recipient.
+type Intent struct {
+	Recipient Recipient
+	// Type routes the intent to its channels and is what the customer opts out
+	// of, e.g. `sign_up`.
+	Type string
+	// Data renders the intent, it implements the template of every channel it
+	// can be sent on, e.g. EmailTemplate.
+	Data interface{}
+}
+
+// EmailContent is an intent rendered as an HTML email.
+type EmailContent struct {
+	Subject     string
+	Body        []byte
+	Attachments []mailer.Attachment
+}
+
+// EmailTemplate renders an intent for the email channel.
+type EmailTemplate interface {
+	Email(ctx context.Context, r Recipient) (EmailContent, error)
+}
+
+// SMSTemplate renders an intent for the SMS channel.
+type SMSTemplate interface {
+	SMS(ctx context.Context, r Recipient) (string, error)
+}
+
+// PushTemplate renders an intent for the push channel.
+type PushTemplate interface {
+	Push(ctx context.Context, r Recipient) (push.Notification, error)
+}
+
+// WhatsAppTemplate renders an intent for the WhatsApp channel, the recipient of
+// the messages is set by the channel.
+type WhatsAppTemplate interface {
+	WhatsApp(ctx context.Context, r Recipient) ([]whatsapp.Message, error)
+}
+
+// InboxContent is an intent rendered as an entry of the app inbox, its type is
+// the type of the intent.
+type InboxContent struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// InboxTemplate renders an intent for the inbox channel.
+type InboxTemplate interface {
+	Inbox(ctx context.Context, r Recipient) (InboxContent, error)
+}
+
+// The statuses of an outcome.
+const (
+	StatusSent    = "sent"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// Outcome is the result of an intent on a channel.
+type Outcome struct {
+	Channel string
+	Status  string
+	// Err is why the channel is skipped or failed.
+	Err error
+}
+
+// Outcomes are the results of an intent, in the order of its route.
+type Outcomes []Outcome
+
+// Get returns the outcome of the channel.
+func (o Outcomes) Get(channel string) (Outcome, bool) {
+	for _, outcome := range o {
+		if outcome.Channel == channel {
+			return outcome, true
+		}
+	}
+
+	return Outcome{}, false
+}
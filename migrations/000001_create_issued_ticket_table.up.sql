@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS issued_ticket (
+    number VARCHAR(64) PRIMARY KEY,
+    ticket_id BIGINT NOT NULL,
+    customer_id BIGINT NOT NULL,
+    customer_name VARCHAR(255) NOT NULL,
+    customer_email VARCHAR(255) NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    show_id VARCHAR(64) NOT NULL,
+    order_id VARCHAR(64) NOT NULL,
+    tier VARCHAR(64) NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    show_venue VARCHAR(255) NOT NULL,
+    show_country VARCHAR(255) NOT NULL,
+    show_city VARCHAR(255) NOT NULL,
+    show_time TIMESTAMPTZ NOT NULL,
+    show_timezone VARCHAR(64) NOT NULL,
+    locale VARCHAR(16) NOT NULL,
+    object_key VARCHAR(255) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS issued_ticket_customer_id_issued_at_idx ON issued_ticket (customer_id, issued_at DESC);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS device (
+    token VARCHAR(4096) PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    platform VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS device_customer_id_idx ON device (customer_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS whatsapp_message (
+    id VARCHAR(256) PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    reference VARCHAR(256) NOT NULL,
+    recipient VARCHAR(32) NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS whatsapp_message_customer_id_idx ON whatsapp_message (customer_id);
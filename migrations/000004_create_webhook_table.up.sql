@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS webhook_endpoint (
+    id BIGSERIAL PRIMARY KEY,
+    owner_id BIGINT NOT NULL,
+    event_id VARCHAR(64) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    failure_count INT NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_endpoint_event_id_idx ON webhook_endpoint (event_id);
+CREATE INDEX IF NOT EXISTS webhook_endpoint_owner_id_idx ON webhook_endpoint (owner_id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    endpoint_id BIGINT NOT NULL REFERENCES webhook_endpoint (id) ON DELETE CASCADE,
+    event VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    response_status INT NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_endpoint_id_id_idx ON webhook_delivery (endpoint_id, id DESC);
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS inbox_notification (
+    id BIGSERIAL PRIMARY KEY,
+    customer_id BIGINT NOT NULL,
+    type VARCHAR(64) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS inbox_notification_customer_id_id_idx ON inbox_notification (customer_id, id DESC);
+CREATE INDEX IF NOT EXISTS inbox_notification_unread_idx ON inbox_notification (customer_id) WHERE read_at IS NULL;
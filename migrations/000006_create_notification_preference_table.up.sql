@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS notification_preference (
+    customer_id BIGINT NOT NULL,
+    type VARCHAR(64) NOT NULL,
+    channel VARCHAR(32) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (customer_id, type, channel)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pending_order_ticket;
+DROP TABLE IF EXISTS pending_order;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS pending_order (
+    order_id VARCHAR(64) PRIMARY KEY,
+    expected INT NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL,
+    due_at TIMESTAMPTZ NOT NULL,
+    claimed_until TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS pending_order_pending_idx ON pending_order (due_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS pending_order_sent_at_idx ON pending_order (sent_at) WHERE status = 'sent';
+
+CREATE TABLE IF NOT EXISTS pending_order_ticket (
+    order_id VARCHAR(64) NOT NULL REFERENCES pending_order (order_id) ON DELETE CASCADE,
+    ticket_number VARCHAR(64) NOT NULL,
+    event JSONB NOT NULL,
+    code TEXT NOT NULL,
+    object_key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (order_id, ticket_number)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS issued_ticket_reminder_idx;
+
+ALTER TABLE issued_ticket
+    DROP COLUMN IF EXISTS reminded_at,
+    DROP COLUMN IF EXISTS reminder_claimed_until;
@@ -0,0 +1,5 @@
+ALTER TABLE issued_ticket
+    ADD COLUMN IF NOT EXISTS reminder_claimed_until TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS issued_ticket_reminder_idx ON issued_ticket (show_time) WHERE reminded_at IS NULL;
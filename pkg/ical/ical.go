@@ -0,0 +1,359 @@
+// Package ical generates iCalendar (RFC 5545) calendars.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Methods of the calendar (RFC 5546).
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+)
+
+// ContentType is the MIME type of a calendar published by email.
+const ContentType = "text/calendar; charset=utf-8; method=PUBLISH"
+
+const (
+	maxLineOctets = 75
+	utcFormat     = "20060102T150405Z"
+	localFormat   = "20060102T150405"
+)
+
+// timezoneSpan is how far before the first and after the last event of a
+// timezone its transitions are written.
+const timezoneSpan = 366 * 24 * time.Hour
+
+// Event is a VEVENT. The event is written in the location of Start, a named
+// location gets its VTIMEZONE while others are written in UTC.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	Duration    time.Duration
+	// Reminder adds a display alarm the duration before the start, there is no
+	// alarm when it is zero.
+	Reminder time.Duration
+}
+
+// Calendar is a VCALENDAR.
+type Calendar struct {
+	ProdID string
+	Method string
+	Events []Event
+	now    func() time.Time
+}
+
+// New is a constructor.
+func New(prodID string) *Calendar {
+	return &Calendar{
+		ProdID: prodID,
+		Method: MethodPublish,
+		now:    time.Now,
+	}
+}
+
+// AddEvent adds an event to the calendar.
+func (c *Calendar) AddEvent(e Event) {
+	c.Events = append(c.Events, e)
+}
+
+// Bytes encodes the calendar.
+func (c *Calendar) Bytes() []byte {
+	buff := new(bytes.Buffer)
+	c.Encode(buff)
+	return buff.Bytes()
+}
+
+// Encode writes the calendar with CRLF line endings and lines folded at 75
+// octets.
+func (c *Calendar) Encode(w io.Writer) error {
+	cw := &writer{w: w}
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", c.ProdID)
+	cw.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		cw.line("METHOD", c.Method)
+	}
+
+	timezones := make(map[string]*timezone)
+	names := make([]string, 0)
+	for _, e := range c.Events {
+		loc := e.Start.Location()
+		if !isNamed(loc) {
+			continue
+		}
+
+		tz, ok := timezones[loc.String()]
+		if !ok {
+			tz = &timezone{location: loc, first: e.Start, last: e.Start}
+			timezones[loc.String()] = tz
+			names = append(names, loc.String())
+		}
+		if e.Start.Before(tz.first) {
+			tz.first = e.Start
+		}
+		if end := e.Start.Add(e.Duration); end.After(tz.last) {
+			tz.last = end
+		}
+	}
+	for _, name := range names {
+		cw.timezone(timezones[name])
+	}
+
+	dtstamp := c.now().UTC().Format(utcFormat)
+	for _, e := range c.Events {
+		cw.event(e, dtstamp)
+	}
+
+	cw.line("END", "VCALENDAR")
+	return cw.err
+}
+
+// isNamed tells whether the location has an IANA name to be used as TZID.
+func isNamed(loc *time.Location) bool {
+	name := loc.String()
+	return name != "" && name != "UTC" && name != "Local"
+}
+
+// timezone is a location used by the events from first to last.
+type timezone struct {
+	location *time.Location
+	first    time.Time
+	last     time.Time
+}
+
+// observance is a STANDARD or DAYLIGHT component, the offset in effect from its
+// start.
+type observance struct {
+	start      time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	daylight   bool
+}
+
+// observances returns the offset in effect a span before the first event and
+// every transition until a span after the last one.
+func (tz *timezone) observances() []observance {
+	from := tz.first.Add(-timezoneSpan).In(tz.location)
+	to := tz.last.Add(timezoneSpan)
+
+	name, offset := from.Zone()
+	observances := []observance{{
+		start:      from.Truncate(time.Second),
+		offsetFrom: offset,
+		offsetTo:   offset,
+		name:       name,
+		daylight:   from.IsDST(),
+	}}
+
+	for t := from; t.Before(to); {
+		next := t.Add(24 * time.Hour)
+		if nextName, nextOffset := next.Zone(); nextName != name || nextOffset != offset {
+			at := transition(t, next)
+			atName, atOffset := at.Zone()
+			observances = append(observances, observance{
+				start:      at,
+				offsetFrom: offset,
+				offsetTo:   atOffset,
+				name:       atName,
+				daylight:   at.IsDST(),
+			})
+			name, offset = atName, atOffset
+		}
+		t = next
+	}
+
+	return observances
+}
+
+// transition returns the first second after a whose zone differs from the zone
+// of a, the zone is known to change by b.
+func transition(a, b time.Time) time.Time {
+	name, offset := a.Zone()
+	a, b = a.Truncate(time.Second), b.Truncate(time.Second)
+	for b.Sub(a) > time.Second {
+		mid := a.Add(b.Sub(a) / 2).Truncate(time.Second)
+		if midName, midOffset := mid.Zone(); midName == name && midOffset == offset {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	return b
+}
+
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func (cw *writer) event(e Event, dtstamp string) {
+	cw.line("BEGIN", "VEVENT")
+	cw.line("UID", e.UID)
+	cw.line("DTSTAMP", dtstamp)
+	cw.dateTime("DTSTART", e.Start)
+	if e.Duration > 0 {
+		cw.dateTime("DTEND", e.Start.Add(e.Duration))
+	}
+	cw.text("SUMMARY", e.Summary)
+	if e.Description != "" {
+		cw.text("DESCRIPTION", e.Description)
+	}
+	if e.Location != "" {
+		cw.text("LOCATION", e.Location)
+	}
+	if e.URL != "" {
+		cw.line("URL", e.URL)
+	}
+	cw.line("STATUS", "CONFIRMED")
+	cw.line("TRANSP", "OPAQUE")
+
+	if e.Reminder > 0 {
+		cw.line("BEGIN", "VALARM")
+		cw.line("ACTION", "DISPLAY")
+		cw.text("DESCRIPTION", e.Summary)
+		cw.line("TRIGGER", "-"+duration(e.Reminder))
+		cw.line("END", "VALARM")
+	}
+
+	cw.line("END", "VEVENT")
+}
+
+// timezone writes a VTIMEZONE with the observances around the events, so the
+// local times resolve to the right offset on either side of a daylight saving
+// change.
+func (cw *writer) timezone(tz *timezone) {
+	cw.line("BEGIN", "VTIMEZONE")
+	cw.line("TZID", tz.location.String())
+	for _, o := range tz.observances() {
+		component := "STANDARD"
+		if o.daylight {
+			component = "DAYLIGHT"
+		}
+
+		cw.line("BEGIN", component)
+		// the start is the local time in the offset before the observance.
+		cw.line("DTSTART", o.start.In(time.FixedZone("", o.offsetFrom)).Format(localFormat))
+		cw.line("TZOFFSETFROM", utcOffset(o.offsetFrom))
+		cw.line("TZOFFSETTO", utcOffset(o.offsetTo))
+		cw.text("TZNAME", o.name)
+		cw.line("END", component)
+	}
+	cw.line("END", "VTIMEZONE")
+}
+
+func (cw *writer) dateTime(name string, t time.Time) {
+	if isNamed(t.Location()) {
+		cw.line(fmt.Sprintf("%s;TZID=%s", name, t.Location().String()), t.Format(localFormat))
+		return
+	}
+
+	cw.line(name, t.UTC().Format(utcFormat))
+}
+
+func (cw *writer) text(name, value string) {
+	cw.line(name, escape(value))
+}
+
+// line writes a content line, folding it into lines of at most 75 octets
+// without splitting a UTF-8 character.
+func (cw *writer) line(name, value string) {
+	if cw.err != nil {
+		return
+	}
+
+	content := name + ":" + value
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// continuation lines start with a space
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+
+	_, cw.err = io.WriteString(cw.w, b.String())
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escape escapes a TEXT value.
+func escape(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// duration formats a dur-value, e.g. `P1D` or `PT1H30M`.
+func duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+
+	var b strings.Builder
+	b.WriteString("P")
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	if hours > 0 || minutes > 0 || seconds > 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds > 0 {
+			fmt.Fprintf(&b, "%dS", seconds)
+		}
+	}
+
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+
+	return b.String()
+}
+
+func utcOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
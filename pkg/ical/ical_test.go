@@ -0,0 +1,139 @@
+package ical_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ical"
+)
+
+func newCalendar(t *testing.T, e ical.Event) string {
+	cal := ical.New("-//TicketMaster//Ticket//EN")
+	cal.AddEvent(e)
+
+	return string(cal.Bytes())
+}
+
+func newEvent(t *testing.T) ical.Event {
+	location, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+
+	return ical.Event{
+		UID:      "TM-0001@tsel-ticketmaster",
+		Summary:  "Coldplay Music of the Spheres",
+		Location: "Gelora Bung Karno, Jakarta",
+		Start:    time.Date(2026, 10, 12, 19, 30, 0, 0, location),
+		Duration: 3 * time.Hour,
+		Reminder: 24 * time.Hour,
+	}
+}
+
+// unfold reverses the line folding of RFC 5545 section 3.1.
+func unfold(content string) []string {
+	content = strings.ReplaceAll(content, "\r\n ", "")
+	content = strings.TrimSuffix(content, "\r\n")
+	return strings.Split(content, "\r\n")
+}
+
+func TestEncode_LineEndings(t *testing.T) {
+	content := newCalendar(t, newEvent(t))
+
+	assert.True(t, strings.HasSuffix(content, "\r\n"))
+	assert.NotContains(t, strings.ReplaceAll(content, "\r\n", ""), "\n")
+	assert.NotContains(t, strings.ReplaceAll(content, "\r\n", ""), "\r")
+}
+
+func TestEncode_RequiredProperties(t *testing.T) {
+	lines := unfold(newCalendar(t, newEvent(t)))
+
+	assert.Equal(t, "BEGIN:VCALENDAR", lines[0])
+	assert.Equal(t, "END:VCALENDAR", lines[len(lines)-1])
+	assert.Contains(t, lines, "VERSION:2.0")
+	assert.Contains(t, lines, "PRODID:-//TicketMaster//Ticket//EN")
+	assert.Contains(t, lines, "METHOD:PUBLISH")
+	assert.Contains(t, lines, "UID:TM-0001@tsel-ticketmaster")
+
+	dtstamp := regexp.MustCompile(`^DTSTAMP:\d{8}T\d{6}Z$`)
+	found := false
+	for _, line := range lines {
+		if dtstamp.MatchString(line) {
+			found = true
+		}
+	}
+	assert.True(t, found, "DTSTAMP must be a UTC date-time")
+}
+
+func TestEncode_Timezone(t *testing.T) {
+	lines := unfold(newCalendar(t, newEvent(t)))
+
+	assert.Contains(t, lines, "DTSTART;TZID=Asia/Jakarta:20261012T193000")
+	assert.Contains(t, lines, "DTEND;TZID=Asia/Jakarta:20261012T223000")
+	assert.Contains(t, lines, "BEGIN:VTIMEZONE")
+	assert.Contains(t, lines, "TZID:Asia/Jakarta")
+	assert.Contains(t, lines, "TZOFFSETTO:+0700")
+	assert.Contains(t, lines, "TZNAME:WIB")
+	assert.NotContains(t, lines, "BEGIN:DAYLIGHT")
+}
+
+func TestEncode_DaylightSaving(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+
+	e := newEvent(t)
+	e.Start = time.Date(2026, 11, 20, 19, 30, 0, 0, location)
+	content := strings.Join(unfold(newCalendar(t, e)), "\n")
+
+	assert.Contains(t, content, "DTSTART;TZID=America/New_York:20261120T193000")
+	assert.Contains(t, content, "BEGIN:DAYLIGHT\nDTSTART:20260308T020000\nTZOFFSETFROM:-0500\nTZOFFSETTO:-0400\nTZNAME:EDT\nEND:DAYLIGHT")
+	assert.Contains(t, content, "BEGIN:STANDARD\nDTSTART:20261101T020000\nTZOFFSETFROM:-0400\nTZOFFSETTO:-0500\nTZNAME:EST\nEND:STANDARD")
+	assert.Contains(t, content, "BEGIN:STANDARD\nDTSTART:20271107T020000\nTZOFFSETFROM:-0400\nTZOFFSETTO:-0500\nTZNAME:EST\nEND:STANDARD")
+}
+
+func TestEncode_UTC(t *testing.T) {
+	e := newEvent(t)
+	e.Start = time.Date(2026, 10, 12, 19, 30, 0, 0, time.FixedZone("", 7*60*60))
+	lines := unfold(newCalendar(t, e))
+
+	assert.Contains(t, lines, "DTSTART:20261012T123000Z")
+	assert.NotContains(t, lines, "BEGIN:VTIMEZONE")
+}
+
+func TestEncode_Alarm(t *testing.T) {
+	e := newEvent(t)
+	e.Reminder = 90 * time.Minute
+	content := strings.Join(unfold(newCalendar(t, e)), "\n")
+
+	assert.Contains(t, content, "BEGIN:VALARM\nACTION:DISPLAY\nDESCRIPTION:Coldplay Music of the Spheres\nTRIGGER:-PT1H30M\nEND:VALARM")
+
+	e.Reminder = 0
+	assert.NotContains(t, newCalendar(t, e), "VALARM")
+}
+
+func TestEncode_Escaping(t *testing.T) {
+	e := newEvent(t)
+	e.Location = "Gelora Bung Karno; Jl. Pintu Satu Senayan, Jakarta\nGate 5 \\ North"
+	lines := unfold(newCalendar(t, e))
+
+	assert.Contains(t, lines, `LOCATION:Gelora Bung Karno\; Jl. Pintu Satu Senayan\, Jakarta\nGate 5 \\ North`)
+}
+
+func TestEncode_Folding(t *testing.T) {
+	e := newEvent(t)
+	e.Description = strings.Repeat("Konser spektakuler di Jakarta ", 10) + strings.Repeat("🎵", 30)
+	content := newCalendar(t, e)
+
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.True(t, utf8.ValidString(line), "a multi-octet character must not be split")
+	}
+
+	assert.Contains(t, unfold(content), "DESCRIPTION:"+e.Description)
+}
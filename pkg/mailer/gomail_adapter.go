@@ -2,6 +2,7 @@ package mailer
 
 import (
 	"context"
+	"io"
 
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
@@ -122,6 +123,26 @@ func (g *GomailAdapter) setBody(m Message, gm *gomail.Message) (err error) {
 	return
 }
 
+func (g *GomailAdapter) setAttachments(m Message, gm *gomail.Message) (err error) {
+	for _, attachment := range m.Attachments {
+		content := attachment.Content
+		settings := []gomail.FileSetting{
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		}
+		if attachment.ContentType != "" {
+			settings = append(settings, gomail.SetHeader(map[string][]string{
+				"Content-Type": {attachment.ContentType},
+			}))
+		}
+
+		gm.Attach(attachment.Filename, settings...)
+	}
+	return
+}
+
 func (g *GomailAdapter) composeGomailMessage(m Message) (gm *gomail.Message, err error) {
 	gm = gomail.NewMessage()
 
@@ -135,6 +156,7 @@ func (g *GomailAdapter) composeGomailMessage(m Message) (gm *gomail.Message, err
 	g.setSubject(m, gm)
 	g.setCarbonCopy(m, gm)
 	g.setBody(m, gm)
+	g.setAttachments(m, gm)
 
 	return
 }
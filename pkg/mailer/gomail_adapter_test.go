@@ -1,14 +1,18 @@
 package mailer_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer/mocks"
+	"gopkg.in/gomail.v2"
 
 	"github.com/tsel-ticketmaster/tm-notification/pkg/mailer"
 )
@@ -114,6 +118,49 @@ func TestGomailAdapterSend_Success(t *testing.T) {
 	})
 }
 
+func TestGomailAdapterSend_Attachments(t *testing.T) {
+	gomailDialerMock := &mocks.GomailDialer{}
+	gomailDialerMock.On("DialAndSend", mock.MatchedBy(func(gm *gomail.Message) bool {
+		buff := new(bytes.Buffer)
+		if _, err := gm.WriteTo(buff); err != nil {
+			return false
+		}
+
+		raw := buff.String()
+		return strings.Contains(raw, `filename="ticket.pkpass"`) &&
+			strings.Contains(raw, "Content-Type: application/vnd.apple.pkpass") &&
+			strings.Contains(raw, base64.StdEncoding.EncodeToString([]byte("pass content")))
+	})).Return(nil)
+
+	m := mailer.NewGomailAdapter(logrus.New(), "default-sender@mail.com", gomailDialerMock, true)
+
+	msg := mailer.Message{
+		To: []mailer.Recepient{
+			{
+				Name:    "Testing Testing 1",
+				Address: "testing1@mail.com",
+			},
+		},
+		Subject: "test subject",
+		MessageBody: mailer.MessageBody{
+			ContentType: mailer.ContentTypeHTML,
+			Body:        []byte("<p>Hallo test.</p>"),
+		},
+		Attachments: []mailer.Attachment{
+			{
+				Filename:    "ticket.pkpass",
+				ContentType: "application/vnd.apple.pkpass",
+				Content:     []byte("pass content"),
+			},
+		},
+	}
+
+	err := m.Send(context.TODO(), msg)
+	assert.NoError(t, err)
+
+	gomailDialerMock.AssertExpectations(t)
+}
+
 func TestGomailAdapterSend_Error_DialUp(t *testing.T) {
 	gomailDialerMock := &mocks.GomailDialer{}
 	gomailDialerMock.On("DialAndSend", mock.Anything).Return(fmt.Errorf("tcp: Timeout"))
@@ -31,6 +31,13 @@ type MessageBody struct {
 	Body        []byte
 }
 
+// Attachment is a file attached to the message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
 // Message is a message to be sent to the mail server.
 type Message struct {
 	From        string
@@ -38,6 +45,7 @@ type Message struct {
 	CC          []Recepient
 	Subject     string
 	MessageBody MessageBody
+	Attachments []Attachment
 }
 
 // Mailer is collection of behavior of mailer.
@@ -60,10 +68,11 @@ func (um *unimplementMailer) Send(ctx context.Context, messages ...Message) (err
 			}
 
 			um.logger.WithContext(ctx).WithFields(logrus.Fields{
-				"no":            fmt.Sprintf("%d.%d", i, j),
-				"email.subject": message.Subject,
-				"email.from":    from,
-				"email.to":      recipient.Address,
+				"no":                fmt.Sprintf("%d.%d", i, j),
+				"email.subject":     message.Subject,
+				"email.from":        from,
+				"email.to":          recipient.Address,
+				"email.attachments": len(message.Attachments),
 			}).Info("fake sending email")
 		}
 	}
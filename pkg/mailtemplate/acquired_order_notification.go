@@ -0,0 +1,48 @@
+package mailtemplate
+
+import "bytes"
+
+// AcquiredOrderNotificationTemplate is a concrete struct of MailTemplate
+type AcquiredOrderNotificationTemplate struct {
+	page *page
+}
+
+// NewAcquiredOrderNotificationTemplate is a constructor. The template is resolved
+// from the given locale, e.g. `id-ID` falls back to `id` then to DefaultLocale.
+func NewAcquiredOrderNotificationTemplate(locale string) MailTemplate {
+	return &AcquiredOrderNotificationTemplate{
+		page: defaultRegistry.Load().lookup(AcquiredOrderNotification, locale),
+	}
+}
+
+// Populate will populate the template with data.
+//
+// The `Data` must contain fields:
+//
+// `CustomerName` As the name of recipient.
+//
+// `Tickets` As the tickets of the order with the link to download each of them.
+//
+// An error is returned when any of them is missing or empty.
+//
+// For example:
+//
+//	// set the arguments
+//	data := &AcquiredOrderNotificationData{}
+//	data.CustomerName = "John Doe"
+//	data.Tickets = []OrderTicketData{
+//		{TicketNumber: "TICKET-1", TicketPDFLink: "https://example.com/TICKET-1.pdf"},
+//		{TicketNumber: "TICKET-2", TicketPDFLink: "https://example.com/TICKET-2.pdf"},
+//	}
+//
+//	// instantiate the template
+//	template := NewAcquiredOrderNotificationTemplate("id-ID")
+//	tbuff, err := template.Populate(data)
+func (et *AcquiredOrderNotificationTemplate) Populate(data Data) (buff *bytes.Buffer, err error) {
+	return et.page.execute(data)
+}
+
+// Subject returns the translated email subject.
+func (et *AcquiredOrderNotificationTemplate) Subject() string {
+	return et.page.subject
+}
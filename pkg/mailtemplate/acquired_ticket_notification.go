@@ -1,26 +1,17 @@
 package mailtemplate
 
-import (
-	"bytes"
-	"embed"
-	"html/template"
-)
-
-//go:embed html/acquired_ticket_notification.html
-var acquiredTicketNotificationTemplate embed.FS
+import "bytes"
 
 // AcquiredTicketNotificationTemplate is a concrete struct of MailTemplate
 type AcquiredTicketNotificationTemplate struct {
-	rawBuff []byte
-	raw     string
+	page *page
 }
 
-// NewCustomerVerificationTemplate is a constructor.
-func NewAcquiredTicketNotificationTemplate() MailTemplate {
-	rawBuff, _ := acquiredTicketNotificationTemplate.ReadFile("html/acquired_ticket_notification.html")
+// NewAcquiredTicketNotificationTemplate is a constructor. The template is resolved
+// from the given locale, e.g. `id-ID` falls back to `id` then to DefaultLocale.
+func NewAcquiredTicketNotificationTemplate(locale string) MailTemplate {
 	return &AcquiredTicketNotificationTemplate{
-		rawBuff: rawBuff,
-		raw:     string(rawBuff),
+		page: defaultRegistry.Load().lookup(AcquiredTicketNotification, locale),
 	}
 }
 
@@ -28,20 +19,27 @@ func NewAcquiredTicketNotificationTemplate() MailTemplate {
 //
 // The `Data` must contain fields:
 //
-// `RecipientName` As the name of recipient.
+// `CustomerName` As the name of recipient.
+//
+// `TicketPDFLink` As the link to download the ticket.
+//
+// An error is returned when any of them is missing or empty.
 //
 // For example:
 //
 //	// set the arguments
-//	data := &ExampleData{}
-//	data.RecipientName = "John Doe"
+//	data := &AcquiredTicketNotificationData{}
+//	data.CustomerName = "John Doe"
+//	data.TicketPDFLink = "https://example.com/ticket.pdf"
 //
 //	// instantiate the template
-//	template := NewExampleTemplate()
-//	tbuff := template.Populate(data)
-func (et *AcquiredTicketNotificationTemplate) Populate(data Data) (buff *bytes.Buffer) {
-	buff = new(bytes.Buffer)
-	t, _ := template.New("acquired-ticket-notification").Parse(et.raw)
-	t.Execute(buff, data)
-	return
+//	template := NewAcquiredTicketNotificationTemplate("id-ID")
+//	tbuff, err := template.Populate(data)
+func (et *AcquiredTicketNotificationTemplate) Populate(data Data) (buff *bytes.Buffer, err error) {
+	return et.page.execute(data)
+}
+
+// Subject returns the translated email subject.
+func (et *AcquiredTicketNotificationTemplate) Subject() string {
+	return et.page.subject
 }
@@ -0,0 +1,90 @@
+package mailtemplate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// requiredFields is the data contract of each template, the listed fields must
+// be present and non-zero before the template is rendered.
+var requiredFields = map[string][]string{
+	CustomerVerification:       {"RecipientName", "VerificationLink"},
+	AcquiredTicketNotification: {"CustomerName", "TicketPDFLink"},
+	AcquiredOrderNotification:  {"CustomerName", "Tickets"},
+	Ticket:                     {"CustomerName", "EventName", "Venue", "Tier", "TicketNumber", "ShowTime", "Code"},
+}
+
+// ValidationError is returned when the data does not satisfy the template
+// contract.
+type ValidationError struct {
+	Template string
+	Missing  []string
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("mailtemplate: %s is missing required fields: %s", e.Template, strings.Join(e.Missing, ", "))
+}
+
+// Validate checks that every required field of the template exists on the data
+// and is not empty, an empty list or map counts as missing. The keys of a
+// MapData are checked the same way. It is done by Populate before rendering.
+func Validate(name string, data Data) error {
+	required := requiredFields[name]
+	if len(required) == 0 {
+		return nil
+	}
+
+	var v reflect.Value
+	if data != nil {
+		v = reflect.ValueOf(data.Get())
+	}
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+
+	missing := make([]string, 0)
+	for _, field := range required {
+		if !v.IsValid() {
+			missing = append(missing, field)
+			continue
+		}
+
+		var f reflect.Value
+		switch v.Kind() {
+		case reflect.Struct:
+			f = v.FieldByName(field)
+		case reflect.Map:
+			f = v.MapIndex(reflect.ValueOf(field))
+		}
+
+		if isEmpty(f) {
+			missing = append(missing, field)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &ValidationError{Template: name, Missing: missing}
+	}
+
+	return nil
+}
+
+// isEmpty tells whether the field is absent, zero or an empty collection. The
+// value of a map field is held by an interface, it is unwrapped first.
+func isEmpty(f reflect.Value) bool {
+	for f.IsValid() && f.Kind() == reflect.Interface && !f.IsNil() {
+		f = f.Elem()
+	}
+	if !f.IsValid() || f.IsZero() {
+		return true
+	}
+
+	switch f.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String, reflect.Chan:
+		return f.Len() == 0
+	}
+
+	return false
+}
@@ -1,26 +1,17 @@
 package mailtemplate
 
-import (
-	"bytes"
-	"embed"
-	"html/template"
-)
-
-//go:embed html/customer_verification_template.html
-var customerVerificationTemplate embed.FS
+import "bytes"
 
 // CustomerVerificationTemplate is a concrete struct of MailTemplate
 type CustomerVerificationTemplate struct {
-	rawBuff []byte
-	raw     string
+	page *page
 }
 
-// NewCustomerVerificationTemplate is a constructor.
-func NewCustomerVerificationTemplate() MailTemplate {
-	rawBuff, _ := customerVerificationTemplate.ReadFile("html/customer_verification_template.html")
+// NewCustomerVerificationTemplate is a constructor. The template is resolved
+// from the given locale, e.g. `id-ID` falls back to `id` then to DefaultLocale.
+func NewCustomerVerificationTemplate(locale string) MailTemplate {
 	return &CustomerVerificationTemplate{
-		rawBuff: rawBuff,
-		raw:     string(rawBuff),
+		page: defaultRegistry.Load().lookup(CustomerVerification, locale),
 	}
 }
 
@@ -30,18 +21,25 @@ func NewCustomerVerificationTemplate() MailTemplate {
 //
 // `RecipientName` As the name of recipient.
 //
+// `VerificationLink` As the link to verify the account.
+//
+// An error is returned when any of them is missing or empty.
+//
 // For example:
 //
 //	// set the arguments
-//	data := &ExampleData{}
+//	data := &VerificationEmailData{}
 //	data.RecipientName = "John Doe"
+//	data.VerificationLink = "https://example.com/verify"
 //
 //	// instantiate the template
-//	template := NewExampleTemplate()
-//	tbuff := template.Populate(data)
-func (et *CustomerVerificationTemplate) Populate(data Data) (buff *bytes.Buffer) {
-	buff = new(bytes.Buffer)
-	t, _ := template.New("customer-verification").Parse(et.raw)
-	t.Execute(buff, data)
-	return
+//	template := NewCustomerVerificationTemplate("id-ID")
+//	tbuff, err := template.Populate(data)
+func (et *CustomerVerificationTemplate) Populate(data Data) (buff *bytes.Buffer, err error) {
+	return et.page.execute(data)
+}
+
+// Subject returns the translated email subject.
+func (et *CustomerVerificationTemplate) Subject() string {
+	return et.page.subject
 }
@@ -1,6 +1,9 @@
 package mailtemplate
 
+import "time"
+
 type VerificationEmailData struct {
+	Layout
 	RecipientName    string
 	VerificationLink string
 }
@@ -10,14 +13,43 @@ func (d VerificationEmailData) Get() interface{} {
 }
 
 type AcquiredTicketNotificationData struct {
+	Layout
 	CustomerName  string
 	TicketPDFLink string
+	// GoogleWalletLink is the optional link saving the ticket to Google Wallet.
+	GoogleWalletLink string
+	// AppleWalletAttached tells the customer the Apple Wallet pass is attached.
+	AppleWalletAttached bool
 }
 
 func (d AcquiredTicketNotificationData) Get() interface{} {
 	return d
 }
 
+type AcquiredOrderNotificationData struct {
+	Layout
+	CustomerName string
+	OrderID      string
+	Tickets      []OrderTicketData
+	// AppleWalletAttached tells the customer the Apple Wallet passes are
+	// attached.
+	AppleWalletAttached bool
+}
+
+func (d AcquiredOrderNotificationData) Get() interface{} {
+	return d
+}
+
+// OrderTicketData is a ticket listed in the acquired order notification.
+type OrderTicketData struct {
+	TicketNumber  string
+	EventName     string
+	Tier          string
+	TicketPDFLink string
+	// GoogleWalletLink is the optional link saving the ticket to Google Wallet.
+	GoogleWalletLink string
+}
+
 type TicketData struct {
 	CustomerName string
 	EventName    string
@@ -26,7 +58,15 @@ type TicketData struct {
 	City         string
 	Tier         string
 	TicketNumber string
-	DateTime     string
+	ShowTime     time.Time
+	// Timezone is the IANA timezone of the show, e.g. `Asia/Jakarta`. The
+	// configured application timezone is used when it is empty.
+	Timezone string
+	// Code is the signed ticket code scanned at the gate, it is printed as a
+	// QR code.
+	Code string
+	// Barcode prints the code as a Code 128 barcode as well.
+	Barcode bool
 }
 
 func (d TicketData) Get() interface{} {
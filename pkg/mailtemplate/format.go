@@ -0,0 +1,197 @@
+package mailtemplate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// defaultLocation is the timezone of the formatted times without a loadable
+// timezone, they are left as is while it is nil.
+var defaultLocation atomic.Pointer[time.Location]
+
+// SetLocation sets the default timezone of the formatted times, e.g. the
+// application timezone. It applies to the loaded templates and localizers too.
+func SetLocation(location *time.Location) {
+	defaultLocation.Store(location)
+}
+
+// formatter holds the locale aware formatting functions registered on every
+// template as `formatDate`, `formatTime`, `formatDateTime`, `relativeTime` and
+// `currency`.
+type formatter struct {
+	loc      locale
+	fallback locale
+	now      func() time.Time
+}
+
+// in converts the time to the first loadable timezone, falling back to the
+// default location, see SetLocation.
+func (f formatter) in(t time.Time, timezone []string) time.Time {
+	for _, tz := range timezone {
+		if tz == "" {
+			continue
+		}
+		if location, err := time.LoadLocation(tz); err == nil {
+			return t.In(location)
+		}
+	}
+
+	if location := defaultLocation.Load(); location != nil {
+		return t.In(location)
+	}
+
+	return t
+}
+
+func (f formatter) days() []string {
+	if len(f.loc.Format.Days) == 7 {
+		return f.loc.Format.Days
+	}
+	return f.fallback.Format.Days
+}
+
+func (f formatter) months() []string {
+	if len(f.loc.Format.Months) == 12 {
+		return f.loc.Format.Months
+	}
+	return f.fallback.Format.Months
+}
+
+func (f formatter) message(key string) string {
+	return translate(f.loc, f.fallback)(key)
+}
+
+// FormatDate formats the date, e.g. `Sat, 12 Oct 2026`. The optional timezone is
+// an IANA name such as `Asia/Jakarta`.
+func (f formatter) FormatDate(t time.Time, timezone ...string) string {
+	t = f.in(t, timezone)
+	return fmt.Sprintf("%s, %02d %s %d", f.days()[t.Weekday()], t.Day(), f.months()[t.Month()-1], t.Year())
+}
+
+// FormatTime formats the time of day with the zone abbreviation, e.g. `19:30 WIB`.
+func (f formatter) FormatTime(t time.Time, timezone ...string) string {
+	return f.in(t, timezone).Format("15:04 MST")
+}
+
+// FormatDateTime formats both, e.g. `Sat, 12 Oct 2026 19:30 WIB`.
+func (f formatter) FormatDateTime(t time.Time, timezone ...string) string {
+	return f.FormatDate(t, timezone...) + " " + f.FormatTime(t, timezone...)
+}
+
+// RelativeTime describes the distance between now and the time, e.g. `in 3 days`
+// or `2 hours ago`.
+func (f formatter) RelativeTime(t time.Time) string {
+	d := t.Sub(f.now())
+
+	format := f.message("relative_future")
+	if d < 0 {
+		d = -d
+		format = f.message("relative_past")
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return f.message("relative_now")
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	default:
+		n, unit = int(d/(24*time.Hour)), "day"
+	}
+
+	if n == 1 {
+		unit += "_one"
+	} else {
+		unit += "_other"
+	}
+
+	return fmt.Sprintf(format, fmt.Sprintf(f.message(unit), n))
+}
+
+type currency struct {
+	symbol   string
+	decimals int
+	spaced   bool
+}
+
+// currencies maps ISO 4217 codes to their symbol and number of decimals, any
+// other code is printed as is, e.g. `JPY 1,500.00`.
+var currencies = map[string]currency{
+	"IDR": {"Rp", 0, true},
+	"USD": {"$", 2, false},
+	"SGD": {"S$", 2, false},
+	"EUR": {"€", 2, false},
+}
+
+// Currency formats the amount in the given ISO 4217 currency using the locale
+// separators, e.g. `Rp 1.500.000` or `$1,500.00`.
+func (f formatter) Currency(amount interface{}, code string) (string, error) {
+	value, err := toFloat(amount)
+	if err != nil {
+		return "", err
+	}
+
+	code = strings.ToUpper(code)
+	c, ok := currencies[code]
+	if !ok {
+		c = currency{code, 2, true}
+	}
+
+	sign := ""
+	if value < 0 {
+		sign, value = "-", -value
+	}
+
+	thousands, decimal := f.loc.Format.ThousandsSeparator, f.loc.Format.DecimalSeparator
+	if thousands == "" || decimal == "" {
+		thousands, decimal = f.fallback.Format.ThousandsSeparator, f.fallback.Format.DecimalSeparator
+	}
+
+	plain := strconv.FormatFloat(math.Round(value*math.Pow10(c.decimals))/math.Pow10(c.decimals), 'f', c.decimals, 64)
+	integer, fraction, _ := strings.Cut(plain, ".")
+
+	var b strings.Builder
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+	if fraction != "" {
+		b.WriteString(decimal)
+		b.WriteString(fraction)
+	}
+
+	symbol := c.symbol
+	if c.spaced {
+		symbol += " "
+	}
+
+	return sign + symbol + b.String(), nil
+}
+
+func toFloat(amount interface{}) (float64, error) {
+	switch v := amount.(type) {
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("currency: unsupported amount type %T", amount)
+	}
+}
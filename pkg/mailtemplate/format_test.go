@@ -0,0 +1,94 @@
+package mailtemplate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFormatter(t *testing.T, name string, now time.Time) formatter {
+	loc, err := readLocale(embeddedFS, name)
+	assert.NoError(t, err)
+	fallback, err := readLocale(embeddedFS, DefaultLocale)
+	assert.NoError(t, err)
+
+	return formatter{loc: loc, fallback: fallback, now: func() time.Time { return now }}
+}
+
+func TestFormatter_FormatDateTime(t *testing.T) {
+	showTime := time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC)
+
+	t.Run("format in the show timezone", func(t *testing.T) {
+		f := newTestFormatter(t, "en", time.Now())
+		assert.Equal(t, "Sat, 10 Oct 2026 19:30 WIB", f.FormatDateTime(showTime, "Asia/Jakarta"))
+		assert.Equal(t, "Sat, 10 Oct 2026", f.FormatDate(showTime, "Asia/Jakarta"))
+		assert.Equal(t, "20:30 WITA", f.FormatTime(showTime, "Asia/Makassar"))
+	})
+
+	t.Run("format with the locale names", func(t *testing.T) {
+		f := newTestFormatter(t, "id", time.Now())
+		assert.Equal(t, "Sab, 10 Okt 2026 19:30 WIB", f.FormatDateTime(showTime, "Asia/Jakarta"))
+	})
+
+	t.Run("ignore an unknown timezone", func(t *testing.T) {
+		f := newTestFormatter(t, "en", time.Now())
+		assert.NotPanics(t, func() { f.FormatDateTime(showTime, "Mars/Olympus_Mons") })
+	})
+
+	t.Run("fall back to the default location", func(t *testing.T) {
+		f := newTestFormatter(t, "en", time.Now())
+		assert.Equal(t, "12:30 UTC", f.FormatTime(showTime))
+
+		location, err := time.LoadLocation("Asia/Jakarta")
+		assert.NoError(t, err)
+		SetLocation(location)
+		defer SetLocation(nil)
+
+		assert.Equal(t, "19:30 WIB", f.FormatTime(showTime))
+		assert.Equal(t, "19:30 WIB", f.FormatTime(showTime, "Mars/Olympus_Mons"))
+		assert.Equal(t, "20:30 WITA", f.FormatTime(showTime, "Asia/Makassar"))
+	})
+}
+
+func TestFormatter_RelativeTime(t *testing.T) {
+	now := time.Date(2026, time.October, 10, 12, 0, 0, 0, time.UTC)
+
+	en := newTestFormatter(t, "en", now)
+	assert.Equal(t, "just now", en.RelativeTime(now.Add(30*time.Second)))
+	assert.Equal(t, "in 1 minute", en.RelativeTime(now.Add(time.Minute)))
+	assert.Equal(t, "in 3 days", en.RelativeTime(now.Add(72*time.Hour)))
+	assert.Equal(t, "2 hours ago", en.RelativeTime(now.Add(-2*time.Hour)))
+
+	id := newTestFormatter(t, "id", now)
+	assert.Equal(t, "3 hari lagi", id.RelativeTime(now.Add(72*time.Hour)))
+	assert.Equal(t, "2 jam yang lalu", id.RelativeTime(now.Add(-2*time.Hour)))
+}
+
+func TestFormatter_Currency(t *testing.T) {
+	en := newTestFormatter(t, "en", time.Now())
+	id := newTestFormatter(t, "id", time.Now())
+
+	cases := []struct {
+		f      formatter
+		amount interface{}
+		code   string
+		want   string
+	}{
+		{id, 1500000, "IDR", "Rp 1.500.000"},
+		{en, int64(1500000), "idr", "Rp 1,500,000"},
+		{en, 1234.5, "USD", "$1,234.50"},
+		{id, 1234.5, "USD", "$1.234,50"},
+		{en, -20, "USD", "-$20.00"},
+		{en, "99.9", "JPY", "JPY 99.90"},
+	}
+
+	for _, c := range cases {
+		got, err := c.f.Currency(c.amount, c.code)
+		assert.NoError(t, err)
+		assert.Equal(t, c.want, got)
+	}
+
+	_, err := en.Currency(struct{}{}, "USD")
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package mailtemplate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+)
+
+// funcs returns the functions registered on every template of the given locale.
+func funcs(name string, loc, fallback locale) template.FuncMap {
+	f := formatter{loc: loc, fallback: fallback, now: time.Now}
+
+	return template.FuncMap{
+		"dict":           dict,
+		"locale":         func() string { return name },
+		"t":              translate(loc, fallback),
+		"formatDate":     f.FormatDate,
+		"formatTime":     f.FormatTime,
+		"formatDateTime": f.FormatDateTime,
+		"relativeTime":   f.RelativeTime,
+		"currency":       f.Currency,
+		"qrCode":         qrCode,
+		"barcode":        barcode,
+	}
+}
+
+// dict builds a map from key and value pairs, it is used to pass several values
+// to a partial, e.g. `{{ template "button" dict "Link" .Link "Label" "Verify" }}`.
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict: odd number of arguments")
+	}
+
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+
+	return m, nil
+}
+
+// translate returns the `t` function which looks up a message of the locale,
+// falling back to the default locale and finally to the key itself.
+func translate(loc, fallback locale) func(key string) string {
+	return func(key string) string {
+		if msg, ok := loc.Messages[key]; ok {
+			return msg
+		}
+		if msg, ok := fallback.Messages[key]; ok {
+			return msg
+		}
+		return key
+	}
+}
+
+// qrCode encodes the content as a QR code inlined as a data URI image, e.g.
+// `<img src="{{ qrCode .Code }}">`.
+func qrCode(content string) (template.URL, error) {
+	png, err := ticketcode.QRCodePNG(content, 256)
+	if err != nil {
+		return "", err
+	}
+
+	return dataURI(png), nil
+}
+
+// barcode encodes the content as a Code 128 barcode inlined as a data URI
+// image.
+func barcode(content string) (template.URL, error) {
+	png, err := ticketcode.Code128PNG(content, 64)
+	if err != nil {
+		return "", err
+	}
+
+	return dataURI(png), nil
+}
+
+func dataURI(png []byte) template.URL {
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(png))
+}
@@ -0,0 +1,41 @@
+package mailtemplate
+
+import "time"
+
+// Localizer gives access to the translations and formatting functions of a
+// locale outside of the templates, e.g. to lay out a document in Go.
+type Localizer struct {
+	formatter
+	locale string
+}
+
+// NewLocalizer is a constructor. The locale is resolved like the templates, see
+// Candidates.
+func NewLocalizer(locale string) Localizer {
+	r := defaultRegistry.Load()
+	fallback := r.locales[DefaultLocale]
+
+	for _, candidate := range Candidates(locale) {
+		if loc, ok := r.locales[candidate]; ok {
+			return Localizer{
+				formatter: formatter{loc: loc, fallback: fallback, now: time.Now},
+				locale:    candidate,
+			}
+		}
+	}
+
+	return Localizer{
+		formatter: formatter{loc: fallback, fallback: fallback, now: time.Now},
+		locale:    DefaultLocale,
+	}
+}
+
+// Locale returns the resolved locale.
+func (l Localizer) Locale() string {
+	return l.locale
+}
+
+// T returns the translated message, like `{{ t "key" }}` in the templates.
+func (l Localizer) T(key string) string {
+	return l.message(key)
+}
@@ -1,6 +1,23 @@
 package mailtemplate
 
-import "bytes"
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sync/atomic"
+)
+
+//go:embed html
+var htmlFS embed.FS
+
+// Name of the templates, matching the page file names under `pages/<locale>`.
+const (
+	CustomerVerification       = "customer_verification"
+	AcquiredTicketNotification = "acquired_ticket_notification"
+	AcquiredOrderNotification  = "acquired_order_notification"
+	Ticket                     = "ticket"
+)
 
 // Data is an abstraction of mail data.
 type Data interface {
@@ -9,5 +26,37 @@ type Data interface {
 
 // MailTemplate is an abstraction of mail template.
 type MailTemplate interface {
-	Populate(data Data) (buff *bytes.Buffer)
+	Populate(data Data) (buff *bytes.Buffer, err error)
+	Subject() string
+}
+
+// Layout holds the values rendered by the shared base layout and its partials.
+type Layout struct {
+	UnsubscribeLink string
+}
+
+// embeddedFS is the root of the embedded templates.
+var embeddedFS, _ = fs.Sub(htmlFS, "html")
+
+// embeddedRegistry holds the templates shipped within the binary, they are used
+// until the overrides of a Watcher are loaded. The embedded files are also the
+// fallback of the override files which can not be parsed.
+var embeddedRegistry = mustLoad()
+
+// defaultRegistry is the registry used by the template constructors, it is
+// swapped atomically on reload.
+var defaultRegistry atomic.Pointer[registry]
+
+func init() {
+	defaultRegistry.Store(embeddedRegistry)
+}
+
+// mustLoad is like load but panics on error, the embedded templates are part of
+// the binary and a parse failure is a programming error.
+func mustLoad() *registry {
+	r, err := load(embeddedFS)
+	if err != nil {
+		panic(fmt.Sprintf("mailtemplate: %s", err))
+	}
+	return r
 }
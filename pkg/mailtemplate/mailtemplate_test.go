@@ -0,0 +1,293 @@
+package mailtemplate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+)
+
+func TestCustomerVerificationTemplate_Populate(t *testing.T) {
+	mt := mailtemplate.NewCustomerVerificationTemplate("")
+
+	t.Run("render the page within the base layout", func(t *testing.T) {
+		buff, err := mt.Populate(&mailtemplate.VerificationEmailData{
+			RecipientName:    "John Doe",
+			VerificationLink: "https://example.com/verify?token=abc",
+		})
+		assert.NoError(t, err)
+		html := buff.String()
+
+		assert.Contains(t, html, "<!DOCTYPE html>")
+		assert.Contains(t, html, "<title>Customer Verification</title>")
+		assert.Contains(t, html, "<b>John Doe</b>")
+		assert.Contains(t, html, `href="https://example.com/verify?token=abc"`)
+		assert.Contains(t, html, "sign up request")
+		assert.NotContains(t, html, "Unsubscribe")
+	})
+
+	t.Run("render the page and subject in the requested locale", func(t *testing.T) {
+		mt := mailtemplate.NewCustomerVerificationTemplate("id-ID")
+		buff, err := mt.Populate(&mailtemplate.VerificationEmailData{
+			RecipientName:    "John Doe",
+			VerificationLink: "https://example.com/verify?token=abc",
+		})
+		assert.NoError(t, err)
+		html := buff.String()
+
+		assert.Equal(t, "Verifikasi Akun", mt.Subject())
+		assert.Contains(t, html, `<html lang="id">`)
+		assert.Contains(t, html, "Hai <b>John Doe</b>")
+		assert.Contains(t, html, "Salam,")
+	})
+
+	t.Run("fall back to the default locale", func(t *testing.T) {
+		mt := mailtemplate.NewCustomerVerificationTemplate("fr-FR")
+		buff, err := mt.Populate(&mailtemplate.VerificationEmailData{
+			RecipientName:    "John Doe",
+			VerificationLink: "https://example.com/verify?token=abc",
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Customer Verification", mt.Subject())
+		assert.Contains(t, buff.String(), `<html lang="en">`)
+	})
+
+	t.Run("render the unsubscribe link in the footer", func(t *testing.T) {
+		buff, err := mt.Populate(&mailtemplate.VerificationEmailData{
+			Layout: mailtemplate.Layout{
+				UnsubscribeLink: "https://example.com/unsubscribe",
+			},
+			RecipientName:    "John Doe",
+			VerificationLink: "https://example.com/verify?token=abc",
+		})
+		assert.NoError(t, err)
+
+		assert.Contains(t, buff.String(), `href="https://example.com/unsubscribe"`)
+	})
+}
+
+func TestAcquiredTicketNotificationTemplate_Populate(t *testing.T) {
+	mt := mailtemplate.NewAcquiredTicketNotificationTemplate("")
+	buff, err := mt.Populate(&mailtemplate.AcquiredTicketNotificationData{
+		CustomerName:  "John Doe",
+		TicketPDFLink: "https://example.com/TICKET-1.pdf",
+	})
+	assert.NoError(t, err)
+	html := buff.String()
+
+	assert.Contains(t, html, "Download Your Ticket!")
+	assert.Contains(t, html, "Hi John Doe")
+	assert.Contains(t, html, `href="https://example.com/TICKET-1.pdf"`)
+	assert.Contains(t, html, "issueing ticket")
+	assert.NotContains(t, html, "Wallet")
+}
+
+func TestAcquiredTicketNotificationTemplate_Populate_Wallet(t *testing.T) {
+	mt := mailtemplate.NewAcquiredTicketNotificationTemplate("id-ID")
+	buff, err := mt.Populate(&mailtemplate.AcquiredTicketNotificationData{
+		CustomerName:        "John Doe",
+		TicketPDFLink:       "https://example.com/TICKET-1.pdf",
+		GoogleWalletLink:    "https://pay.google.com/gp/v/save/token",
+		AppleWalletAttached: true,
+	})
+	assert.NoError(t, err)
+	html := buff.String()
+
+	assert.Contains(t, html, "terlampir sebagai pass Apple Wallet")
+	assert.Contains(t, html, `<a href="https://pay.google.com/gp/v/save/token" target="_blank">Simpan ke Google Wallet</a>`)
+}
+
+func TestAcquiredOrderNotificationTemplate_Populate(t *testing.T) {
+	t.Run("list every ticket of the order", func(t *testing.T) {
+		mt := mailtemplate.NewAcquiredOrderNotificationTemplate("")
+		buff, err := mt.Populate(&mailtemplate.AcquiredOrderNotificationData{
+			CustomerName: "John Doe",
+			OrderID:      "ORDER-1",
+			Tickets: []mailtemplate.OrderTicketData{
+				{TicketNumber: "TICKET-1", EventName: "Concert", Tier: "VIP", TicketPDFLink: "https://example.com/TICKET-1.pdf"},
+				{TicketNumber: "TICKET-2", EventName: "Concert", Tier: "VIP", TicketPDFLink: "https://example.com/TICKET-2.pdf", GoogleWalletLink: "https://pay.google.com/gp/v/save/token"},
+			},
+			AppleWalletAttached: true,
+		})
+		assert.NoError(t, err)
+		html := buff.String()
+
+		assert.Equal(t, "Acquired Tickets", mt.Subject())
+		assert.Contains(t, html, "Your order contains 2 tickets")
+		assert.Contains(t, html, "Ticket TICKET-1")
+		assert.Contains(t, html, `<a href="https://example.com/TICKET-1.pdf" target="_blank">Download</a>`)
+		assert.Contains(t, html, `<a href="https://example.com/TICKET-2.pdf" target="_blank">Download</a>`)
+		assert.Contains(t, html, `<a href="https://pay.google.com/gp/v/save/token" target="_blank">Add to Google Wallet</a>`)
+		assert.Contains(t, html, "attached as Apple Wallet passes")
+	})
+
+	t.Run("return an error without tickets", func(t *testing.T) {
+		mt := mailtemplate.NewAcquiredOrderNotificationTemplate("id")
+		_, err := mt.Populate(&mailtemplate.AcquiredOrderNotificationData{
+			CustomerName: "John Doe",
+		})
+
+		var validationErr *mailtemplate.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{"Tickets"}, validationErr.Missing)
+	})
+}
+
+func TestTicketTemplate_Populate(t *testing.T) {
+	mt := mailtemplate.NewTicketTemplate("")
+	buff, err := mt.Populate(&mailtemplate.TicketData{
+		CustomerName: "John Doe",
+		EventName:    "Concert",
+		Venue:        "GBK",
+		Tier:         "VIP",
+		TicketNumber: "TICKET-1",
+		ShowTime:     time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC),
+		Timezone:     "Asia/Jakarta",
+		Code:         "TICKET-1.signature",
+	})
+	assert.NoError(t, err)
+	html := buff.String()
+
+	assert.Contains(t, html, "<title>Ticket TICKET-1</title>")
+	assert.Contains(t, html, `class="ticketContainer"`)
+	assert.Contains(t, html, "Concert")
+	assert.Contains(t, html, "Sat, 10 Oct 2026 19:30 WIB")
+	assert.Contains(t, html, `<img class="qrCode" src="data:image/png;base64,`)
+	assert.NotContains(t, html, `class="barcode"`)
+}
+
+func TestTicketTemplate_Populate_Barcode(t *testing.T) {
+	mt := mailtemplate.NewTicketTemplate("")
+	buff, err := mt.Populate(&mailtemplate.TicketData{
+		CustomerName: "John Doe",
+		EventName:    "Concert",
+		Venue:        "GBK",
+		Tier:         "VIP",
+		TicketNumber: "TICKET-1",
+		ShowTime:     time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC),
+		Code:         "TICKET-1.signature",
+		Barcode:      true,
+	})
+	assert.NoError(t, err)
+
+	assert.Contains(t, buff.String(), `<img class="barcode" src="data:image/png;base64,`)
+}
+
+func TestPopulate_Validation(t *testing.T) {
+	t.Run("return the missing and empty fields", func(t *testing.T) {
+		mt := mailtemplate.NewAcquiredTicketNotificationTemplate("")
+		buff, err := mt.Populate(&mailtemplate.AcquiredTicketNotificationData{
+			CustomerName: "John Doe",
+		})
+
+		assert.Nil(t, buff)
+		var validationErr *mailtemplate.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, mailtemplate.AcquiredTicketNotification, validationErr.Template)
+		assert.Equal(t, []string{"TicketPDFLink"}, validationErr.Missing)
+	})
+
+	t.Run("return every required field of a nil data", func(t *testing.T) {
+		mt := mailtemplate.NewCustomerVerificationTemplate("")
+		_, err := mt.Populate(nil)
+
+		assert.EqualError(t, err, "mailtemplate: customer_verification is missing required fields: RecipientName, VerificationLink")
+	})
+
+	t.Run("treat a zero show time as missing", func(t *testing.T) {
+		mt := mailtemplate.NewTicketTemplate("")
+		_, err := mt.Populate(&mailtemplate.TicketData{
+			CustomerName: "John Doe",
+			EventName:    "Concert",
+			Venue:        "GBK",
+			Tier:         "VIP",
+			TicketNumber: "TICKET-1",
+			Code:         "TICKET-1.signature",
+		})
+
+		assert.EqualError(t, err, "mailtemplate: ticket is missing required fields: ShowTime")
+	})
+
+	t.Run("treat an empty list as missing", func(t *testing.T) {
+		mt := mailtemplate.NewAcquiredOrderNotificationTemplate("")
+		_, err := mt.Populate(&mailtemplate.AcquiredOrderNotificationData{
+			CustomerName: "John Doe",
+			Tickets:      []mailtemplate.OrderTicketData{},
+		})
+
+		assert.EqualError(t, err, "mailtemplate: acquired_order_notification is missing required fields: Tickets")
+	})
+
+	t.Run("check the keys of a map data", func(t *testing.T) {
+		err := mailtemplate.Validate(mailtemplate.AcquiredOrderNotification, &mailtemplate.MapData{
+			"CustomerName": "John Doe",
+			"Tickets":      []interface{}{},
+		})
+		assert.EqualError(t, err, "mailtemplate: acquired_order_notification is missing required fields: Tickets")
+
+		err = mailtemplate.Validate(mailtemplate.AcquiredOrderNotification, mailtemplate.MapData{
+			"Tickets": []interface{}{map[string]interface{}{"TicketNumber": "TICKET-1"}},
+		})
+		assert.EqualError(t, err, "mailtemplate: acquired_order_notification is missing required fields: CustomerName")
+
+		err = mailtemplate.Validate(mailtemplate.AcquiredOrderNotification, &mailtemplate.MapData{
+			"CustomerName": "John Doe",
+			"Tickets":      []interface{}{map[string]interface{}{"TicketNumber": "TICKET-1"}},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCandidates(t *testing.T) {
+	assert.Equal(t, []string{"id-id", "id", "en"}, mailtemplate.Candidates("id-ID"))
+	assert.Equal(t, []string{"id-id", "id", "en"}, mailtemplate.Candidates("id_ID"))
+	assert.Equal(t, []string{"id", "en"}, mailtemplate.Candidates("id"))
+	assert.Equal(t, []string{"en-us", "en"}, mailtemplate.Candidates("en-US"))
+	assert.Equal(t, []string{"en"}, mailtemplate.Candidates(""))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("render a registered template by name", func(t *testing.T) {
+		mt, err := mailtemplate.New(mailtemplate.CustomerVerification, "id")
+		assert.NoError(t, err)
+
+		data := mailtemplate.NewData(mailtemplate.CustomerVerification)
+		assert.IsType(t, &mailtemplate.VerificationEmailData{}, data)
+		data.(*mailtemplate.VerificationEmailData).RecipientName = "John Doe"
+		data.(*mailtemplate.VerificationEmailData).VerificationLink = "https://example.com/verify"
+
+		buff, err := mt.Populate(data)
+		assert.NoError(t, err)
+		assert.Contains(t, buff.String(), "Hai <b>John Doe</b>")
+	})
+
+	t.Run("return an error for an unknown template", func(t *testing.T) {
+		_, err := mailtemplate.New("unknown", "en")
+		assert.Error(t, err)
+		assert.IsType(t, &mailtemplate.MapData{}, mailtemplate.NewData("unknown"))
+	})
+
+	t.Run("list the registered templates", func(t *testing.T) {
+		assert.Equal(t, []string{
+			mailtemplate.AcquiredOrderNotification,
+			mailtemplate.AcquiredTicketNotification,
+			mailtemplate.CustomerVerification,
+			mailtemplate.Ticket,
+		}, mailtemplate.Names())
+	})
+}
+
+func TestPlainText(t *testing.T) {
+	text := mailtemplate.PlainText(`<html><head><title>T</title><style>p{}</style></head><body>
+<div style="display: none;">preheader</div>
+<!-- comment -->
+<a href="https://example.com"><img src="logo.png"></a>
+<h1>Hello &amp; welcome</h1>
+<p>Hi   <b>John</b>,<br>please verify.</p>
+<a href="https://example.com/verify">Verify</a>
+</body></html>`)
+
+	assert.Equal(t, "Hello & welcome\n\nHi John,\nplease verify.\n\nVerify (https://example.com/verify)", text)
+}
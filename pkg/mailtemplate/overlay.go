@@ -0,0 +1,87 @@
+package mailtemplate
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// overlayFS is a read only file system where the files of upper take precedence
+// over the files of lower, it lets an override directory replace only some of
+// the embedded templates.
+type overlayFS struct {
+	upper fs.FS
+	lower fs.FS
+}
+
+// Open implements fs.FS.
+func (o overlayFS) Open(name string) (fs.File, error) {
+	f, err := o.upper.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return o.lower.Open(name)
+}
+
+// ReadDir implements fs.ReadDirFS by merging the entries of both file systems.
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	upper, upperErr := fs.ReadDir(o.upper, name)
+	lower, lowerErr := fs.ReadDir(o.lower, name)
+	if upperErr != nil && lowerErr != nil {
+		return nil, lowerErr
+	}
+
+	entries := make(map[string]fs.DirEntry, len(upper)+len(lower))
+	for _, e := range lower {
+		entries[e.Name()] = e
+	}
+	for _, e := range upper {
+		entries[e.Name()] = e
+	}
+
+	merged := make([]fs.DirEntry, 0, len(entries))
+	for _, e := range entries {
+		merged = append(merged, e)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name() < merged[j].Name() })
+
+	return merged, nil
+}
+
+// excludeFS hides some files of a file system, e.g. the override files which
+// fail to parse so the embedded ones are used instead.
+type excludeFS struct {
+	fsys     fs.FS
+	excluded map[string]bool
+}
+
+// Open implements fs.FS.
+func (e excludeFS) Open(name string) (fs.File, error) {
+	if e.excluded[name] {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return e.fsys.Open(name)
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (e excludeFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(e.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !e.excluded[path.Join(name, entry.Name())] {
+			kept = append(kept, entry)
+		}
+	}
+
+	return kept, nil
+}
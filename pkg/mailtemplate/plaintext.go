@@ -0,0 +1,47 @@
+package mailtemplate
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	invisibleElements = regexp.MustCompile(`(?is)<(head|style|script|title)\b.*?</(head|style|script|title)>|<!--.*?-->`)
+	hiddenElements    = regexp.MustCompile(`(?is)<div[^>]*display:\s*none[^>]*>.*?</div>`)
+	anchorElements    = regexp.MustCompile(`(?is)<a\b[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	lineBreakElements = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|h[1-6]|li|table)>`)
+	tags              = regexp.MustCompile(`(?s)<[^>]*>`)
+	horizontalSpaces  = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLines        = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// PlainText converts the rendered HTML email to plain text, links are kept as
+// `label (url)`. It is meant for the text alternative of an email.
+func PlainText(body string) string {
+	text := invisibleElements.ReplaceAllString(body, "")
+	text = hiddenElements.ReplaceAllString(text, "")
+	text = anchorElements.ReplaceAllStringFunc(text, func(a string) string {
+		m := anchorElements.FindStringSubmatch(a)
+		href, label := m[1], strings.TrimSpace(tags.ReplaceAllString(m[2], ""))
+		if label == "" {
+			return ""
+		}
+		if label == href {
+			return href
+		}
+		return label + " (" + href + ")"
+	})
+	text = lineBreakElements.ReplaceAllString(text, "\n")
+	text = tags.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = horizontalSpaces.ReplaceAllString(text, " ")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,208 @@
+package mailtemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is used when a template or a message is not available in the
+// requested locale.
+const DefaultLocale = "en"
+
+// Location of the templates inside the template file system.
+const (
+	layoutsPattern  = "layouts/*.html"
+	partialsPattern = "partials/*.html"
+	pagesDir        = "pages"
+	localesDir      = "locales"
+)
+
+// locale is the content of `locales/<locale>.json`.
+type locale struct {
+	Subjects map[string]string `json:"subjects"`
+	Messages map[string]string `json:"messages"`
+	Format   struct {
+		Days               []string `json:"days"`
+		Months             []string `json:"months"`
+		ThousandsSeparator string   `json:"thousands_separator"`
+		DecimalSeparator   string   `json:"decimal_separator"`
+	} `json:"format"`
+}
+
+// page is a parsed template in a specific locale.
+type page struct {
+	name    string
+	locale  string
+	subject string
+	tmpl    *template.Template
+}
+
+// registry holds every parsed page, indexed by template name and locale, and
+// the messages of every locale.
+type registry struct {
+	pages   map[string]map[string]*page
+	locales map[string]locale
+}
+
+// load parses every page found under `pages/<locale>` together with all
+// layouts and partials. The page extends a layout by invoking it, e.g.
+// `{{ template "base" . }}`, and overrides the layout blocks (`title`,
+// `preheader`, `heading`, `content`, `reason`) with `{{ define }}`.
+func load(fsys fs.FS) (*registry, error) {
+	locales, err := fs.ReadDir(fsys, pagesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultLocale, err := readLocale(fsys, DefaultLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &registry{
+		pages:   make(map[string]map[string]*page),
+		locales: map[string]locale{DefaultLocale: defaultLocale},
+	}
+	for _, l := range locales {
+		if !l.IsDir() {
+			continue
+		}
+
+		loc, err := readLocale(fsys, l.Name())
+		if err != nil {
+			return nil, err
+		}
+		r.locales[l.Name()] = loc
+
+		files, err := fs.Glob(fsys, path.Join(pagesDir, l.Name(), "*.html"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+
+			tmpl, err := template.New(path.Base(file)).
+				Option("missingkey=error").
+				Funcs(funcs(l.Name(), loc, defaultLocale)).
+				ParseFS(fsys, layoutsPattern, partialsPattern, file)
+			if err != nil {
+				return nil, fmt.Errorf("parse %s: %w", file, err)
+			}
+
+			subject, ok := loc.Subjects[name]
+			if !ok {
+				subject = defaultLocale.Subjects[name]
+			}
+
+			if _, ok := r.pages[name]; !ok {
+				r.pages[name] = make(map[string]*page)
+			}
+			r.pages[name][l.Name()] = &page{
+				name:    name,
+				locale:  l.Name(),
+				subject: subject,
+				tmpl:    tmpl,
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func readLocale(fsys fs.FS, name string) (locale, error) {
+	loc := locale{}
+
+	buff, err := fs.ReadFile(fsys, path.Join(localesDir, name+".json"))
+	if err != nil {
+		if name != DefaultLocale && errors.Is(err, fs.ErrNotExist) {
+			return loc, nil
+		}
+		return loc, err
+	}
+
+	if err := json.Unmarshal(buff, &loc); err != nil {
+		return loc, fmt.Errorf("parse locale %s: %w", name, err)
+	}
+
+	return loc, nil
+}
+
+// execute validates the data against the template contract then renders the
+// parsed page with it.
+func (p *page) execute(data Data) (*bytes.Buffer, error) {
+	if err := Validate(p.name, data); err != nil {
+		return nil, err
+	}
+
+	buff := new(bytes.Buffer)
+	if err := p.tmpl.Execute(buff, data); err != nil {
+		return nil, fmt.Errorf("mailtemplate: render %s: %w", p.name, err)
+	}
+
+	return buff, nil
+}
+
+// find returns the page of the given template in the closest available locale,
+// see Candidates.
+func (r *registry) find(name, locale string) (*page, error) {
+	pages, ok := r.pages[name]
+	if !ok {
+		return nil, fmt.Errorf("mailtemplate: template %q is not registered", name)
+	}
+
+	for _, candidate := range Candidates(locale) {
+		if p, ok := pages[candidate]; ok {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("mailtemplate: template %q has no %q page", name, DefaultLocale)
+}
+
+// lookup is like find but panics when the template is not registered, it is
+// used by the constructors of the built-in templates.
+func (r *registry) lookup(name, locale string) *page {
+	p, err := r.find(name, locale)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
+
+// names returns the registered template names in order.
+func (r *registry) names() []string {
+	names := make([]string, 0, len(r.pages))
+	for name := range r.pages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Candidates returns the locales to be tried for the given locale, from the most
+// to the least specific, ending with the DefaultLocale. For example `id-ID`
+// yields `id-id`, `id`, `en`.
+func Candidates(locale string) []string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+
+	candidates := make([]string, 0, 3)
+	if locale != "" {
+		candidates = append(candidates, locale)
+		if i := strings.Index(locale, "-"); i > 0 {
+			candidates = append(candidates, locale[:i])
+		}
+	}
+	if len(candidates) == 0 || candidates[len(candidates)-1] != DefaultLocale {
+		candidates = append(candidates, DefaultLocale)
+	}
+
+	return candidates
+}
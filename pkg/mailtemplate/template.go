@@ -0,0 +1,61 @@
+package mailtemplate
+
+import "bytes"
+
+// MapData is a free-form Data, it is used for templates without a dedicated
+// data type such as the ones added through a Watcher.
+type MapData map[string]interface{}
+
+// Get implements Data.
+func (d MapData) Get() interface{} {
+	return map[string]interface{}(d)
+}
+
+// dataTypes returns an empty data of the built-in templates.
+var dataTypes = map[string]func() Data{
+	CustomerVerification:       func() Data { return &VerificationEmailData{} },
+	AcquiredTicketNotification: func() Data { return &AcquiredTicketNotificationData{} },
+	AcquiredOrderNotification:  func() Data { return &AcquiredOrderNotificationData{} },
+	Ticket:                     func() Data { return &TicketData{} },
+}
+
+// NamedTemplate is a concrete struct of MailTemplate resolved by name.
+type NamedTemplate struct {
+	page *page
+}
+
+// New is a constructor of any registered template, see Names. The template is
+// resolved from the given locale like the constructors of the built-in ones.
+func New(name, locale string) (MailTemplate, error) {
+	p, err := defaultRegistry.Load().find(name, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NamedTemplate{page: p}, nil
+}
+
+// NewData returns an empty data for the template, to be filled by e.g.
+// `json.Unmarshal`. It is a *MapData when the template has no dedicated type.
+func NewData(name string) Data {
+	if newData, ok := dataTypes[name]; ok {
+		return newData()
+	}
+	return &MapData{}
+}
+
+// Names returns the names of the registered templates.
+func Names() []string {
+	return defaultRegistry.Load().names()
+}
+
+// Populate will populate the template with data, an error is returned when the
+// data does not satisfy the template contract.
+func (nt *NamedTemplate) Populate(data Data) (buff *bytes.Buffer, err error) {
+	return nt.page.execute(data)
+}
+
+// Subject returns the translated email subject.
+func (nt *NamedTemplate) Subject() string {
+	return nt.page.subject
+}
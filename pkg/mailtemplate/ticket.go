@@ -1,26 +1,17 @@
 package mailtemplate
 
-import (
-	"bytes"
-	"embed"
-	"html/template"
-)
-
-//go:embed html/ticket.html
-var ticketTemplate embed.FS
+import "bytes"
 
 // TicketTemplate is a concrete struct of MailTemplate
 type TicketTemplate struct {
-	rawBuff []byte
-	raw     string
+	page *page
 }
 
-// NewTicketTemplate is a constructor.
-func NewTicketTemplate() MailTemplate {
-	rawBuff, _ := ticketTemplate.ReadFile("html/ticket.html")
+// NewTicketTemplate is a constructor. The template is resolved
+// from the given locale, e.g. `id-ID` falls back to `id` then to DefaultLocale.
+func NewTicketTemplate(locale string) MailTemplate {
 	return &TicketTemplate{
-		rawBuff: rawBuff,
-		raw:     string(rawBuff),
+		page: defaultRegistry.Load().lookup(Ticket, locale),
 	}
 }
 
@@ -28,20 +19,25 @@ func NewTicketTemplate() MailTemplate {
 //
 // The `Data` must contain fields:
 //
-// `RecipientName` As the name of recipient.
+// `CustomerName`, `EventName`, `Venue`, `Tier`, `TicketNumber`, `ShowTime` and `Code`.
+//
+// An error is returned when any of them is missing or empty.
 //
 // For example:
 //
 //	// set the arguments
-//	data := &ExampleData{}
-//	data.RecipientName = "John Doe"
+//	data := &TicketData{}
+//	data.CustomerName = "John Doe"
+//	...
 //
 //	// instantiate the template
-//	template := NewExampleTemplate()
-//	tbuff := template.Populate(data)
-func (et *TicketTemplate) Populate(data Data) (buff *bytes.Buffer) {
-	buff = new(bytes.Buffer)
-	t, _ := template.New("acquired-ticket-notification").Parse(et.raw)
-	t.Execute(buff, data)
-	return
+//	template := NewTicketTemplate("id-ID")
+//	tbuff, err := template.Populate(data)
+func (et *TicketTemplate) Populate(data Data) (buff *bytes.Buffer, err error) {
+	return et.page.execute(data)
+}
+
+// Subject returns the translated email subject.
+func (et *TicketTemplate) Subject() string {
+	return et.page.subject
 }
@@ -0,0 +1,203 @@
+package mailtemplate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WatcherProperty is the property of Watcher.
+type WatcherProperty struct {
+	Logger *logrus.Logger
+	// Source holds the template overrides, laid out like the embedded `html`
+	// directory (`layouts`, `partials`, `pages/<locale>`, `locales`). Any file
+	// system works, e.g. `os.DirFS` for a directory or an adapter of a bucket.
+	Source fs.FS
+	// Interval is the polling interval of the source, the source is loaded only
+	// once when it is zero.
+	Interval time.Duration
+}
+
+// Watcher loads the template overrides on top of the embedded templates and
+// atomically swaps the parsed templates whenever the source changes.
+type Watcher struct {
+	logger   *logrus.Logger
+	source   fs.FS
+	interval time.Duration
+	checksum string
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewWatcher is a constructor.
+func NewWatcher(props WatcherProperty) *Watcher {
+	logger := props.Logger
+	if logger == nil {
+		logger = logrus.New()
+	}
+
+	return &Watcher{
+		logger:   logger,
+		source:   props.Source,
+		interval: props.Interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Load parses the overrides and swaps them in. An override file which fails to
+// parse is replaced by its embedded version and its error is returned. The
+// current templates are kept when the source can not be read.
+//
+// The checksum of the source is recorded even when a file is broken, the source
+// is loaded again only once it changes.
+func (w *Watcher) Load() error {
+	checksum, err := w.sum()
+	if err != nil {
+		return err
+	}
+	w.checksum = checksum
+
+	source, broken := checkOverrides(w.source)
+	r, err := load(overlayFS{upper: source, lower: embeddedFS})
+	if err != nil {
+		return err
+	}
+
+	defaultRegistry.Store(r)
+	return broken
+}
+
+// checkOverrides parses every override file on its own and hides the broken
+// ones, the returned error joins their parse errors.
+func checkOverrides(source fs.FS) (fs.FS, error) {
+	excluded := make(map[string]bool)
+	var errs []error
+
+	fs.WalkDir(source, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		content, err := fs.ReadFile(source, p)
+		if err == nil {
+			err = checkOverride(p, content)
+		}
+		if err != nil {
+			excluded[p] = true
+			errs = append(errs, fmt.Errorf("parse %s: %w", p, err))
+		}
+
+		return nil
+	})
+
+	if len(excluded) == 0 {
+		return source, nil
+	}
+
+	return excludeFS{fsys: source, excluded: excluded}, errors.Join(errs...)
+}
+
+// checkOverride parses a locale or a template file, a template is parsed with
+// the template functions but without the templates it refers to.
+func checkOverride(p string, content []byte) error {
+	switch path.Ext(p) {
+	case ".json":
+		return json.Unmarshal(content, &locale{})
+	case ".html":
+		_, err := template.New(path.Base(p)).
+			Funcs(funcs(DefaultLocale, locale{}, locale{})).
+			Parse(string(content))
+		return err
+	}
+
+	return nil
+}
+
+// Start loads the overrides then polls the source for changes in background.
+func (w *Watcher) Start() {
+	if err := w.Load(); err != nil {
+		w.logger.WithError(err).Error("mailtemplate: fall back to the embedded templates")
+	}
+
+	if w.interval <= 0 {
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.reload()
+			}
+		}
+	}()
+}
+
+// Close stops polling the source.
+func (w *Watcher) Close() error {
+	close(w.stop)
+	w.wg.Wait()
+	return nil
+}
+
+func (w *Watcher) reload() {
+	checksum, err := w.sum()
+	if err != nil {
+		w.logger.WithError(err).Error("mailtemplate: could not read the template source")
+		return
+	}
+	if checksum == w.checksum {
+		return
+	}
+
+	if err := w.Load(); err != nil {
+		w.logger.WithError(err).Error("mailtemplate: fall back to the embedded templates")
+		return
+	}
+
+	w.logger.Info("mailtemplate: templates are reloaded")
+}
+
+// sum returns the checksum of every file path and content of the source.
+func (w *Watcher) sum() (string, error) {
+	h := sha256.New()
+	err := fs.WalkDir(w.source, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := fs.ReadFile(w.source, path)
+		if err != nil {
+			return err
+		}
+
+		h.Write([]byte(path))
+		h.Write(content)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,90 @@
+package mailtemplate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeOverride(t *testing.T, dir, name, content string) {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func populateVerification(t *testing.T) string {
+	buff, err := NewCustomerVerificationTemplate("en").Populate(&VerificationEmailData{
+		RecipientName:    "John Doe",
+		VerificationLink: "https://example.com/verify",
+	})
+	assert.NoError(t, err)
+	return buff.String()
+}
+
+func TestWatcher(t *testing.T) {
+	t.Cleanup(func() { defaultRegistry.Store(embeddedRegistry) })
+
+	dir := t.TempDir()
+	w := NewWatcher(WatcherProperty{Source: os.DirFS(dir), Interval: 10 * time.Millisecond})
+
+	t.Run("override a page and keep the embedded layouts", func(t *testing.T) {
+		writeOverride(t, dir, "pages/en/customer_verification.html",
+			`{{ template "base" . }}{{ define "content" }}<p>Welcome {{ .RecipientName }}</p>{{ end }}`)
+
+		assert.NoError(t, w.Load())
+
+		html := populateVerification(t)
+		assert.Contains(t, html, "<p>Welcome John Doe</p>")
+		assert.Contains(t, html, "<!DOCTYPE html>")
+	})
+
+	t.Run("fall back to the embedded file of a broken override", func(t *testing.T) {
+		writeOverride(t, dir, "pages/en/customer_verification.html", `{{ template "base" . }`)
+		writeOverride(t, dir, "pages/id/customer_verification.html",
+			`{{ template "base" . }}{{ define "content" }}<p>Selamat datang {{ .RecipientName }}</p>{{ end }}`)
+
+		err := w.Load()
+		assert.ErrorContains(t, err, "pages/en/customer_verification.html")
+		assert.NotContains(t, err.Error(), "pages/id/customer_verification.html")
+
+		assert.Contains(t, populateVerification(t), "<b>John Doe</b>")
+		buff, err := NewCustomerVerificationTemplate("id").Populate(&VerificationEmailData{
+			RecipientName:    "John Doe",
+			VerificationLink: "https://example.com/verify",
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, buff.String(), "<p>Selamat datang John Doe</p>")
+	})
+
+	t.Run("load a broken source once", func(t *testing.T) {
+		logger, hook := test.NewNullLogger()
+		w := NewWatcher(WatcherProperty{Logger: logger, Source: os.DirFS(dir)})
+
+		w.reload()
+		w.reload()
+		assert.Len(t, hook.AllEntries(), 1)
+		assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	})
+
+	t.Run("reload the changed source in background", func(t *testing.T) {
+		w.Start()
+		defer w.Close()
+
+		writeOverride(t, dir, "pages/en/customer_verification.html",
+			`{{ template "base" . }}{{ define "content" }}<p>Reloaded {{ .RecipientName }}</p>{{ end }}`)
+
+		assert.Eventually(t, func() bool {
+			buff, err := NewCustomerVerificationTemplate("en").Populate(&VerificationEmailData{
+				RecipientName:    "John Doe",
+				VerificationLink: "https://example.com/verify",
+			})
+			return err == nil && strings.Contains(buff.String(), "<p>Reloaded John Doe</p>")
+		}, time.Second, 10*time.Millisecond)
+	})
+}
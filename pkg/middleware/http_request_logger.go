@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"time"
 
@@ -33,7 +36,7 @@ type httpRequestLoggerMiddleware struct {
 func NewHTTPRequestLogger(logger *logrus.Logger, debug bool, statusCode ...int) HTTPRequestLogger {
 	if debug {
 		httpStatusMap := make(map[int]bool)
-		for s := range statusCode {
+		for _, s := range statusCode {
 			httpStatusMap[s] = true
 		}
 		return &httpRequestLoggerMiddleware{
@@ -45,18 +48,89 @@ func NewHTTPRequestLogger(logger *logrus.Logger, debug bool, statusCode ...int)
 	return &unimplementHTTPRequestLogger{}
 }
 
+// redacted replaces the credentials in the logs.
+const redacted = "REDACTED"
+
+// sensitiveHeaders carry credentials, e.g. the token subprotocol of a WebSocket
+// handshake.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":          true,
+	"Cookie":                 true,
+	"Sec-Websocket-Protocol": true,
+}
+
+// sensitiveQueries carry credentials in the url.
+var sensitiveQueries = []string{"access_token", "token"}
+
+// redactURI replaces the credentials in the query of the request uri.
+func redactURI(uri string) string {
+	u, err := url.ParseRequestURI(uri)
+	if err != nil {
+		return uri
+	}
+
+	query := u.Query()
+	found := false
+	for _, key := range sensitiveQueries {
+		if query.Has(key) {
+			query.Set(key, redacted)
+			found = true
+		}
+	}
+	if !found {
+		return uri
+	}
+
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// maxRecordedBodySize caps the response body kept for the log, a long-lived
+// response such as a stream would be kept whole otherwise.
+const maxRecordedBodySize = 4 << 10
+
 type wrappedResponseWriter struct {
 	http.ResponseWriter
-	recorder http.ResponseWriter
+	recorder  *httptest.ResponseRecorder
+	truncated bool
+	hijacked  bool
 }
 
-func (wrw wrappedResponseWriter) WriteHeader(statusCode int) {
+func (wrw *wrappedResponseWriter) WriteHeader(statusCode int) {
 	wrw.recorder.WriteHeader(statusCode)
 	wrw.ResponseWriter.WriteHeader(statusCode)
 }
 
-func (wrw wrappedResponseWriter) Write(b []byte) (n int, err error) {
-	wrw.recorder.Write(b)
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// flush a stream.
+func (wrw *wrappedResponseWriter) Unwrap() http.ResponseWriter {
+	return wrw.ResponseWriter
+}
+
+// Hijack lets a handler take over the connection, e.g. to upgrade it to a
+// WebSocket.
+func (wrw *wrappedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(wrw.ResponseWriter).Hijack()
+	if err == nil {
+		wrw.hijacked = true
+		wrw.recorder.WriteHeader(http.StatusSwitchingProtocols)
+	}
+	return conn, rw, err
+}
+
+// Write records the body up to maxRecordedBodySize, an event stream is not
+// recorded at all.
+func (wrw *wrappedResponseWriter) Write(b []byte) (n int, err error) {
+	if !wrw.truncated {
+		if strings.HasPrefix(wrw.Header().Get("Content-Type"), "text/event-stream") {
+			wrw.truncated = true
+		} else if room := maxRecordedBodySize - wrw.recorder.Body.Len(); len(b) > room {
+			wrw.recorder.Write(b[:room])
+			wrw.truncated = true
+		} else {
+			wrw.recorder.Write(b)
+		}
+	}
 	return wrw.ResponseWriter.Write(b)
 }
 
@@ -64,7 +138,7 @@ func (m *httpRequestLoggerMiddleware) Middleware(handler http.Handler) http.Hand
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		recoreder := httptest.NewRecorder()
 
-		wrappedResponseWriter := wrappedResponseWriter{w, recoreder}
+		wrappedResponseWriter := &wrappedResponseWriter{ResponseWriter: w, recorder: recoreder}
 
 		requestHeader := r.Header
 
@@ -89,22 +163,31 @@ func (m *httpRequestLoggerMiddleware) Middleware(handler http.Handler) http.Hand
 
 		var responseBodyData interface{}
 		// json.NewDecoder(result.Body).Decode(&responseBodyData)
-		bindData(result.Body, &responseBodyData)
+		if !wrappedResponseWriter.truncated && !wrappedResponseWriter.hijacked {
+			bindData(result.Body, &responseBodyData)
+		}
 
 		responseHeader := w.Header().Clone()
 
 		captured := logrus.Fields{}
 		captured["http.method"] = r.Method
-		captured["http.url"] = r.RequestURI
+		captured["http.url"] = redactURI(r.RequestURI)
 		captured["http.request.body"] = requestBodyData
 		captured["http.status_code"] = result.StatusCode
 		for reqHeaderKey, reqHeaderCol := range requestHeader {
-			captured[fmt.Sprintf("http.request.header.%s", strings.ReplaceAll(strings.ToLower(reqHeaderKey), " ", "_"))] = strings.Join(reqHeaderCol, ",")
+			value := strings.Join(reqHeaderCol, ",")
+			if sensitiveHeaders[http.CanonicalHeaderKey(reqHeaderKey)] {
+				value = redacted
+			}
+			captured[fmt.Sprintf("http.request.header.%s", strings.ReplaceAll(strings.ToLower(reqHeaderKey), " ", "_"))] = value
 		}
 		for resHeaderKey, resHeaderCol := range responseHeader {
 			captured[fmt.Sprintf("http.response.header.%s", strings.ReplaceAll(strings.ToLower(resHeaderKey), " ", "_"))] = strings.Join(resHeaderCol, ",")
 		}
 		captured["http.response.body"] = responseBodyData
+		if wrappedResponseWriter.truncated {
+			captured["http.response.body_truncated"] = true
+		}
 		captured["time_consumption"] = elapsed.String()
 
 		if ok := m.httpStatusMap[result.StatusCode]; ok {
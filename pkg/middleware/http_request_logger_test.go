@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/middleware"
+)
+
+func TestHTTPRequestLogger_StatusCode(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	handler := middleware.NewHTTPRequestLogger(logger, true, http.StatusInternalServerError).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Empty(t, hook.AllEntries(), "a status code which is not given should not be logged")
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	assert.Len(t, hook.AllEntries(), 1)
+}
+
+func TestHTTPRequestLogger_Redact(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.InfoLevel)
+
+	handler := middleware.NewHTTPRequestLogger(logger, true, http.StatusOK).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/tm-notification/customer/notifications/ws?access_token=secret-token&last_event_id=7", nil)
+	r.Header.Set("Authorization", "Bearer secret-token")
+	r.Header.Set("Sec-WebSocket-Protocol", "access_token, secret-token")
+	r.Header.Set("Accept", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	entry := hook.LastEntry()
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, "/tm-notification/customer/notifications/ws?access_token=REDACTED&last_event_id=7", entry.Data["http.url"])
+		assert.Equal(t, "REDACTED", entry.Data["http.request.header.authorization"])
+		assert.Equal(t, "REDACTED", entry.Data["http.request.header.sec-websocket-protocol"])
+		assert.Equal(t, "application/json", entry.Data["http.request.header.accept"])
+	}
+}
+
+func TestHTTPRequestLogger_ResponseBody(t *testing.T) {
+	serve := func(contentType, body string) *logrus.Entry {
+		logger, hook := test.NewNullLogger()
+		handler := middleware.NewHTTPRequestLogger(logger, true, http.StatusOK).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(body))
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tm-notification", nil))
+		assert.Equal(t, body, w.Body.String(), "the response should be written whole")
+
+		return hook.LastEntry()
+	}
+
+	t.Run("record a small body", func(t *testing.T) {
+		entry := serve("application/json", `{"status":"OK"}`)
+		assert.Equal(t, map[string]interface{}{"status": "OK"}, entry.Data["http.response.body"])
+		assert.NotContains(t, entry.Data, "http.response.body_truncated")
+	})
+
+	t.Run("cap a large body", func(t *testing.T) {
+		entry := serve("application/json", `{"data":"`+strings.Repeat("x", 8<<10)+`"}`)
+		assert.Nil(t, entry.Data["http.response.body"])
+		assert.Equal(t, true, entry.Data["http.response.body_truncated"])
+	})
+
+	t.Run("skip an event stream", func(t *testing.T) {
+		entry := serve("text/event-stream", "id: 1\ndata: {}\n\n")
+		assert.Nil(t, entry.Data["http.response.body"])
+		assert.Equal(t, true, entry.Data["http.response.body_truncated"])
+	})
+}
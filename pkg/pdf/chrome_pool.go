@@ -0,0 +1,318 @@
+package pdf
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Errors.
+var (
+	ErrPoolClosed = fmt.Errorf("pdf: chrome pool is closed")
+)
+
+// ChromePoolProperty is the property of ChromePool.
+type ChromePoolProperty struct {
+	Logger *logrus.Logger
+	// Size is the maximum number of tabs rendering at the same time.
+	Size int
+	// RenderTimeout bounds the time a tab is held by a single render.
+	RenderTimeout time.Duration
+	// HealthCheckInterval is the interval of the browser liveness probe.
+	HealthCheckInterval time.Duration
+	// AllocatorOptions overrides the default headless Chrome flags.
+	AllocatorOptions []chromedp.ExecAllocatorOption
+}
+
+// ChromePoolStats is a snapshot of the pool utilisation.
+type ChromePoolStats struct {
+	Size     int
+	InUse    int
+	Restarts int64
+}
+
+// ChromePool keeps a single long-lived headless Chrome and hands out a bounded
+// number of tabs. A crashed or unresponsive browser is restarted by the health
+// check or on the next acquisition.
+type ChromePool struct {
+	logger              *logrus.Logger
+	size                int
+	renderTimeout       time.Duration
+	healthCheckInterval time.Duration
+	allocatorOptions    []chromedp.ExecAllocatorOption
+
+	tabs     chan struct{}
+	inUse    atomic.Int64
+	restarts atomic.Int64
+
+	mu            sync.RWMutex
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+	healthy       bool
+	closed        bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+
+	tabsInUse      metric.Int64UpDownCounter
+	waitDuration   metric.Float64Histogram
+	renderDuration metric.Float64Histogram
+	restartCount   metric.Int64Counter
+}
+
+// NewChromePool is a constructor. It launches the browser right away, a launch
+// failure is logged and retried on the first acquisition.
+func NewChromePool(props ChromePoolProperty) *ChromePool {
+	logger := props.Logger
+	if logger == nil {
+		logger = logrus.New()
+	}
+
+	size := props.Size
+	if size < 1 {
+		size = 1
+	}
+
+	renderTimeout := props.RenderTimeout
+	if renderTimeout <= 0 {
+		renderTimeout = 30 * time.Second
+	}
+
+	allocatorOptions := props.AllocatorOptions
+	if allocatorOptions == nil {
+		allocatorOptions = chromedp.DefaultExecAllocatorOptions[:]
+	}
+
+	p := &ChromePool{
+		logger:              logger,
+		size:                size,
+		renderTimeout:       renderTimeout,
+		healthCheckInterval: props.HealthCheckInterval,
+		allocatorOptions:    allocatorOptions,
+		tabs:                make(chan struct{}, size),
+		stop:                make(chan struct{}),
+	}
+	p.instrument()
+
+	if err := p.restart(); err != nil {
+		logger.WithError(err).Error("pdf: failed to launch chrome")
+	}
+
+	if p.healthCheckInterval > 0 {
+		p.wg.Add(1)
+		go p.watch()
+	}
+
+	return p
+}
+
+func (p *ChromePool) instrument() {
+	meter := otel.Meter("github.com/tsel-ticketmaster/tm-notification/pkg/pdf")
+
+	p.tabsInUse, _ = meter.Int64UpDownCounter("pdf.chrome.tabs.in_use",
+		metric.WithDescription("Number of chrome tabs currently rendering."))
+	p.waitDuration, _ = meter.Float64Histogram("pdf.chrome.tabs.wait_duration",
+		metric.WithDescription("Time spent waiting for a free chrome tab."), metric.WithUnit("s"))
+	p.renderDuration, _ = meter.Float64Histogram("pdf.chrome.render.duration",
+		metric.WithDescription("Time spent rendering a document in a chrome tab."), metric.WithUnit("s"))
+	p.restartCount, _ = meter.Int64Counter("pdf.chrome.restarts",
+		metric.WithDescription("Number of chrome browser restarts."))
+	meter.Int64ObservableGauge("pdf.chrome.tabs.capacity",
+		metric.WithDescription("Maximum number of chrome tabs rendering at the same time."),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(p.size))
+			return nil
+		}))
+}
+
+// Stats returns the pool utilisation.
+func (p *ChromePool) Stats() ChromePoolStats {
+	return ChromePoolStats{
+		Size:     p.size,
+		InUse:    int(p.inUse.Load()),
+		Restarts: p.restarts.Load(),
+	}
+}
+
+// acquire waits for a free slot and opens a new tab in the browser. The tab is
+// closed after RenderTimeout or when ctx is done, whichever comes first. The
+// returned release must be called with the render error, if any.
+func (p *ChromePool) acquire(ctx context.Context) (tabCtx context.Context, release func(err error), err error) {
+	waitStart := time.Now()
+	select {
+	case p.tabs <- struct{}{}:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+	p.waitDuration.Record(ctx, time.Since(waitStart).Seconds())
+
+	browserCtx, err := p.browser()
+	if err != nil {
+		<-p.tabs
+		return nil, nil, err
+	}
+
+	p.inUse.Add(1)
+	p.tabsInUse.Add(ctx, 1)
+
+	tabCtx, cancelTab := chromedp.NewContext(browserCtx)
+	tabCtx, cancelTimeout := context.WithTimeout(tabCtx, p.renderTimeout)
+	stopAfter := context.AfterFunc(ctx, cancelTimeout)
+
+	renderStart := time.Now()
+	release = func(err error) {
+		stopAfter()
+		cancelTimeout()
+		cancelTab()
+
+		p.renderDuration.Record(ctx, time.Since(renderStart).Seconds())
+		p.tabsInUse.Add(ctx, -1)
+		p.inUse.Add(-1)
+		<-p.tabs
+
+		if err != nil && browserCtx.Err() != nil {
+			p.markUnhealthy(browserCtx)
+		}
+	}
+
+	return tabCtx, release, nil
+}
+
+// browser returns the running browser, restarting it when it is unhealthy.
+func (p *ChromePool) browser() (context.Context, error) {
+	p.mu.RLock()
+	closed, healthy, browserCtx := p.closed, p.healthy, p.browserCtx
+	p.mu.RUnlock()
+
+	if closed {
+		return nil, ErrPoolClosed
+	}
+	if healthy && browserCtx.Err() == nil {
+		return browserCtx, nil
+	}
+
+	if err := p.restart(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.browserCtx, nil
+}
+
+func (p *ChromePool) markUnhealthy(browserCtx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.browserCtx == browserCtx {
+		p.healthy = false
+	}
+}
+
+// restart kills the current browser, if any, and launches a new one.
+func (p *ChromePool) restart() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	if p.healthy && p.browserCtx != nil && p.browserCtx.Err() == nil {
+		return nil
+	}
+
+	if p.browserCancel != nil {
+		p.browserCancel()
+		p.restarts.Add(1)
+		p.restartCount.Add(context.Background(), 1)
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), p.allocatorOptions...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+	cancel := func() {
+		browserCancel()
+		allocCancel()
+	}
+
+	// the first run launches the browser.
+	if err := chromedp.Run(browserCtx); err != nil {
+		cancel()
+		p.browserCtx, p.browserCancel, p.healthy = nil, nil, false
+		return fmt.Errorf("pdf: launch chrome: %w", err)
+	}
+
+	p.browserCtx, p.browserCancel, p.healthy = browserCtx, cancel, true
+	return nil
+}
+
+// watch probes the browser periodically and restarts it when it does not
+// respond.
+func (p *ChromePool) watch() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if err := p.probe(); err != nil {
+				p.logger.WithError(err).Warn("pdf: chrome is unhealthy, restarting")
+
+				p.mu.Lock()
+				p.healthy = false
+				p.mu.Unlock()
+
+				if err := p.restart(); err != nil {
+					p.logger.WithError(err).Error("pdf: failed to restart chrome")
+				}
+			}
+		}
+	}
+}
+
+// probe opens a blank tab within the health check interval.
+func (p *ChromePool) probe() error {
+	p.mu.RLock()
+	browserCtx := p.browserCtx
+	p.mu.RUnlock()
+
+	if browserCtx == nil {
+		return fmt.Errorf("pdf: chrome is not running")
+	}
+
+	tabCtx, cancel := chromedp.NewContext(browserCtx)
+	defer cancel()
+	tabCtx, cancelTimeout := context.WithTimeout(tabCtx, p.healthCheckInterval)
+	defer cancelTimeout()
+
+	return chromedp.Run(tabCtx, chromedp.Navigate("about:blank"))
+}
+
+// Close stops the health check and kills the browser.
+func (p *ChromePool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	if p.browserCancel != nil {
+		p.browserCancel()
+	}
+	p.mu.Unlock()
+
+	close(p.stop)
+	p.wg.Wait()
+
+	return nil
+}
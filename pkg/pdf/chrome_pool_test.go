@@ -0,0 +1,82 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestChromePool(t *testing.T, size int) *ChromePool {
+	logger := logrus.New()
+	logger.SetLevel(logrus.FatalLevel)
+
+	p := NewChromePool(ChromePoolProperty{
+		Logger:        logger,
+		Size:          size,
+		RenderTimeout: 10 * time.Second,
+	})
+	t.Cleanup(func() { p.Close() })
+
+	return p
+}
+
+func TestChromePool_Acquire(t *testing.T) {
+	t.Run("wait for a free tab until the context is done", func(t *testing.T) {
+		p := newTestChromePool(t, 1)
+		p.tabs <- struct{}{}
+		defer func() { <-p.tabs }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, _, err := p.acquire(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 0, p.Stats().InUse)
+	})
+
+	t.Run("return error once the pool is closed", func(t *testing.T) {
+		p := newTestChromePool(t, 1)
+		assert.NoError(t, p.Close())
+
+		_, _, err := p.acquire(context.Background())
+		assert.ErrorIs(t, err, ErrPoolClosed)
+		assert.Len(t, p.tabs, 0)
+	})
+}
+
+func TestChromeRenderer_Render(t *testing.T) {
+	found := false
+	for _, name := range []string{"google-chrome", "chromium", "chromium-browser", "headless-shell"} {
+		if _, err := exec.LookPath(name); err == nil {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Skip("chrome is not installed")
+	}
+
+	p := newTestChromePool(t, 2)
+	r := NewChromeRenderer(p)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			pdfBytes, err := r.Render(context.Background(), []byte("<h1>Ticket</h1>"), DefaultOptions())
+			assert.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(pdfBytes, []byte("%PDF")))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, ChromePoolStats{Size: 2, InUse: 0, Restarts: 0}, p.Stats())
+}
@@ -0,0 +1,68 @@
+package pdf
+
+import (
+	"context"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// ChromeRenderer is a concrete struct of Renderer, it prints the document in a
+// tab of the headless Chrome held by a ChromePool.
+type ChromeRenderer struct {
+	pool *ChromePool
+}
+
+// NewChromeRenderer is a constructor.
+func NewChromeRenderer(pool *ChromePool) Renderer {
+	return &ChromeRenderer{
+		pool: pool,
+	}
+}
+
+// Render implements Renderer.
+func (r *ChromeRenderer) Render(ctx context.Context, html []byte, opts Options) (pdfBytes []byte, err error) {
+	tabCtx, release, err := r.pool.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(err) }()
+
+	if err = chromedp.Run(tabCtx, printToPDF(html, opts, &pdfBytes)); err != nil {
+		return nil, err
+	}
+
+	return pdfBytes, nil
+}
+
+// printToPDF loads the document in a blank tab and prints it.
+func printToPDF(html []byte, opts Options, pdfBytes *[]byte) chromedp.Tasks {
+	return chromedp.Tasks{
+		chromedp.Navigate("about:blank"),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			frameTree, err := page.GetFrameTree().Do(ctx)
+			if err != nil {
+				return err
+			}
+			return page.SetDocumentContent(frameTree.Frame.ID, string(html)).Do(ctx)
+		}),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			printResult, _, err := page.PrintToPDF().
+				WithPaperWidth(opts.PageSize.Width).
+				WithPaperHeight(opts.PageSize.Height).
+				WithLandscape(opts.Landscape).
+				WithMarginTop(opts.Margin.Top).
+				WithMarginRight(opts.Margin.Right).
+				WithMarginBottom(opts.Margin.Bottom).
+				WithMarginLeft(opts.Margin.Left).
+				WithPrintBackground(opts.PrintBackground).
+				Do(ctx)
+			if err != nil {
+				return err
+			}
+
+			*pdfBytes = printResult
+			return nil
+		}),
+	}
+}
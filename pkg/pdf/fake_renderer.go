@@ -0,0 +1,49 @@
+package pdf
+
+import (
+	"context"
+	"sync"
+)
+
+// fakePDF is the smallest valid PDF document, a single blank page.
+const fakePDF = "%PDF-1.4\n" +
+	"1 0 obj<</Type/Catalog/Pages 2 0 R>>endobj\n" +
+	"2 0 obj<</Type/Pages/Kids[3 0 R]/Count 1>>endobj\n" +
+	"3 0 obj<</Type/Page/Parent 2 0 R/MediaBox[0 0 595 842]>>endobj\n" +
+	"trailer<</Root 1 0 R>>\n" +
+	"%%EOF\n"
+
+// FakeRenderer is a Renderer which does not need a browser, it is meant for
+// unit tests. It returns Err when set, otherwise a blank single page PDF.
+type FakeRenderer struct {
+	Err error
+
+	mu       sync.Mutex
+	rendered [][]byte
+}
+
+// NewFakeRenderer is a constructor.
+func NewFakeRenderer() *FakeRenderer {
+	return &FakeRenderer{}
+}
+
+// Render implements Renderer.
+func (r *FakeRenderer) Render(ctx context.Context, html []byte, opts Options) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rendered = append(r.rendered, html)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	return []byte(fakePDF), nil
+}
+
+// Rendered returns the documents passed to Render in order.
+func (r *FakeRenderer) Rendered() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([][]byte(nil), r.rendered...)
+}
@@ -0,0 +1,131 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+)
+
+// Colors of the ticket, matching `partials/ticket_card.html`.
+var (
+	ticketBackground = [3]int{0xB7, 0xB5, 0xE4}
+	ticketText       = [3]int{0x2F, 0x4F, 0x4F}
+	ticketRule       = [3]int{0xEF, 0xEF, 0xEF}
+)
+
+// NativeTicketRenderer is a concrete struct of TicketRenderer, it lays out the
+// ticket in Go without a browser.
+type NativeTicketRenderer struct{}
+
+// NewNativeTicketRenderer is a constructor.
+func NewNativeTicketRenderer() TicketRenderer {
+	return &NativeTicketRenderer{}
+}
+
+// RenderTicket implements TicketRenderer.
+func (r *NativeTicketRenderer) RenderTicket(ctx context.Context, locale string, data *mailtemplate.TicketData) ([]byte, error) {
+	if err := mailtemplate.Validate(mailtemplate.Ticket, data); err != nil {
+		return nil, err
+	}
+
+	l := mailtemplate.NewLocalizer(locale)
+
+	qrCode, err := ticketcode.QRCodePNG(data.Code, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	// A5 landscape, in millimeters.
+	f := fpdf.New("L", "mm", "A5", "")
+	f.SetTitle(fmt.Sprintf("%s %s", l.T("ticket"), data.TicketNumber), true)
+	f.SetAuthor("TicketMaster", true)
+	f.SetAutoPageBreak(false, 0)
+	f.AddPage()
+	tr := f.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth, pageHeight := f.GetPageSize()
+	f.SetFillColor(ticketBackground[0], ticketBackground[1], ticketBackground[2])
+	f.Rect(0, 0, pageWidth, pageHeight, "F")
+
+	// card
+	cardX, cardY, cardWidth, cardHeight := 20.0, 20.0, pageWidth-40, pageHeight-40
+	f.SetFillColor(255, 255, 255)
+	f.RoundedRect(cardX, cardY, cardWidth, cardHeight, 4, "1234", "F")
+
+	f.SetTextColor(ticketText[0], ticketText[1], ticketText[2])
+	f.SetFont("Helvetica", "B", 20)
+	f.SetXY(cardX+8, cardY+6)
+	f.CellFormat(cardWidth-16, 10, "TicketMaster", "", 1, "L", false, 0, "")
+
+	f.SetDrawColor(ticketRule[0], ticketRule[1], ticketRule[2])
+	f.SetLineWidth(0.5)
+	f.Line(cardX+8, cardY+18, cardX+cardWidth-8, cardY+18)
+
+	// details on the left, qr code on the right
+	qrSize := 48.0
+	detailsWidth := cardWidth - qrSize - 28
+	details := [][2]string{
+		{l.T("name"), data.CustomerName},
+		{l.T("event"), data.EventName},
+		{l.T("venue"), data.Venue},
+		{l.T("country"), data.Country},
+		{l.T("city"), data.City},
+		{l.T("tier"), data.Tier},
+	}
+
+	f.SetY(cardY + 22)
+	for _, d := range details {
+		f.SetX(cardX + 8)
+		f.SetFont("Helvetica", "B", 11)
+		f.CellFormat(24, 7, tr(d[0]+":"), "", 0, "L", false, 0, "")
+		f.SetFont("Helvetica", "", 11)
+		f.CellFormat(detailsWidth-24, 7, tr(d[1]), "", 1, "L", false, 0, "")
+	}
+
+	f.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrCode))
+	f.ImageOptions("qr", cardX+cardWidth-qrSize-8, cardY+22, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	if data.Barcode {
+		barcode, err := ticketcode.Code128PNG(data.Code, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		barcodeHeight := 10.0
+		f.RegisterImageOptionsReader("barcode", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(barcode))
+		f.ImageOptions("barcode", cardX+8, cardY+cardHeight-barcodeHeight-30, detailsWidth, barcodeHeight, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	}
+
+	// rip line
+	ripY := cardY + cardHeight - 24
+	f.SetFillColor(ticketBackground[0], ticketBackground[1], ticketBackground[2])
+	f.Circle(cardX, ripY, 4, "F")
+	f.Circle(cardX+cardWidth, ripY, 4, "F")
+	f.SetDrawColor(ticketBackground[0], ticketBackground[1], ticketBackground[2])
+	f.SetLineWidth(0.8)
+	f.SetDashPattern([]float64{2, 2}, 0)
+	f.Line(cardX+6, ripY, cardX+cardWidth-6, ripY)
+	f.SetDashPattern([]float64{}, 0)
+
+	// ticket number and show time
+	f.SetFont("Helvetica", "B", 12)
+	f.SetXY(cardX+8, ripY+6)
+	f.CellFormat(cardWidth/2-8, 8, tr(data.TicketNumber), "", 0, "L", false, 0, "")
+	f.SetFont("Helvetica", "", 12)
+	f.CellFormat(cardWidth/2-8, 8, tr(l.FormatDateTime(data.ShowTime, data.Timezone)), "", 0, "R", false, 0, "")
+
+	if err := f.Error(); err != nil {
+		return nil, err
+	}
+
+	buff := new(bytes.Buffer)
+	if err := f.Output(buff); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
@@ -0,0 +1,45 @@
+package pdf
+
+import "context"
+
+// PageSize is the paper size in inches.
+type PageSize struct {
+	Width  float64
+	Height float64
+}
+
+// Common paper sizes.
+var (
+	PageA4     = PageSize{Width: 8.27, Height: 11.69}
+	PageA5     = PageSize{Width: 5.83, Height: 8.27}
+	PageLetter = PageSize{Width: 8.5, Height: 11}
+)
+
+// Margin is the page margin in inches.
+type Margin struct {
+	Top    float64
+	Right  float64
+	Bottom float64
+	Left   float64
+}
+
+// Options is the print options of a document.
+type Options struct {
+	PageSize        PageSize
+	Landscape       bool
+	Margin          Margin
+	PrintBackground bool
+}
+
+// DefaultOptions returns A4 portrait with 0.4 inch margins and no background.
+func DefaultOptions() Options {
+	return Options{
+		PageSize: PageA4,
+		Margin:   Margin{Top: 0.4, Right: 0.4, Bottom: 0.4, Left: 0.4},
+	}
+}
+
+// Renderer is an abstraction of an HTML to PDF renderer.
+type Renderer interface {
+	Render(ctx context.Context, html []byte, opts Options) ([]byte, error)
+}
@@ -0,0 +1,43 @@
+package pdf
+
+import (
+	"context"
+
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+)
+
+// Name of the ticket renderers, selected by `PDF_RENDERER`.
+const (
+	RendererChrome = "chrome"
+	RendererNative = "native"
+)
+
+// TicketRenderer is an abstraction of a ticket PDF renderer.
+type TicketRenderer interface {
+	RenderTicket(ctx context.Context, locale string, data *mailtemplate.TicketData) ([]byte, error)
+}
+
+// HTMLTicketRenderer is a concrete struct of TicketRenderer, it prints the
+// ticket template with a Renderer.
+type HTMLTicketRenderer struct {
+	renderer Renderer
+	options  Options
+}
+
+// NewHTMLTicketRenderer is a constructor.
+func NewHTMLTicketRenderer(renderer Renderer) TicketRenderer {
+	return &HTMLTicketRenderer{
+		renderer: renderer,
+		options:  DefaultOptions(),
+	}
+}
+
+// RenderTicket implements TicketRenderer.
+func (r *HTMLTicketRenderer) RenderTicket(ctx context.Context, locale string, data *mailtemplate.TicketData) ([]byte, error) {
+	buff, err := mailtemplate.NewTicketTemplate(locale).Populate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.renderer.Render(ctx, buff.Bytes(), r.options)
+}
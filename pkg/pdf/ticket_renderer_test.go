@@ -0,0 +1,70 @@
+package pdf_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/mailtemplate"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/pdf"
+)
+
+func newTicketData() *mailtemplate.TicketData {
+	return &mailtemplate.TicketData{
+		CustomerName: "Jöhn Doe",
+		EventName:    "Concert",
+		Venue:        "GBK",
+		Country:      "Indonesia",
+		City:         "Jakarta",
+		Tier:         "VIP",
+		TicketNumber: "TICKET-1",
+		ShowTime:     time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC),
+		Timezone:     "Asia/Jakarta",
+		Code:         "TICKET-1.signature",
+	}
+}
+
+func TestNativeTicketRenderer_RenderTicket(t *testing.T) {
+	r := pdf.NewNativeTicketRenderer()
+
+	t.Run("render the ticket without a browser", func(t *testing.T) {
+		pdfBytes, err := r.RenderTicket(context.Background(), "id-ID", newTicketData())
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdfBytes, []byte("%PDF-")))
+		assert.True(t, bytes.Contains(pdfBytes, []byte("/Subtype /Image")))
+	})
+
+	t.Run("render the barcode when it is enabled", func(t *testing.T) {
+		data := newTicketData()
+		data.Barcode = true
+
+		pdfBytes, err := r.RenderTicket(context.Background(), "en", data)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, bytes.Count(pdfBytes, []byte("/Subtype /Image")))
+	})
+
+	t.Run("return error when the ticket data is incomplete", func(t *testing.T) {
+		data := newTicketData()
+		data.TicketNumber = ""
+
+		_, err := r.RenderTicket(context.Background(), "en", data)
+
+		var validationErr *mailtemplate.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
+
+func TestHTMLTicketRenderer_RenderTicket(t *testing.T) {
+	fake := pdf.NewFakeRenderer()
+	r := pdf.NewHTMLTicketRenderer(fake)
+
+	pdfBytes, err := r.RenderTicket(context.Background(), "id-ID", newTicketData())
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdfBytes, []byte("%PDF-")))
+
+	rendered := fake.Rendered()
+	assert.Len(t, rendered, 1)
+	assert.Contains(t, string(rendered[0]), "Sab, 10 Okt 2026 19:30 WIB")
+}
@@ -0,0 +1,192 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"go.opentelemetry.io/otel"
+)
+
+// APNs endpoints.
+const (
+	APNsEndpoint        = "https://api.push.apple.com"
+	APNsSandboxEndpoint = "https://api.sandbox.push.apple.com"
+)
+
+// apnsTokenTTL is how long the provider token is reused, APNs rejects tokens
+// older than an hour and refreshing more than every 20 minutes.
+const apnsTokenTTL = 45 * time.Minute
+
+// APNsSenderProperty is the configuration of the APNs sender with token based
+// authentication.
+type APNsSenderProperty struct {
+	// Endpoint is the base URL, APNsEndpoint is used when it is empty.
+	Endpoint string
+	KeyID    string
+	TeamID   string
+	// PrivateKey is the PEM encoded `.p8` signing key.
+	PrivateKey []byte
+	// Topic is the bundle id of the app.
+	Topic  string
+	Client *http.Client
+	// Timeout is the timeout of the requests when Client is nil,
+	// DefaultTimeout is used when it is zero.
+	Timeout time.Duration
+}
+
+// APNsSender sends the notifications through the Apple Push Notification
+// service HTTP/2 API.
+type APNsSender struct {
+	endpoint   string
+	keyID      string
+	teamID     string
+	privateKey *ecdsa.PrivateKey
+	topic      string
+	client     *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+	now      func() time.Time
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type apnsErrorResponse struct {
+	Reason string `json:"reason"`
+}
+
+// NewAPNsSender is a constructor.
+func NewAPNsSender(props APNsSenderProperty) (Sender, error) {
+	privateKey, err := jwt.ParseECPrivateKeyFromPEM(props.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := props.Endpoint
+	if endpoint == "" {
+		endpoint = APNsEndpoint
+	}
+
+	client := props.Client
+	if client == nil {
+		timeout := props.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &APNsSender{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		keyID:      props.KeyID,
+		teamID:     props.TeamID,
+		privateKey: privateKey,
+		topic:      props.Topic,
+		client:     client,
+		now:        time.Now,
+	}, nil
+}
+
+// providerToken returns the cached ES256 provider token, it is signed again once
+// it expires.
+func (s *APNsSender) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Sub(s.issuedAt) < apnsTokenTTL {
+		return s.token, nil
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Issuer:   s.teamID,
+		IssuedAt: jwt.NewNumericDate(now),
+	})
+	token.Header["kid"] = s.keyID
+
+	signed, err := token.SignedString(s.privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = signed
+	s.issuedAt = now
+
+	return signed, nil
+}
+
+// Send implements Sender. ErrInvalidToken is returned for an unregistered or
+// malformed token.
+func (s *APNsSender) Send(ctx context.Context, n Notification) (err error) {
+	tp := otel.GetTracerProvider()
+	t := tp.Tracer("push")
+	ctx, span := t.Start(ctx, "apns.send")
+	defer span.End()
+
+	// the custom data sits next to the reserved `aps` key.
+	payload := make(map[string]interface{}, len(n.Data)+1)
+	for k, v := range n.Data {
+		payload[k] = v
+	}
+	payload["aps"] = map[string]interface{}{
+		"alert": apnsAlert{Title: n.Title, Body: n.Body},
+		"sound": "default",
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.providerToken()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/3/device/%s", s.endpoint, n.Token), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+token)
+	req.Header.Set("apns-topic", s.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	var errRes apnsErrorResponse
+	json.Unmarshal(detail, &errRes)
+
+	switch errRes.Reason {
+	case "BadDeviceToken", "Unregistered", "DeviceTokenNotForTopic":
+		return fmt.Errorf("%w: %s", ErrInvalidToken, errRes.Reason)
+	}
+	if res.StatusCode == http.StatusGone {
+		return fmt.Errorf("%w: %s", ErrInvalidToken, errRes.Reason)
+	}
+
+	return fmt.Errorf("Push: APNs responded %d: %s", res.StatusCode, bytes.TrimSpace(detail))
+}
@@ -0,0 +1,115 @@
+package push_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+)
+
+func newAPNsKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestAPNsSenderSend(t *testing.T) {
+	key, keyPEM := newAPNsKey(t)
+
+	t.Run("post the alert with a provider token", func(t *testing.T) {
+		tokens := make([]string, 0)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/3/device/device-token", r.URL.Path)
+			assert.Equal(t, "com.tsel.ticketmaster", r.Header.Get("apns-topic"))
+			assert.Equal(t, "alert", r.Header.Get("apns-push-type"))
+
+			tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "bearer ")
+			tokens = append(tokens, tokenString)
+			claims := jwt.RegisteredClaims{}
+			token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "ES256", token.Method.Alg())
+			assert.Equal(t, "KEY123", token.Header["kid"])
+			assert.Equal(t, "TEAM123", claims.Issuer)
+
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "TICKET-1", body["ticket_number"])
+			assert.Equal(t, map[string]interface{}{"title": "Your ticket is ready", "body": "Concert"}, body["aps"].(map[string]interface{})["alert"])
+		}))
+		defer srv.Close()
+
+		s, err := push.NewAPNsSender(push.APNsSenderProperty{
+			Endpoint:   srv.URL,
+			KeyID:      "KEY123",
+			TeamID:     "TEAM123",
+			PrivateKey: keyPEM,
+			Topic:      "com.tsel.ticketmaster",
+		})
+		assert.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			err = s.Send(context.Background(), push.Notification{
+				Token:    "device-token",
+				Platform: push.PlatformIOS,
+				Title:    "Your ticket is ready",
+				Body:     "Concert",
+				Data:     map[string]string{"ticket_number": "TICKET-1"},
+			})
+			assert.NoError(t, err)
+		}
+
+		assert.Len(t, tokens, 2)
+		assert.Equal(t, tokens[0], tokens[1], "the provider token is reused")
+	})
+
+	t.Run("return ErrInvalidToken for an unregistered token", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"reason":"Unregistered","timestamp":1700000000000}`))
+		}))
+		defer srv.Close()
+
+		s, err := push.NewAPNsSender(push.APNsSenderProperty{Endpoint: srv.URL, PrivateKey: keyPEM})
+		assert.NoError(t, err)
+
+		err = s.Send(context.Background(), push.Notification{Token: "device-token"})
+		assert.ErrorIs(t, err, push.ErrInvalidToken)
+	})
+
+	t.Run("return the provider error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"reason":"ExpiredProviderToken"}`))
+		}))
+		defer srv.Close()
+
+		s, err := push.NewAPNsSender(push.APNsSenderProperty{Endpoint: srv.URL, PrivateKey: keyPEM})
+		assert.NoError(t, err)
+
+		err = s.Send(context.Background(), push.Notification{Token: "device-token"})
+		assert.ErrorContains(t, err, "APNs responded 403")
+	})
+
+	t.Run("return an error for an invalid key", func(t *testing.T) {
+		_, err := push.NewAPNsSender(push.APNsSenderProperty{PrivateKey: []byte("invalid")})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,153 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"golang.org/x/oauth2"
+)
+
+// FCMEndpoint is the base URL of the FCM HTTP v1 API.
+const FCMEndpoint = "https://fcm.googleapis.com"
+
+// FCMScope is the OAuth 2.0 scope of the FCM HTTP v1 API.
+const FCMScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// FCMSenderProperty is the configuration of the FCM sender.
+type FCMSenderProperty struct {
+	// Endpoint is the base URL of the API, FCMEndpoint is used when it is empty.
+	Endpoint  string
+	ProjectID string
+	// TokenSource gives the OAuth 2.0 access token of the service account, see
+	// `google.CredentialsFromJSON` with FCMScope.
+	TokenSource oauth2.TokenSource
+	Client      *http.Client
+	// Timeout is the timeout of the requests when Client is nil,
+	// DefaultTimeout is used when it is zero.
+	Timeout time.Duration
+}
+
+// FCMSender sends the notifications through the Firebase Cloud Messaging HTTP
+// v1 API.
+type FCMSender struct {
+	url         string
+	tokenSource oauth2.TokenSource
+	client      *http.Client
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// NewFCMSender is a constructor.
+func NewFCMSender(props FCMSenderProperty) Sender {
+	endpoint := props.Endpoint
+	if endpoint == "" {
+		endpoint = FCMEndpoint
+	}
+
+	client := props.Client
+	if client == nil {
+		timeout := props.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &FCMSender{
+		url:         fmt.Sprintf("%s/v1/projects/%s/messages:send", strings.TrimSuffix(endpoint, "/"), props.ProjectID),
+		tokenSource: props.TokenSource,
+		client:      client,
+	}
+}
+
+// Send implements Sender. ErrInvalidToken is returned for an unregistered
+// token.
+func (s *FCMSender) Send(ctx context.Context, n Notification) (err error) {
+	tp := otel.GetTracerProvider()
+	t := tp.Tracer("push")
+	ctx, span := t.Start(ctx, "fcm.send")
+	defer span.End()
+
+	body, err := json.Marshal(fcmRequest{
+		Message: fcmMessage{
+			Token: n.Token,
+			Notification: fcmNotification{
+				Title: n.Title,
+				Body:  n.Body,
+			},
+			Data: n.Data,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.tokenSource != nil {
+		token, err := s.tokenSource.Token()
+		if err != nil {
+			return err
+		}
+		token.SetAuthHeader(req)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	var errRes fcmErrorResponse
+	json.Unmarshal(detail, &errRes)
+	for _, d := range errRes.Error.Details {
+		if d.ErrorCode == "UNREGISTERED" || (d.ErrorCode == "INVALID_ARGUMENT" && strings.Contains(errRes.Error.Message, "registration token")) {
+			return fmt.Errorf("%w: %s", ErrInvalidToken, errRes.Error.Message)
+		}
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrInvalidToken, errRes.Error.Message)
+	}
+
+	return fmt.Errorf("Push: FCM responded %d: %s", res.StatusCode, bytes.TrimSpace(detail))
+}
@@ -0,0 +1,80 @@
+package push_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"golang.org/x/oauth2"
+)
+
+func TestFCMSenderSend(t *testing.T) {
+	t.Run("post the message to the project", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/projects/tsel-ticketmaster/messages:send", r.URL.Path)
+			assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{
+				"message": map[string]interface{}{
+					"token": "device-token",
+					"notification": map[string]interface{}{
+						"title": "Your ticket is ready",
+						"body":  "Concert",
+					},
+					"data": map[string]interface{}{"ticket_number": "TICKET-1"},
+				},
+			}, body)
+
+			w.Write([]byte(`{"name":"projects/tsel-ticketmaster/messages/1"}`))
+		}))
+		defer srv.Close()
+
+		s := push.NewFCMSender(push.FCMSenderProperty{
+			Endpoint:    srv.URL,
+			ProjectID:   "tsel-ticketmaster",
+			TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access-token", TokenType: "Bearer"}),
+		})
+
+		err := s.Send(context.Background(), push.Notification{
+			Token:    "device-token",
+			Platform: push.PlatformAndroid,
+			Title:    "Your ticket is ready",
+			Body:     "Concert",
+			Data:     map[string]string{"ticket_number": "TICKET-1"},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("return ErrInvalidToken for an unregistered token", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND","details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"UNREGISTERED"}]}}`))
+		}))
+		defer srv.Close()
+
+		s := push.NewFCMSender(push.FCMSenderProperty{Endpoint: srv.URL, ProjectID: "tsel-ticketmaster"})
+
+		err := s.Send(context.Background(), push.Notification{Token: "device-token"})
+		assert.ErrorIs(t, err, push.ErrInvalidToken)
+	})
+
+	t.Run("return the provider error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"code":503,"message":"unavailable","status":"UNAVAILABLE"}}`))
+		}))
+		defer srv.Close()
+
+		s := push.NewFCMSender(push.FCMSenderProperty{Endpoint: srv.URL, ProjectID: "tsel-ticketmaster"})
+
+		err := s.Send(context.Background(), push.Notification{Token: "device-token"})
+		assert.ErrorContains(t, err, "FCM responded 503")
+		assert.NotErrorIs(t, err, push.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,82 @@
+// Package push sends mobile push notifications through FCM and APNs.
+package push
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTimeout is the timeout of the requests to the push providers when no timeout is
+// configured, a request never waits forever.
+const DefaultTimeout = 10 * time.Second
+
+// Platform of the device.
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	PlatformWeb     = "web"
+)
+
+// Push error
+var (
+	// ErrInvalidToken is returned when the provider does not know the device
+	// token anymore, e.g. the app is uninstalled, and the token should be
+	// removed.
+	ErrInvalidToken        = fmt.Errorf("Push: Invalid or unregistered device token")
+	ErrUnsupportedPlatform = fmt.Errorf("Push: Unsupported platform")
+)
+
+// Notification is a push notification to a device.
+type Notification struct {
+	Token    string
+	Platform string
+	Title    string
+	Body     string
+	// Data is the custom payload handled by the app, e.g. the ticket number.
+	Data map[string]string
+}
+
+// Sender is collection of behavior of push sender.
+type Sender interface {
+	Send(ctx context.Context, n Notification) (err error)
+}
+
+// PlatformSender routes the notification to the sender of its platform.
+type PlatformSender map[string]Sender
+
+// Send implements Sender.
+func (ps PlatformSender) Send(ctx context.Context, n Notification) (err error) {
+	sender, ok := ps[n.Platform]
+	if !ok || sender == nil {
+		return fmt.Errorf("%w: %s", ErrUnsupportedPlatform, n.Platform)
+	}
+
+	return sender.Send(ctx, n)
+}
+
+type unimplementSender struct {
+	logger *logrus.Logger
+}
+
+// NewFakeSender returns a sender logging the notifications, it is used when no
+// provider is configured.
+func NewFakeSender(logger *logrus.Logger) Sender {
+	if logger == nil {
+		logger = logrus.New()
+	}
+
+	return &unimplementSender{logger: logger}
+}
+
+func (us *unimplementSender) Send(ctx context.Context, n Notification) (err error) {
+	us.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"push.platform": n.Platform,
+		"push.token":    n.Token,
+		"push.title":    n.Title,
+	}).Info("fake sending push notification")
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package push_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/push/mocks"
+)
+
+func TestPlatformSenderSend(t *testing.T) {
+	fcmMock := &mocks.Sender{}
+	fcmMock.On("Send", mock.Anything, mock.MatchedBy(func(n push.Notification) bool {
+		return n.Platform == push.PlatformAndroid
+	})).Return(nil)
+	apnsMock := &mocks.Sender{}
+	apnsMock.On("Send", mock.Anything, mock.MatchedBy(func(n push.Notification) bool {
+		return n.Platform == push.PlatformIOS
+	})).Return(nil)
+
+	s := push.PlatformSender{
+		push.PlatformAndroid: fcmMock,
+		push.PlatformIOS:     apnsMock,
+	}
+
+	assert.NoError(t, s.Send(context.Background(), push.Notification{Platform: push.PlatformAndroid}))
+	assert.NoError(t, s.Send(context.Background(), push.Notification{Platform: push.PlatformIOS}))
+	assert.ErrorIs(t, s.Send(context.Background(), push.Notification{Platform: push.PlatformWeb}), push.ErrUnsupportedPlatform)
+
+	fcmMock.AssertExpectations(t)
+	apnsMock.AssertExpectations(t)
+}
@@ -0,0 +1,114 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanout_SubscribeOutsideLock(t *testing.T) {
+	f := newFanout(2, 1)
+	release := make(chan struct{})
+	f.onFirst = func(ctx context.Context, key string) error {
+		if key == "slow" {
+			<-release
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	subscriptions := make(chan *Subscription, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := f.subscribe(context.Background(), "slow")
+			assert.NoError(t, err)
+			subscriptions <- s
+		}()
+	}
+
+	assert.Eventually(t, func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		t, ok := f.topics["slow"]
+		return ok && t.joining == 2
+	}, time.Second, time.Millisecond)
+
+	// the other keys are served while the broker is subscribing the slow one.
+	fast, err := f.subscribe(context.Background(), "fast")
+	assert.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		f.broadcast("fast", []byte("hello"))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("broadcast is blocked by the broker")
+	}
+	assert.Equal(t, []byte("hello"), <-fast.C)
+
+	// the joining subscribers count against the limit.
+	_, err = f.subscribe(context.Background(), "slow")
+	assert.ErrorIs(t, err, ErrTooManySubscriptions)
+
+	close(release)
+	wg.Wait()
+	close(subscriptions)
+	assert.Equal(t, 2, f.count("slow"))
+
+	for s := range subscriptions {
+		s.Close()
+	}
+	assert.Equal(t, 0, f.count("slow"))
+}
+
+func TestFanout_ResubscribeAfterUnsubscribe(t *testing.T) {
+	f := newFanout(0, 1)
+
+	var mu sync.Mutex
+	var calls []string
+	unsubscribing := make(chan struct{})
+	release := make(chan struct{})
+	f.onFirst = func(ctx context.Context, key string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, "subscribe")
+		return nil
+	}
+	f.onLast = func(key string) {
+		close(unsubscribing)
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, "unsubscribe")
+	}
+
+	s, err := f.subscribe(context.Background(), "42")
+	assert.NoError(t, err)
+	go s.Close()
+	<-unsubscribing
+
+	subscribed := make(chan struct{})
+	go func() {
+		s, err := f.subscribe(context.Background(), "42")
+		assert.NoError(t, err)
+		assert.NotNil(t, s)
+		close(subscribed)
+	}()
+
+	select {
+	case <-subscribed:
+		t.Fatal("key is subscribed before it is unsubscribed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-subscribed
+	assert.Equal(t, []string{"subscribe", "unsubscribe", "subscribe"}, calls)
+}
@@ -0,0 +1,284 @@
+// Package realtime fans the messages published to a key out to the local
+// subscribers of the key, e.g. the open connections of a customer.
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultBufferSize is the number of messages buffered for a subscriber when
+// no size is configured.
+const DefaultBufferSize = 64
+
+// Realtime error
+var (
+	ErrTooManySubscriptions = fmt.Errorf("Realtime: Too many subscriptions of the key")
+	ErrClosed               = fmt.Errorf("Realtime: Hub is closed")
+)
+
+// Hub is collection of behavior of realtime hub.
+type Hub interface {
+	Publish(ctx context.Context, key string, message []byte) error
+	// Subscribe receives the messages published to the key from now on, the
+	// subscription must be closed once it is no longer read.
+	Subscribe(ctx context.Context, key string) (*Subscription, error)
+	Close() error
+}
+
+// Subscription receives the messages of a key. C is closed when the
+// subscription is closed or when it falls behind by more than the buffer size,
+// so a slow subscriber never misses a message silently.
+type Subscription struct {
+	C <-chan []byte
+
+	key   string
+	c     chan []byte
+	once  sync.Once
+	leave func(*Subscription)
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.leave(s)
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() {
+		close(s.c)
+	})
+}
+
+// fanout keeps the local subscribers of every key. The first subscriber and
+// the last one leaving a key are reported to subscribe and unsubscribe the key
+// from the broker, the reports are made without the lock so a slow broker does
+// not hold up the other keys.
+type fanout struct {
+	mu         sync.Mutex
+	topics     map[string]*topic
+	leaving    map[string]chan struct{}
+	maxPerKey  int
+	bufferSize int
+	closed     bool
+	onFirst    func(ctx context.Context, key string) error
+	onLast     func(key string)
+}
+
+// topic is the state of a key. It is ready once the key is subscribed from the
+// broker, the subscribers joining in the meantime wait for it.
+type topic struct {
+	subscribers map[*Subscription]struct{}
+	joining     int
+	ready       chan struct{}
+	err         error
+}
+
+func newFanout(maxPerKey, bufferSize int) *fanout {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	return &fanout{
+		topics:     make(map[string]*topic),
+		leaving:    make(map[string]chan struct{}),
+		maxPerKey:  maxPerKey,
+		bufferSize: bufferSize,
+		onFirst:    func(ctx context.Context, key string) error { return nil },
+		onLast:     func(key string) {},
+	}
+}
+
+func (f *fanout) subscribe(ctx context.Context, key string) (*Subscription, error) {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil, ErrClosed
+	}
+
+	t, ok := f.topics[key]
+	if ok && f.maxPerKey > 0 && len(t.subscribers)+t.joining >= f.maxPerKey {
+		f.mu.Unlock()
+		return nil, ErrTooManySubscriptions
+	}
+
+	if !ok {
+		t = &topic{subscribers: make(map[*Subscription]struct{}), ready: make(chan struct{})}
+		f.topics[key] = t
+	}
+	t.joining++
+	leaving := f.leaving[key]
+	f.mu.Unlock()
+
+	if !ok {
+		// the key is unsubscribed by the last subscriber before it is
+		// subscribed again, so the broker sees them in order.
+		if leaving != nil {
+			<-leaving
+		}
+		err := f.onFirst(ctx, key)
+
+		f.mu.Lock()
+		t.err = err
+		if err != nil && f.topics[key] == t {
+			delete(f.topics, key)
+		}
+		close(t.ready)
+		f.mu.Unlock()
+	}
+
+	<-t.ready
+
+	f.mu.Lock()
+	t.joining--
+	if t.err != nil {
+		f.mu.Unlock()
+		return nil, t.err
+	}
+	if f.closed {
+		unsubscribe := f.release(key, t)
+		f.mu.Unlock()
+		unsubscribe()
+		return nil, ErrClosed
+	}
+
+	c := make(chan []byte, f.bufferSize)
+	s := &Subscription{C: c, key: key, c: c, leave: f.leave}
+	t.subscribers[s] = struct{}{}
+	f.mu.Unlock()
+
+	return s, nil
+}
+
+func (f *fanout) leave(s *Subscription) {
+	f.mu.Lock()
+	unsubscribe := f.remove(s)
+	f.mu.Unlock()
+
+	unsubscribe()
+}
+
+// remove must be called with the lock held, the returned func unsubscribes the
+// key when s is its last subscriber and must be called without the lock.
+func (f *fanout) remove(s *Subscription) func() {
+	s.close()
+
+	t, ok := f.topics[s.key]
+	if !ok {
+		return func() {}
+	}
+	if _, ok := t.subscribers[s]; !ok {
+		return func() {}
+	}
+
+	delete(t.subscribers, s)
+	return f.release(s.key, t)
+}
+
+// release must be called with the lock held, it drops the topic having no
+// subscriber left. The returned func unsubscribes the key and must be called
+// without the lock.
+func (f *fanout) release(key string, t *topic) func() {
+	if len(t.subscribers) > 0 || t.joining > 0 || f.topics[key] != t {
+		return func() {}
+	}
+
+	delete(f.topics, key)
+	done := make(chan struct{})
+	f.leaving[key] = done
+
+	return func() {
+		f.onLast(key)
+
+		f.mu.Lock()
+		if f.leaving[key] == done {
+			delete(f.leaving, key)
+		}
+		f.mu.Unlock()
+		close(done)
+	}
+}
+
+func (f *fanout) broadcast(key string, message []byte) {
+	var unsubscribes []func()
+
+	f.mu.Lock()
+	if t, ok := f.topics[key]; ok {
+		for s := range t.subscribers {
+			select {
+			case s.c <- message:
+			default:
+				unsubscribes = append(unsubscribes, f.remove(s))
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	for _, unsubscribe := range unsubscribes {
+		unsubscribe()
+	}
+}
+
+func (f *fanout) count(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if t, ok := f.topics[key]; ok {
+		return len(t.subscribers)
+	}
+
+	return 0
+}
+
+func (f *fanout) close() {
+	var unsubscribes []func()
+
+	f.mu.Lock()
+	f.closed = true
+	for _, t := range f.topics {
+		for s := range t.subscribers {
+			unsubscribes = append(unsubscribes, f.remove(s))
+		}
+	}
+	f.mu.Unlock()
+
+	for _, unsubscribe := range unsubscribes {
+		unsubscribe()
+	}
+}
+
+// LocalHubProperty is the property of the local hub.
+type LocalHubProperty struct {
+	// MaxSubscriptionsPerKey limits the subscriptions of a key, it is unlimited
+	// when it is zero.
+	MaxSubscriptionsPerKey int
+	BufferSize             int
+}
+
+type localHub struct {
+	fanout *fanout
+}
+
+// NewLocalHub is a constructor of the hub of a single replica.
+func NewLocalHub(props LocalHubProperty) Hub {
+	return &localHub{
+		fanout: newFanout(props.MaxSubscriptionsPerKey, props.BufferSize),
+	}
+}
+
+// Publish implements Hub.
+func (h *localHub) Publish(ctx context.Context, key string, message []byte) error {
+	h.fanout.broadcast(key, message)
+	return nil
+}
+
+// Subscribe implements Hub.
+func (h *localHub) Subscribe(ctx context.Context, key string) (*Subscription, error) {
+	return h.fanout.subscribe(ctx, key)
+}
+
+// Close implements Hub.
+func (h *localHub) Close() error {
+	h.fanout.close()
+	return nil
+}
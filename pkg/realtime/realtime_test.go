@@ -0,0 +1,66 @@
+package realtime_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/realtime"
+)
+
+func receive(t *testing.T, s *realtime.Subscription) []byte {
+	t.Helper()
+
+	select {
+	case msg := <-s.C:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message is received")
+		return nil
+	}
+}
+
+func TestLocalHub(t *testing.T) {
+	ctx := context.Background()
+	hub := realtime.NewLocalHub(realtime.LocalHubProperty{MaxSubscriptionsPerKey: 2, BufferSize: 1})
+	defer hub.Close()
+
+	a, err := hub.Subscribe(ctx, "42")
+	assert.NoError(t, err)
+	b, err := hub.Subscribe(ctx, "42")
+	assert.NoError(t, err)
+	other, err := hub.Subscribe(ctx, "43")
+	assert.NoError(t, err)
+
+	_, err = hub.Subscribe(ctx, "42")
+	assert.ErrorIs(t, err, realtime.ErrTooManySubscriptions)
+
+	assert.NoError(t, hub.Publish(ctx, "42", []byte("hello")))
+	assert.Equal(t, []byte("hello"), receive(t, a))
+	assert.Equal(t, []byte("hello"), receive(t, b))
+	assert.Len(t, other.C, 0)
+
+	b.Close()
+	_, ok := <-b.C
+	assert.False(t, ok)
+
+	c, err := hub.Subscribe(ctx, "42")
+	assert.NoError(t, err)
+	defer c.Close()
+
+	// a lagging subscription is closed rather than losing a message.
+	assert.NoError(t, hub.Publish(ctx, "42", []byte("1")))
+	assert.NoError(t, hub.Publish(ctx, "42", []byte("2")))
+	assert.Equal(t, []byte("1"), receive(t, a))
+	_, ok = <-a.C
+	assert.False(t, ok)
+
+	assert.Equal(t, []byte("1"), receive(t, c))
+
+	assert.NoError(t, hub.Close())
+	_, ok = <-other.C
+	assert.False(t, ok)
+	_, err = hub.Subscribe(ctx, "42")
+	assert.ErrorIs(t, err, realtime.ErrClosed)
+}
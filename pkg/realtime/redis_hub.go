@@ -0,0 +1,91 @@
+package realtime
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// RedisHubProperty is the property of the Redis hub.
+type RedisHubProperty struct {
+	Logger *logrus.Logger
+	Client redis.UniversalClient
+	// Prefix is prepended to the key to name its Redis channel.
+	Prefix string
+	// MaxSubscriptionsPerKey limits the subscriptions of a key on this
+	// replica, it is unlimited when it is zero. The limit is not shared, a key
+	// has up to the limit times the number of replicas subscriptions.
+	MaxSubscriptionsPerKey int
+	BufferSize             int
+}
+
+// RedisHub fans the messages out across the replicas through Redis pub/sub.
+// A replica subscribes the channel of a key only while it has a local
+// subscriber of the key.
+type RedisHub struct {
+	logger *logrus.Logger
+	client redis.UniversalClient
+	prefix string
+	pubsub *redis.PubSub
+	fanout *fanout
+	wg     sync.WaitGroup
+}
+
+// NewRedisHub is a constructor.
+func NewRedisHub(props RedisHubProperty) *RedisHub {
+	logger := props.Logger
+	if logger == nil {
+		logger = logrus.New()
+	}
+
+	h := &RedisHub{
+		logger: logger,
+		client: props.Client,
+		prefix: props.Prefix,
+		pubsub: props.Client.Subscribe(context.Background()),
+		fanout: newFanout(props.MaxSubscriptionsPerKey, props.BufferSize),
+	}
+	h.fanout.onFirst = func(ctx context.Context, key string) error {
+		return h.pubsub.Subscribe(ctx, h.prefix+key)
+	}
+	h.fanout.onLast = func(key string) {
+		if err := h.pubsub.Unsubscribe(context.Background(), h.prefix+key); err != nil {
+			h.logger.WithError(err).WithField("realtime.key", key).Warn()
+		}
+	}
+
+	h.wg.Add(1)
+	go h.receive()
+
+	return h
+}
+
+func (h *RedisHub) receive() {
+	defer h.wg.Done()
+
+	for msg := range h.pubsub.Channel() {
+		h.fanout.broadcast(strings.TrimPrefix(msg.Channel, h.prefix), []byte(msg.Payload))
+	}
+}
+
+// Publish implements Hub.
+func (h *RedisHub) Publish(ctx context.Context, key string, message []byte) error {
+	return h.client.Publish(ctx, h.prefix+key, message).Err()
+}
+
+// Subscribe implements Hub.
+func (h *RedisHub) Subscribe(ctx context.Context, key string) (*Subscription, error) {
+	return h.fanout.subscribe(ctx, key)
+}
+
+// Close implements Hub.
+func (h *RedisHub) Close() error {
+	h.fanout.close()
+	err := h.pubsub.Close()
+	h.wg.Wait()
+
+	return err
+}
@@ -0,0 +1,47 @@
+package realtime_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/realtime"
+)
+
+func TestRedisHub(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+
+	newHub := func() *realtime.RedisHub {
+		return realtime.NewRedisHub(realtime.RedisHubProperty{
+			Client:                 redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+			Prefix:                 "tm-notification:realtime:",
+			MaxSubscriptionsPerKey: 1,
+		})
+	}
+
+	replicaA, replicaB := newHub(), newHub()
+	defer replicaA.Close()
+	defer replicaB.Close()
+
+	s, err := replicaA.Subscribe(ctx, "42")
+	assert.NoError(t, err)
+
+	_, err = replicaA.Subscribe(ctx, "42")
+	assert.ErrorIs(t, err, realtime.ErrTooManySubscriptions)
+
+	assert.Eventually(t, func() bool {
+		return len(mr.PubSubChannels("tm-notification:realtime:*")) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, replicaB.Publish(ctx, "42", []byte("hello")))
+	assert.Equal(t, []byte("hello"), receive(t, s))
+
+	s.Close()
+	assert.Eventually(t, func() bool {
+		return len(mr.PubSubChannels("tm-notification:realtime:*")) == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,145 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// HTTPSenderProperty is the configuration of the SMS provider HTTP API.
+type HTTPSenderProperty struct {
+	Logger *logrus.Logger
+	// Endpoint is the URL the messages are POSTed to.
+	Endpoint string
+	// APIKey is sent as the bearer token.
+	APIKey        string
+	DefaultSender string
+	// Client is the HTTP client, a client with Timeout is used when it is nil.
+	Client *http.Client
+	// Timeout is the timeout of the requests, DefaultTimeout is used when it
+	// is zero.
+	Timeout time.Duration
+}
+
+// HTTPSender sends the messages to an SMS provider through its HTTP API. Each
+// message is POSTed as JSON, e.g. `{"from":"TicketMaster","to":"+6281234567890","text":"..."}`,
+// and any non 2xx response is an error.
+type HTTPSender struct {
+	logger        *logrus.Logger
+	endpoint      string
+	apiKey        string
+	defaultSender string
+	client        *http.Client
+}
+
+type httpSenderRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+// NewHTTPSender is a constructor. A sender logging the messages is returned when
+// it is not active.
+func NewHTTPSender(props HTTPSenderProperty, active bool) SMSSender {
+	logger := props.Logger
+	if logger == nil {
+		logger = logrus.New()
+	}
+
+	if !active {
+		return &unimplementSender{
+			logger:        logger,
+			defaultSender: props.DefaultSender,
+		}
+	}
+
+	client := props.Client
+	if client == nil {
+		timeout := props.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &HTTPSender{
+		logger:        logger,
+		endpoint:      props.Endpoint,
+		apiKey:        props.APIKey,
+		defaultSender: props.DefaultSender,
+		client:        client,
+	}
+}
+
+// Send will send the messages, every message is attempted and the errors are
+// joined.
+func (s *HTTPSender) Send(ctx context.Context, messages ...Message) (err error) {
+	tp := otel.GetTracerProvider()
+	t := tp.Tracer("sms")
+	ctx, span := t.Start(ctx, "send")
+	defer span.End()
+
+	if len(messages) < 1 {
+		return ErrNoMessage
+	}
+
+	errs := make([]error, 0)
+	for _, message := range messages {
+		if err := s.send(ctx, message); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("sms.to", message.To).Error()
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *HTTPSender) send(ctx context.Context, message Message) error {
+	if err := ValidatePhoneNumber(message.To); err != nil {
+		return err
+	}
+
+	from := s.defaultSender
+	if message.From != "" {
+		from = message.From
+	}
+
+	body, err := json.Marshal(httpSenderRequest{
+		From: from,
+		To:   message.To,
+		Text: message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("SMS: provider responded %d: %s", res.StatusCode, bytes.TrimSpace(detail))
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package sms_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sms"
+)
+
+func TestHTTPSenderSend(t *testing.T) {
+	t.Run("post the message to the provider", func(t *testing.T) {
+		received := make([]map[string]string, 0)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "Bearer api-key", r.Header.Get("Authorization"))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			body := map[string]string{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			received = append(received, body)
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer srv.Close()
+
+		s := sms.NewHTTPSender(sms.HTTPSenderProperty{
+			Logger:        logrus.New(),
+			Endpoint:      srv.URL,
+			APIKey:        "api-key",
+			DefaultSender: "TicketMaster",
+		}, true)
+
+		err := s.Send(context.Background(),
+			sms.Message{To: "+6281234567890", Body: "Hello"},
+			sms.Message{From: "TM", To: "+6281234567891", Body: "World"},
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []map[string]string{
+			{"from": "TicketMaster", "to": "+6281234567890", "text": "Hello"},
+			{"from": "TM", "to": "+6281234567891", "text": "World"},
+		}, received)
+	})
+
+	t.Run("return the provider error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "insufficient balance", http.StatusPaymentRequired)
+		}))
+		defer srv.Close()
+
+		s := sms.NewHTTPSender(sms.HTTPSenderProperty{Endpoint: srv.URL}, true)
+
+		err := s.Send(context.Background(), sms.Message{To: "+6281234567890", Body: "Hello"})
+		assert.ErrorContains(t, err, "402: insufficient balance")
+	})
+
+	t.Run("reject an invalid phone number without calling the provider", func(t *testing.T) {
+		called := false
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer srv.Close()
+
+		s := sms.NewHTTPSender(sms.HTTPSenderProperty{Endpoint: srv.URL}, true)
+
+		err := s.Send(context.Background(), sms.Message{To: "081234567890", Body: "Hello"})
+		assert.ErrorIs(t, err, sms.ErrInvalidPhoneNumber)
+		assert.False(t, called)
+	})
+
+	t.Run("return an error without message", func(t *testing.T) {
+		s := sms.NewHTTPSender(sms.HTTPSenderProperty{}, true)
+
+		assert.ErrorIs(t, s.Send(context.Background()), sms.ErrNoMessage)
+	})
+
+	t.Run("log the message when it is not active", func(t *testing.T) {
+		s := sms.NewHTTPSender(sms.HTTPSenderProperty{}, false)
+
+		assert.NoError(t, s.Send(context.Background(), sms.Message{To: "+6281234567890", Body: "Hello"}))
+	})
+}
@@ -0,0 +1,50 @@
+package sms
+
+import (
+	"regexp"
+	"strings"
+)
+
+// e164 is a `+` followed by the country calling code and the subscriber
+// number, up to 15 digits in total.
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// ValidatePhoneNumber returns ErrInvalidPhoneNumber when the phone number is not
+// in the E.164 format.
+func ValidatePhoneNumber(phoneNumber string) error {
+	if !e164.MatchString(phoneNumber) {
+		return ErrInvalidPhoneNumber
+	}
+
+	return nil
+}
+
+// NormalizePhoneNumber converts the phone number to the E.164 format. Spaces,
+// dashes, dots and parentheses are removed, a `00` international prefix becomes
+// `+` and a national number with a leading `0` gets the default calling code,
+// e.g. `0812-3456-7890` with `62` is `+6281234567890`.
+func NormalizePhoneNumber(phoneNumber, defaultCallingCode string) (string, error) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, phoneNumber)
+
+	switch {
+	case strings.HasPrefix(normalized, "+"):
+	case strings.HasPrefix(normalized, "00"):
+		normalized = "+" + normalized[2:]
+	case strings.HasPrefix(normalized, "0") && defaultCallingCode != "":
+		normalized = "+" + strings.TrimPrefix(defaultCallingCode, "+") + normalized[1:]
+	default:
+		normalized = "+" + normalized
+	}
+
+	if err := ValidatePhoneNumber(normalized); err != nil {
+		return "", err
+	}
+
+	return normalized, nil
+}
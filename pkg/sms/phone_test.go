@@ -0,0 +1,43 @@
+package sms_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sms"
+)
+
+func TestValidatePhoneNumber(t *testing.T) {
+	for _, phoneNumber := range []string{"+6281234567890", "+14155552671", "+442071838750"} {
+		assert.NoError(t, sms.ValidatePhoneNumber(phoneNumber), phoneNumber)
+	}
+
+	for _, phoneNumber := range []string{"", "6281234567890", "+0812345678", "+62 812 3456 7890", "+6281234567890123", "+62abc"} {
+		assert.ErrorIs(t, sms.ValidatePhoneNumber(phoneNumber), sms.ErrInvalidPhoneNumber, phoneNumber)
+	}
+}
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	testCases := []struct {
+		phoneNumber string
+		expected    string
+	}{
+		{"+62 812-3456-7890", "+6281234567890"},
+		{"0812-3456-7890", "+6281234567890"},
+		{"006281234567890", "+6281234567890"},
+		{"6281234567890", "+6281234567890"},
+		{"(0812) 3456.7890", "+6281234567890"},
+	}
+
+	for _, tc := range testCases {
+		phoneNumber, err := sms.NormalizePhoneNumber(tc.phoneNumber, "62")
+		assert.NoError(t, err, tc.phoneNumber)
+		assert.Equal(t, tc.expected, phoneNumber)
+	}
+
+	_, err := sms.NormalizePhoneNumber("not a phone", "62")
+	assert.ErrorIs(t, err, sms.ErrInvalidPhoneNumber)
+
+	_, err = sms.NormalizePhoneNumber("", "62")
+	assert.ErrorIs(t, err, sms.ErrInvalidPhoneNumber)
+}
@@ -0,0 +1,56 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTimeout is the timeout of the requests to the provider when no timeout is
+// configured, a request never waits forever.
+const DefaultTimeout = 10 * time.Second
+
+// SMS error
+var (
+	ErrNoMessage          = fmt.Errorf("SMS: No message to be sent")
+	ErrInvalidPhoneNumber = fmt.Errorf("SMS: Invalid E.164 phone number")
+)
+
+// Message is a text message to be sent to the phone number.
+type Message struct {
+	// From is the sender id, the default sender is used when it is empty.
+	From string
+	// To is the E.164 phone number of the recipient, e.g. `+6281234567890`.
+	To   string
+	Body string
+}
+
+// SMSSender is collection of behavior of SMS sender.
+type SMSSender interface {
+	Send(ctx context.Context, messages ...Message) (err error)
+}
+
+type unimplementSender struct {
+	logger        *logrus.Logger
+	defaultSender string
+}
+
+func (us *unimplementSender) Send(ctx context.Context, messages ...Message) (err error) {
+	for i, message := range messages {
+		from := us.defaultSender
+		if message.From != "" {
+			from = message.From
+		}
+
+		us.logger.WithContext(ctx).WithFields(logrus.Fields{
+			"no":       i,
+			"sms.from": from,
+			"sms.to":   message.To,
+			"sms.body": message.Body,
+		}).Info("fake sending sms")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package sse writes Server-Sent Events to an HTTP response.
+package sse
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Writer writes the events of a stream, every write is flushed to the client.
+type Writer struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewWriter starts the event stream of the response.
+func NewWriter(w http.ResponseWriter) (*Writer, error) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sw := &Writer{w: w, rc: http.NewResponseController(w)}
+	if err := sw.rc.Flush(); err != nil {
+		return nil, err
+	}
+
+	return sw, nil
+}
+
+// Event writes an event, the id and the name are omitted when they are empty.
+func (sw *Writer) Event(id, event string, data []byte) error {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	return sw.write(b.String())
+}
+
+// Comment writes a comment, which is ignored by the client and is used as a
+// heartbeat to keep the connection open.
+func (sw *Writer) Comment(text string) error {
+	return sw.write(fmt.Sprintf(": %s\n\n", text))
+}
+
+// Retry tells the client how long to wait before reconnecting.
+func (sw *Writer) Retry(d time.Duration) error {
+	return sw.write(fmt.Sprintf("retry: %d\n\n", d.Milliseconds()))
+}
+
+func (sw *Writer) write(s string) error {
+	if _, err := sw.w.Write([]byte(s)); err != nil {
+		return err
+	}
+
+	return sw.rc.Flush()
+}
@@ -0,0 +1,30 @@
+package sse_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/sse"
+)
+
+func TestWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	w, err := sse.NewWriter(rec)
+	assert.NoError(t, err)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.True(t, rec.Flushed)
+
+	assert.NoError(t, w.Retry(3*time.Second))
+	assert.NoError(t, w.Event("7", "notification", []byte("{\"id\":7}")))
+	assert.NoError(t, w.Event("", "", []byte("a\nb")))
+	assert.NoError(t, w.Comment("heartbeat"))
+
+	assert.Equal(t, "retry: 3000\n\n"+
+		"id: 7\nevent: notification\ndata: {\"id\":7}\n\n"+
+		"data: a\ndata: b\n\n"+
+		": heartbeat\n\n", rec.Body.String())
+}
@@ -9,6 +9,7 @@ const (
 	NOT_FOUND             = "NOT_FOUND"
 	UNPROCESSABLE_ENTITY  = "UNPROCESSABLE_ENTITY"
 	EXPECTATION_FAILED    = "EXPECTATION_FAILED"
+	TOO_MANY_REQUESTS     = "TOO_MANY_REQUESTS"
 	INTERNAL_SERVER_ERROR = "INTERNAL_SERVER_ERROR"
 
 	// custom status
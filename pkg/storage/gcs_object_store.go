@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	gcs "cloud.google.com/go/storage"
+)
+
+// DefaultBucket is the bucket of the objects when no bucket is configured.
+const DefaultBucket = "tsel-ticketmaster"
+
+// GCSObjectStoreProperty is the property of GCSObjectStore.
+type GCSObjectStoreProperty struct {
+	Client *gcs.Client
+	// Bucket is the bucket of the objects, it defaults to DefaultBucket.
+	Bucket    string
+	KeyPrefix string
+	// PublicBaseURL is the base of the object url, it defaults to
+	// `https://storage.googleapis.com/<bucket>`.
+	PublicBaseURL string
+}
+
+// GCSObjectStore is a concrete struct of ObjectStore backed by a Google Cloud
+// Storage bucket.
+type GCSObjectStore struct {
+	client        *gcs.Client
+	bucket        string
+	keyPrefix     string
+	publicBaseURL string
+	now           func() time.Time
+}
+
+// NewGCSObjectStore is a constructor.
+func NewGCSObjectStore(props GCSObjectStoreProperty) ObjectStore {
+	bucket := props.Bucket
+	if bucket == "" {
+		bucket = DefaultBucket
+	}
+
+	publicBaseURL := props.PublicBaseURL
+	if publicBaseURL == "" {
+		publicBaseURL = fmt.Sprintf("https://storage.googleapis.com/%s", bucket)
+	}
+
+	return &GCSObjectStore{
+		client:        props.Client,
+		bucket:        bucket,
+		keyPrefix:     props.KeyPrefix,
+		publicBaseURL: publicBaseURL,
+		now:           time.Now,
+	}
+}
+
+// Put implements ObjectStore.
+func (s *GCSObjectStore) Put(ctx context.Context, object Object) error {
+	name, err := objectName(s.keyPrefix, object.Key)
+	if err != nil {
+		return err
+	}
+
+	w := s.client.Bucket(s.bucket).Object(name).NewWriter(ctx)
+	w.ChunkSize = 0
+	w.ContentType = object.ContentType
+
+	if _, err := io.Copy(w, object.Body); err != nil {
+		w.Close()
+		return fmt.Errorf("io.Copy: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("Writer.Close: %w", err)
+	}
+
+	return nil
+}
+
+// Get implements ObjectStore.
+func (s *GCSObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := objectName(s.keyPrefix, key)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.client.Bucket(s.bucket).Object(name).NewReader(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Delete implements ObjectStore.
+func (s *GCSObjectStore) Delete(ctx context.Context, key string) error {
+	name, err := objectName(s.keyPrefix, key)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Bucket(s.bucket).Object(name).Delete(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return ErrObjectNotFound
+	}
+
+	return err
+}
+
+// URL implements ObjectStore.
+func (s *GCSObjectStore) URL(key string) string {
+	name, err := objectName(s.keyPrefix, key)
+	if err != nil {
+		return ""
+	}
+
+	return publicURL(s.publicBaseURL, name)
+}
+
+// SignedURL implements ObjectStore. The url is signed with the service account
+// of the client, V4 signing limits the expiry to seven days.
+func (s *GCSObjectStore) SignedURL(key string, expiry time.Duration) (string, error) {
+	name, err := objectName(s.keyPrefix, key)
+	if err != nil {
+		return "", err
+	}
+
+	if expiry <= 0 || expiry > 7*24*time.Hour {
+		return "", ErrInvalidExpiry
+	}
+
+	return s.client.Bucket(s.bucket).SignedURL(name, &gcs.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: s.now().Add(expiry),
+		Scheme:  gcs.SigningSchemeV4,
+	})
+}
@@ -0,0 +1,74 @@
+package storage_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/url"
+	"testing"
+	"time"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/storage"
+	"google.golang.org/api/option"
+)
+
+func newServiceAccount(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	serviceAccount, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "tsel-ticketmaster",
+		"client_email": "tm-notification@tsel-ticketmaster.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey})),
+		"token_uri":    "https://oauth2.googleapis.com/token",
+	})
+	assert.NoError(t, err)
+
+	return serviceAccount
+}
+
+func TestGCSObjectStore_SignedURL(t *testing.T) {
+	client, err := gcs.NewClient(context.Background(), option.WithCredentialsJSON(newServiceAccount(t)))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	s := storage.NewGCSObjectStore(storage.GCSObjectStoreProperty{
+		Client:    client,
+		Bucket:    "tsel-ticketmaster",
+		KeyPrefix: "tickets",
+	})
+
+	t.Run("sign the url with the service account", func(t *testing.T) {
+		signedURL, err := s.SignedURL("TICKET-1.pdf", time.Hour)
+		assert.NoError(t, err)
+
+		u, err := url.Parse(signedURL)
+		assert.NoError(t, err)
+		assert.Equal(t, "/tsel-ticketmaster/tickets/TICKET-1.pdf", u.Path)
+		assert.Contains(t, []string{"3599", "3600"}, u.Query().Get("X-Goog-Expires"))
+		assert.NotEmpty(t, u.Query().Get("X-Goog-Signature"))
+	})
+
+	t.Run("build the public url", func(t *testing.T) {
+		assert.Equal(t, "https://storage.googleapis.com/tsel-ticketmaster/tickets/TICKET-1.pdf", s.URL("TICKET-1.pdf"))
+	})
+
+	t.Run("use the default bucket when none is configured", func(t *testing.T) {
+		s := storage.NewGCSObjectStore(storage.GCSObjectStoreProperty{Client: client})
+		assert.Equal(t, "https://storage.googleapis.com/"+storage.DefaultBucket+"/TICKET-1.pdf", s.URL("TICKET-1.pdf"))
+	})
+
+	t.Run("reject an expiry longer than seven days", func(t *testing.T) {
+		_, err := s.SignedURL("TICKET-1.pdf", 8*24*time.Hour)
+		assert.ErrorIs(t, err, storage.ErrInvalidExpiry)
+	})
+}
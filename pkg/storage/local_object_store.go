@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalObjectStoreProperty is the property of LocalObjectStore.
+type LocalObjectStoreProperty struct {
+	Dir       string
+	KeyPrefix string
+	// PublicBaseURL is where the store is served, see ServeHTTP.
+	PublicBaseURL string
+	// Secret signs the urls returned by SignedURL.
+	Secret string
+}
+
+// LocalObjectStore is a concrete struct of ObjectStore backed by a directory of
+// the local filesystem, it is meant for development and tests. It is also an
+// http.Handler serving the objects of signed urls.
+type LocalObjectStore struct {
+	dir           string
+	keyPrefix     string
+	publicBaseURL string
+	secret        []byte
+	now           func() time.Time
+}
+
+// NewLocalObjectStore is a constructor.
+func NewLocalObjectStore(props LocalObjectStoreProperty) *LocalObjectStore {
+	return &LocalObjectStore{
+		dir:           props.Dir,
+		keyPrefix:     props.KeyPrefix,
+		publicBaseURL: props.PublicBaseURL,
+		secret:        []byte(props.Secret),
+		now:           time.Now,
+	}
+}
+
+func (s *LocalObjectStore) path(key string) (string, error) {
+	name, err := objectName(s.keyPrefix, key)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(name)), nil
+}
+
+// Put implements ObjectStore. The object is written to a temporary file first
+// so readers never see a partial object.
+func (s *LocalObjectStore) Put(ctx context.Context, object Object) error {
+	p, err := s.path(object.Key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, object.Body); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), p)
+}
+
+// Get implements ObjectStore.
+func (s *LocalObjectStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Delete implements ObjectStore.
+func (s *LocalObjectStore) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotFound
+	}
+
+	return err
+}
+
+// URL implements ObjectStore.
+func (s *LocalObjectStore) URL(key string) string {
+	name, err := objectName(s.keyPrefix, key)
+	if err != nil {
+		return ""
+	}
+
+	return publicURL(s.publicBaseURL, name)
+}
+
+// SignedURL implements ObjectStore. The url points to ServeHTTP with the expiry
+// and its HMAC-SHA256 signature in the query.
+func (s *LocalObjectStore) SignedURL(key string, expiry time.Duration) (string, error) {
+	name, err := objectName(s.keyPrefix, key)
+	if err != nil {
+		return "", err
+	}
+
+	if expiry <= 0 {
+		return "", ErrInvalidExpiry
+	}
+
+	expires := strconv.FormatInt(s.now().Add(expiry).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(name, expires))
+
+	return fmt.Sprintf("%s?%s", publicURL(s.publicBaseURL, name), query.Encode()), nil
+}
+
+// ServeHTTP serves the object of a signed url, the object name is the request
+// path so the handler is mounted with http.StripPrefix.
+func (s *LocalObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	expires := r.URL.Query().Get("expires")
+	signature, err := hex.DecodeString(r.URL.Query().Get("signature"))
+	if err != nil || !s.verify(name, expires, signature) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if _, err := objectName("", name); err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(name)))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+func (s *LocalObjectStore) sign(name, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(name + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalObjectStore) verify(name, expires string, signature []byte) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.now().Unix() > expiresAt {
+		return false
+	}
+
+	expected, _ := hex.DecodeString(s.sign(name, expires))
+	return hmac.Equal(signature, expected)
+}
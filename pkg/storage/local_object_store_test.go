@@ -0,0 +1,127 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/storage"
+)
+
+func TestLocalObjectStore(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+		Dir:           t.TempDir(),
+		KeyPrefix:     "tickets",
+		PublicBaseURL: "http://localhost:9001/files/",
+	})
+
+	t.Run("put, get and delete an object", func(t *testing.T) {
+		err := s.Put(ctx, storage.Object{
+			Key:         "TICKET-1.pdf",
+			ContentType: "application/pdf",
+			Body:        strings.NewReader("%PDF-1.4"),
+		})
+		assert.NoError(t, err)
+
+		body, err := s.Get(ctx, "TICKET-1.pdf")
+		assert.NoError(t, err)
+		content, err := io.ReadAll(body)
+		assert.NoError(t, err)
+		assert.NoError(t, body.Close())
+		assert.Equal(t, "%PDF-1.4", string(content))
+
+		assert.NoError(t, s.Delete(ctx, "TICKET-1.pdf"))
+
+		_, err = s.Get(ctx, "TICKET-1.pdf")
+		assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+		assert.ErrorIs(t, s.Delete(ctx, "TICKET-1.pdf"), storage.ErrObjectNotFound)
+	})
+
+	t.Run("build the url with the key prefix", func(t *testing.T) {
+		assert.Equal(t, "http://localhost:9001/files/tickets/TICKET-1.pdf", s.URL("TICKET-1.pdf"))
+	})
+
+	t.Run("reject keys escaping the prefix", func(t *testing.T) {
+		for _, key := range []string{"", "/etc/passwd", "../secret.pdf", "a/../../secret.pdf"} {
+			err := s.Put(ctx, storage.Object{Key: key, Body: strings.NewReader("")})
+			assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+		}
+	})
+}
+
+func TestLocalObjectStore_SignedURL(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewLocalObjectStore(storage.LocalObjectStoreProperty{
+		Dir:           t.TempDir(),
+		KeyPrefix:     "tickets",
+		PublicBaseURL: "http://localhost:9001/tm-notification/files",
+		Secret:        "secret",
+	})
+	handler := http.StripPrefix("/tm-notification/files", s)
+
+	err := s.Put(ctx, storage.Object{
+		Key:         "TICKET-1.pdf",
+		ContentType: "application/pdf",
+		Body:        strings.NewReader("%PDF-1.4"),
+	})
+	assert.NoError(t, err)
+
+	serve := func(rawURL string) *httptest.ResponseRecorder {
+		u, err := url.Parse(rawURL)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+		return w
+	}
+
+	t.Run("serve the object of a signed url", func(t *testing.T) {
+		signedURL, err := s.SignedURL("TICKET-1.pdf", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(signedURL, "http://localhost:9001/tm-notification/files/tickets/TICKET-1.pdf?"))
+
+		w := serve(signedURL)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.Equal(t, "%PDF-1.4", w.Body.String())
+	})
+
+	t.Run("reject an unsigned url", func(t *testing.T) {
+		w := serve(s.URL("TICKET-1.pdf"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("reject a url signed for another object", func(t *testing.T) {
+		signedURL, err := s.SignedURL("TICKET-2.pdf", time.Minute)
+		assert.NoError(t, err)
+
+		w := serve(strings.Replace(signedURL, "TICKET-2", "TICKET-1", 1))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("reject an expired url", func(t *testing.T) {
+		signedURL, err := s.SignedURL("TICKET-1.pdf", time.Second)
+		assert.NoError(t, err)
+
+		u, err := url.Parse(signedURL)
+		assert.NoError(t, err)
+		query := u.Query()
+		query.Set("expires", "1")
+		u.RawQuery = query.Encode()
+
+		w := serve(u.String())
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("reject a non positive expiry", func(t *testing.T) {
+		_, err := s.SignedURL("TICKET-1.pdf", 0)
+		assert.ErrorIs(t, err, storage.ErrInvalidExpiry)
+	})
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// Drivers of the object store.
+const (
+	DriverGCS   = "gcs"
+	DriverLocal = "local"
+)
+
+// Errors.
+var (
+	ErrObjectNotFound = fmt.Errorf("storage: object not found")
+	ErrInvalidKey     = fmt.Errorf("storage: invalid object key")
+	ErrInvalidExpiry  = fmt.Errorf("storage: invalid signed url expiry")
+)
+
+// Object is an object to be stored.
+type Object struct {
+	Key         string
+	ContentType string
+	Body        io.Reader
+}
+
+// ObjectStore is collection of behavior of an object storage. Keys are slash
+// separated and relative to the key prefix of the store.
+//
+// URL is the public url of the object, it only works when the objects are
+// publicly readable. SignedURL grants a time-limited read of a private object.
+type ObjectStore interface {
+	Put(ctx context.Context, object Object) (err error)
+	Get(ctx context.Context, key string) (body io.ReadCloser, err error)
+	Delete(ctx context.Context, key string) (err error)
+	URL(key string) string
+	SignedURL(key string, expiry time.Duration) (url string, err error)
+}
+
+// objectName joins the key prefix and the key, rejecting keys that would escape
+// the prefix.
+func objectName(prefix, key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return "", ErrInvalidKey
+	}
+
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidKey
+	}
+
+	if prefix == "" {
+		return cleaned, nil
+	}
+
+	return path.Join(prefix, cleaned), nil
+}
+
+// publicURL joins the base url and the object name.
+func publicURL(baseURL, name string) string {
+	return strings.TrimSuffix(baseURL, "/") + "/" + name
+}
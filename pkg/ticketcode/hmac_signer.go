@@ -0,0 +1,67 @@
+package ticketcode
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// HMACSigner is a concrete struct of Signer. The code is the base64url encoded
+// payload and its HMAC-SHA256, joined by a dot.
+type HMACSigner struct {
+	secret []byte
+}
+
+// NewHMACSigner is a constructor.
+func NewHMACSigner(secret string) Signer {
+	return &HMACSigner{
+		secret: []byte(secret),
+	}
+}
+
+// Sign implements Signer.
+func (s *HMACSigner) Sign(p Payload) (string, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(s.sum(encodedPayload))
+
+	return encodedPayload + "." + signature, nil
+}
+
+// Verify implements Signer.
+func (s *HMACSigner) Verify(code string) (Payload, error) {
+	p := Payload{}
+
+	encodedPayload, encodedSignature, ok := strings.Cut(code, ".")
+	if !ok {
+		return p, ErrInvalidCode
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sum(encodedPayload)) {
+		return p, ErrInvalidCode
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return p, ErrInvalidCode
+	}
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return p, ErrInvalidCode
+	}
+
+	return p, nil
+}
+
+func (s *HMACSigner) sum(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
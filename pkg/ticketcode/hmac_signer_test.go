@@ -0,0 +1,58 @@
+package ticketcode_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+)
+
+func TestHMACSigner(t *testing.T) {
+	signer := ticketcode.NewHMACSigner("secret")
+	payload := ticketcode.Payload{
+		TicketNumber: "TICKET-1",
+		ShowID:       "SHOW-1",
+		CustomerID:   42,
+	}
+
+	code, err := signer.Sign(payload)
+	assert.NoError(t, err)
+
+	t.Run("verify the signed code", func(t *testing.T) {
+		got, err := signer.Verify(code)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, got)
+	})
+
+	t.Run("reject a code signed with another secret", func(t *testing.T) {
+		_, err := ticketcode.NewHMACSigner("another").Verify(code)
+		assert.ErrorIs(t, err, ticketcode.ErrInvalidCode)
+	})
+
+	t.Run("reject a tampered payload", func(t *testing.T) {
+		forged, err := ticketcode.NewHMACSigner("another").Sign(ticketcode.Payload{TicketNumber: "TICKET-2"})
+		assert.NoError(t, err)
+
+		tampered := forged[:bytes.IndexByte([]byte(forged), '.')] + code[bytes.IndexByte([]byte(code), '.'):]
+		_, err = signer.Verify(tampered)
+		assert.ErrorIs(t, err, ticketcode.ErrInvalidCode)
+	})
+
+	t.Run("reject a malformed code", func(t *testing.T) {
+		_, err := signer.Verify("TICKET-1")
+		assert.ErrorIs(t, err, ticketcode.ErrInvalidCode)
+	})
+}
+
+func TestCodePNG(t *testing.T) {
+	pngSignature := []byte("\x89PNG")
+
+	qrCode, err := ticketcode.QRCodePNG("TICKET-1", 128)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(qrCode, pngSignature))
+
+	barcode, err := ticketcode.Code128PNG("TICKET-1", 40)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(barcode, pngSignature))
+}
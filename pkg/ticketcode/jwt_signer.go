@@ -0,0 +1,72 @@
+package ticketcode
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TicketTokenType is the type claim of a ticket token, it tells the token apart
+// from session tokens signed with the same keys.
+const TicketTokenType = "TICKET"
+
+// TokenSigner is an abstraction of the json web token keys, it is satisfied by
+// `internal/pkg/jwt.JSONWebToken`.
+type TokenSigner interface {
+	Sign(ctx context.Context, claims jwt.Claims) (tokenString string, err error)
+	Parse(ctx context.Context, tokenString string, claims jwt.Claims) (err error)
+}
+
+type ticketClaims struct {
+	jwt.StandardClaims
+	Payload
+	Type string `json:"typ"`
+}
+
+// JWTSigner is a concrete struct of Signer. The code is a json web token signed
+// with the service keys, so it can be verified by anyone holding the public
+// key.
+type JWTSigner struct {
+	issuer string
+	token  TokenSigner
+	now    func() time.Time
+}
+
+// NewJWTSigner is a constructor.
+func NewJWTSigner(issuer string, token TokenSigner) Signer {
+	return &JWTSigner{
+		issuer: issuer,
+		token:  token,
+		now:    time.Now,
+	}
+}
+
+// Sign implements Signer.
+func (s *JWTSigner) Sign(p Payload) (string, error) {
+	claims := ticketClaims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:  p.TicketNumber,
+			Issuer:   s.issuer,
+			IssuedAt: s.now().Unix(),
+		},
+		Payload: p,
+		Type:    TicketTokenType,
+	}
+
+	return s.token.Sign(context.Background(), claims)
+}
+
+// Verify implements Signer.
+func (s *JWTSigner) Verify(code string) (Payload, error) {
+	var claims ticketClaims
+	if err := s.token.Parse(context.Background(), code, &claims); err != nil {
+		return Payload{}, ErrInvalidCode
+	}
+
+	if claims.Type != TicketTokenType || claims.Subject != claims.TicketNumber {
+		return Payload{}, ErrInvalidCode
+	}
+
+	return claims.Payload, nil
+}
@@ -0,0 +1,69 @@
+package ticketcode_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/internal/pkg/jwt"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/ticketcode"
+)
+
+func newJSONWebToken(t *testing.T) *jwt.JSONWebToken {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	return jwt.NewJSONWebToken(
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}),
+	)
+}
+
+func TestJWTSigner(t *testing.T) {
+	jsonWebToken := newJSONWebToken(t)
+	signer := ticketcode.NewJWTSigner("tm-notification", jsonWebToken)
+	payload := ticketcode.Payload{
+		TicketNumber: "TICKET-1",
+		ShowID:       "SHOW-1",
+		CustomerID:   42,
+		EventID:      "EVENT-1",
+		Tier:         "VIP",
+	}
+
+	code, err := signer.Sign(payload)
+	assert.NoError(t, err)
+
+	t.Run("verify the signed code", func(t *testing.T) {
+		got, err := signer.Verify(code)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, got)
+	})
+
+	t.Run("reject a code signed with other keys", func(t *testing.T) {
+		_, err := ticketcode.NewJWTSigner("tm-notification", newJSONWebToken(t)).Verify(code)
+		assert.ErrorIs(t, err, ticketcode.ErrInvalidCode)
+	})
+
+	t.Run("reject a session token signed with the same keys", func(t *testing.T) {
+		sessionToken, err := jsonWebToken.Sign(context.Background(), jwt.Claim{Type: "CUSTOMER"})
+		assert.NoError(t, err)
+
+		_, err = signer.Verify(sessionToken)
+		assert.ErrorIs(t, err, ticketcode.ErrInvalidCode)
+	})
+
+	t.Run("reject a code signed by the hmac signer", func(t *testing.T) {
+		hmacCode, err := ticketcode.NewHMACSigner("secret").Sign(payload)
+		assert.NoError(t, err)
+
+		_, err = signer.Verify(hmacCode)
+		assert.ErrorIs(t, err, ticketcode.ErrInvalidCode)
+	})
+}
@@ -0,0 +1,74 @@
+package ticketcode
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Signers of the ticket code.
+const (
+	SignerHMAC = "hmac"
+	SignerJWT  = "jwt"
+)
+
+// Errors.
+var (
+	ErrInvalidCode = fmt.Errorf("invalid ticket code")
+)
+
+// Payload is the content of the code printed on a ticket.
+type Payload struct {
+	TicketNumber string `json:"tn"`
+	ShowID       string `json:"sid"`
+	CustomerID   int64  `json:"cid"`
+	EventID      string `json:"eid,omitempty"`
+	Tier         string `json:"tier,omitempty"`
+}
+
+// Signer is an abstraction of the ticket code signer, the signed code is what
+// the gate scans and verifies.
+type Signer interface {
+	Sign(p Payload) (code string, err error)
+	Verify(code string) (p Payload, err error)
+}
+
+// QRCodePNG encodes the content as a square QR code image of the given size in
+// pixels.
+func QRCodePNG(content string, size int) ([]byte, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodePNG(code, size, size)
+}
+
+// Code128PNG encodes the content as a Code 128 barcode image of the given
+// height in pixels, the width is the minimum needed by the content.
+func Code128PNG(content string, height int) ([]byte, error) {
+	code, err := code128.Encode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodePNG(code, code.Bounds().Dx()*2, height)
+}
+
+func encodePNG(code barcode.Barcode, width, height int) ([]byte, error) {
+	code, err := barcode.Scale(code, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	buff := new(bytes.Buffer)
+	if err := png.Encode(buff, code); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
@@ -0,0 +1,277 @@
+package wallet
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"sort"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// Colors of the pass, matching the ticket pdf.
+const (
+	passBackgroundColor = "rgb(183, 181, 228)"
+	passForegroundColor = "rgb(47, 79, 79)"
+)
+
+// ApplePassProperty is the property of ApplePassGenerator. The certificates and
+// the private key are PEM encoded.
+type ApplePassProperty struct {
+	PassTypeIdentifier string
+	TeamIdentifier     string
+	OrganizationName   string
+	// Certificate is the pass type certificate issued by Apple.
+	Certificate []byte
+	PrivateKey  []byte
+	// WWDRCertificate is the Apple Worldwide Developer Relations intermediate
+	// certificate which issued Certificate.
+	WWDRCertificate []byte
+	// Icon is the PNG icon of the pass, a plain icon is used when it is empty.
+	Icon []byte
+}
+
+// ApplePassGenerator is a concrete struct of PassGenerator, it builds signed
+// Apple Wallet `.pkpass` bundles.
+type ApplePassGenerator struct {
+	passTypeIdentifier string
+	teamIdentifier     string
+	organizationName   string
+	certificate        *x509.Certificate
+	privateKey         crypto.Signer
+	wwdrCertificate    *x509.Certificate
+	icon               []byte
+}
+
+// NewApplePassGenerator is a constructor.
+func NewApplePassGenerator(props ApplePassProperty) (PassGenerator, error) {
+	certificate, err := parseCertificate(props.Certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := parsePrivateKey(props.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	wwdrCertificate, err := parseCertificate(props.WWDRCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	icon := props.Icon
+	if len(icon) == 0 {
+		icon, err = plainIcon(58)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ApplePassGenerator{
+		passTypeIdentifier: props.PassTypeIdentifier,
+		teamIdentifier:     props.TeamIdentifier,
+		organizationName:   props.OrganizationName,
+		certificate:        certificate,
+		privateKey:         privateKey,
+		wwdrCertificate:    wwdrCertificate,
+		icon:               icon,
+	}, nil
+}
+
+type passField struct {
+	Key       string `json:"key"`
+	Label     string `json:"label,omitempty"`
+	Value     string `json:"value"`
+	DateStyle string `json:"dateStyle,omitempty"`
+	TimeStyle string `json:"timeStyle,omitempty"`
+}
+
+type passBarcode struct {
+	Format          string `json:"format"`
+	Message         string `json:"message"`
+	MessageEncoding string `json:"messageEncoding"`
+	AltText         string `json:"altText,omitempty"`
+}
+
+type passStructure struct {
+	PrimaryFields   []passField `json:"primaryFields"`
+	SecondaryFields []passField `json:"secondaryFields"`
+	AuxiliaryFields []passField `json:"auxiliaryFields"`
+	BackFields      []passField `json:"backFields"`
+}
+
+type pass struct {
+	FormatVersion      int           `json:"formatVersion"`
+	PassTypeIdentifier string        `json:"passTypeIdentifier"`
+	SerialNumber       string        `json:"serialNumber"`
+	TeamIdentifier     string        `json:"teamIdentifier"`
+	OrganizationName   string        `json:"organizationName"`
+	Description        string        `json:"description"`
+	RelevantDate       string        `json:"relevantDate,omitempty"`
+	BackgroundColor    string        `json:"backgroundColor"`
+	ForegroundColor    string        `json:"foregroundColor"`
+	LabelColor         string        `json:"labelColor"`
+	Barcodes           []passBarcode `json:"barcodes"`
+	EventTicket        passStructure `json:"eventTicket"`
+}
+
+// Generate implements PassGenerator. The bundle is a zip of `pass.json`, the
+// icon, `manifest.json` with the SHA-1 of every file, and `signature`, a
+// detached PKCS #7 signature of the manifest.
+func (g *ApplePassGenerator) Generate(t Ticket) ([]byte, error) {
+	passJSON, err := json.Marshal(g.pass(t))
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		"pass.json":   passJSON,
+		"icon.png":    g.icon,
+		"icon@2x.png": g.icon,
+	}
+
+	manifest := make(map[string]string, len(files))
+	for name, content := range files {
+		sum := sha1.Sum(content)
+		manifest[name] = hex.EncodeToString(sum[:])
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	files["manifest.json"] = manifestJSON
+
+	signature, err := g.sign(manifestJSON)
+	if err != nil {
+		return nil, err
+	}
+	files["signature"] = signature
+
+	return zipFiles(files)
+}
+
+func (g *ApplePassGenerator) pass(t Ticket) pass {
+	p := pass{
+		FormatVersion:      1,
+		PassTypeIdentifier: g.passTypeIdentifier,
+		SerialNumber:       t.Number,
+		TeamIdentifier:     g.teamIdentifier,
+		OrganizationName:   g.organizationName,
+		Description:        fmt.Sprintf("Ticket for %s", t.EventName),
+		BackgroundColor:    passBackgroundColor,
+		ForegroundColor:    passForegroundColor,
+		LabelColor:         passForegroundColor,
+		Barcodes: []passBarcode{
+			{
+				Format:          "PKBarcodeFormatQR",
+				Message:         t.Code,
+				MessageEncoding: "iso-8859-1",
+				AltText:         t.Number,
+			},
+		},
+		EventTicket: passStructure{
+			PrimaryFields: []passField{
+				{Key: "event", Label: "EVENT", Value: t.EventName},
+			},
+			SecondaryFields: []passField{
+				{Key: "venue", Label: "VENUE", Value: t.Venue},
+			},
+			AuxiliaryFields: []passField{
+				{Key: "tier", Label: "TIER", Value: t.Tier},
+				{Key: "holder", Label: "NAME", Value: t.CustomerName},
+			},
+			BackFields: []passField{
+				{Key: "number", Label: "Ticket Number", Value: t.Number},
+			},
+		},
+	}
+
+	if !t.ShowTime.IsZero() {
+		showTime := t.ShowTime.Format(time.RFC3339)
+		p.RelevantDate = showTime
+		p.EventTicket.SecondaryFields = append(p.EventTicket.SecondaryFields, passField{
+			Key:       "date",
+			Label:     "DATE",
+			Value:     showTime,
+			DateStyle: "PKDateStyleMedium",
+			TimeStyle: "PKDateStyleShort",
+		})
+	}
+
+	if t.Address != "" {
+		p.EventTicket.BackFields = append(p.EventTicket.BackFields, passField{Key: "address", Label: "Address", Value: t.Address})
+	}
+
+	return p
+}
+
+func (g *ApplePassGenerator) sign(manifest []byte) ([]byte, error) {
+	signedData, err := pkcs7.NewSignedData(manifest)
+	if err != nil {
+		return nil, err
+	}
+	signedData.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+
+	if err := signedData.AddSignerChain(g.certificate, g.privateKey, []*x509.Certificate{g.wwdrCertificate}, pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, err
+	}
+	signedData.Detach()
+
+	return signedData.Finish()
+}
+
+// zipFiles archives the files in name order so the same files give the same
+// archive.
+func zipFiles(files map[string][]byte) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buff := new(bytes.Buffer)
+	zw := zip.NewWriter(buff)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+func plainIcon(size int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	c := color.RGBA{R: 0xB7, G: 0xB5, B: 0xE4, A: 0xFF}
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	buff := new(bytes.Buffer)
+	if err := png.Encode(buff, img); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
@@ -0,0 +1,105 @@
+package wallet_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/wallet"
+	"go.mozilla.org/pkcs7"
+)
+
+func readZip(t *testing.T, b []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	assert.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		r.Close()
+		files[f.Name] = content
+	}
+
+	return files
+}
+
+func TestApplePassGenerator_Generate(t *testing.T) {
+	certs := newTestCertificates(t)
+
+	g, err := wallet.NewApplePassGenerator(wallet.ApplePassProperty{
+		PassTypeIdentifier: "pass.com.tsel-ticketmaster.ticket",
+		TeamIdentifier:     "TEAM123456",
+		OrganizationName:   "TicketMaster",
+		Certificate:        certs.passTypePEM,
+		PrivateKey:         certs.keyPEM,
+		WWDRCertificate:    certs.wwdrPEM,
+	})
+	assert.NoError(t, err)
+
+	pkpass, err := g.Generate(newTicket())
+	assert.NoError(t, err)
+
+	files := readZip(t, pkpass)
+
+	t.Run("describe the ticket in pass.json", func(t *testing.T) {
+		var pass map[string]interface{}
+		assert.NoError(t, json.Unmarshal(files["pass.json"], &pass))
+		assert.Equal(t, "pass.com.tsel-ticketmaster.ticket", pass["passTypeIdentifier"])
+		assert.Equal(t, "TICKET-1", pass["serialNumber"])
+		assert.Equal(t, "2026-10-10T12:30:00Z", pass["relevantDate"])
+
+		barcode := pass["barcodes"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "PKBarcodeFormatQR", barcode["format"])
+		assert.Equal(t, "TICKET-1.signature", barcode["message"])
+	})
+
+	t.Run("list the hash of every file in the manifest", func(t *testing.T) {
+		var manifest map[string]string
+		assert.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+		assert.Len(t, manifest, 3)
+		for name, hash := range manifest {
+			sum := sha1.Sum(files[name])
+			assert.Equal(t, hex.EncodeToString(sum[:]), hash, name)
+		}
+	})
+
+	t.Run("sign the manifest with the pass type certificate", func(t *testing.T) {
+		p7, err := pkcs7.Parse(files["signature"])
+		assert.NoError(t, err)
+		assert.Empty(t, p7.Content, "the signature should be detached")
+
+		p7.Content = files["manifest.json"]
+		truststore := x509.NewCertPool()
+		truststore.AddCert(certs.wwdr)
+		assert.NoError(t, p7.VerifyWithChain(truststore))
+		assert.Equal(t, certs.passType.Raw, p7.GetOnlySigner().Raw)
+	})
+
+	t.Run("detect a tampered manifest", func(t *testing.T) {
+		p7, err := pkcs7.Parse(files["signature"])
+		assert.NoError(t, err)
+
+		p7.Content = bytes.Replace(files["manifest.json"], []byte("pass.json"), []byte("pass.jsn"), 1)
+		assert.Error(t, p7.Verify())
+	})
+}
+
+func TestNewApplePassGenerator_InvalidCertificate(t *testing.T) {
+	certs := newTestCertificates(t)
+
+	_, err := wallet.NewApplePassGenerator(wallet.ApplePassProperty{
+		Certificate:     []byte("not a certificate"),
+		PrivateKey:      certs.keyPEM,
+		WWDRCertificate: certs.wwdrPEM,
+	})
+	assert.ErrorIs(t, err, wallet.ErrInvalidCertificate)
+}
@@ -0,0 +1,198 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// GoogleWalletSaveURL is the base of Google Wallet save links.
+const GoogleWalletSaveURL = "https://pay.google.com/gp/v/save/"
+
+var googleWalletInvalidIDChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// GoogleWalletProperty is the property of GoogleWalletLinkGenerator.
+type GoogleWalletProperty struct {
+	IssuerID   string
+	IssuerName string
+	// ServiceAccount is the JSON key of the service account allowed to issue
+	// passes for the issuer.
+	ServiceAccount []byte
+	// Origins are the domains allowed to show the save button.
+	Origins []string
+}
+
+// GoogleWalletLinkGenerator is a concrete struct of SaveLinkGenerator, it signs
+// Google Wallet save links of event tickets. The event ticket class of each
+// event is created by the link as well.
+type GoogleWalletLinkGenerator struct {
+	issuerID     string
+	issuerName   string
+	clientEmail  string
+	privateKeyID string
+	privateKey   interface{}
+	origins      []string
+	now          func() time.Time
+}
+
+// NewGoogleWalletLinkGenerator is a constructor.
+func NewGoogleWalletLinkGenerator(props GoogleWalletProperty) (SaveLinkGenerator, error) {
+	var serviceAccount struct {
+		ClientEmail  string `json:"client_email"`
+		PrivateKeyID string `json:"private_key_id"`
+		PrivateKey   string `json:"private_key"`
+	}
+	if err := json.Unmarshal(props.ServiceAccount, &serviceAccount); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPrivateKey, err)
+	}
+
+	privateKey, err := parsePrivateKey([]byte(serviceAccount.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoogleWalletLinkGenerator{
+		issuerID:     props.IssuerID,
+		issuerName:   props.IssuerName,
+		clientEmail:  serviceAccount.ClientEmail,
+		privateKeyID: serviceAccount.PrivateKeyID,
+		privateKey:   privateKey,
+		origins:      props.Origins,
+		now:          time.Now,
+	}, nil
+}
+
+type localizedString struct {
+	DefaultValue translatedString `json:"defaultValue"`
+}
+
+type translatedString struct {
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+type eventTicketClass struct {
+	ID           string          `json:"id"`
+	IssuerName   string          `json:"issuerName"`
+	EventName    localizedString `json:"eventName"`
+	ReviewStatus string          `json:"reviewStatus"`
+	Venue        *eventVenue     `json:"venue,omitempty"`
+	DateTime     *eventDateTime  `json:"dateTime,omitempty"`
+}
+
+type eventVenue struct {
+	Name    localizedString `json:"name"`
+	Address localizedString `json:"address"`
+}
+
+type eventDateTime struct {
+	Start string `json:"start"`
+}
+
+type eventTicketObject struct {
+	ID               string          `json:"id"`
+	ClassID          string          `json:"classId"`
+	State            string          `json:"state"`
+	TicketHolderName string          `json:"ticketHolderName"`
+	TicketNumber     string          `json:"ticketNumber"`
+	TicketType       localizedString `json:"ticketType"`
+	Barcode          walletBarcode   `json:"barcode"`
+}
+
+type walletBarcode struct {
+	Type          string `json:"type"`
+	Value         string `json:"value"`
+	AlternateText string `json:"alternateText"`
+}
+
+type saveLinkClaims struct {
+	jwt.RegisteredClaims
+	Type    string          `json:"typ"`
+	Origins []string        `json:"origins"`
+	Payload saveLinkPayload `json:"payload"`
+}
+
+type saveLinkPayload struct {
+	EventTicketClasses []eventTicketClass  `json:"eventTicketClasses"`
+	EventTicketObjects []eventTicketObject `json:"eventTicketObjects"`
+}
+
+// SaveLink implements SaveLinkGenerator.
+func (g *GoogleWalletLinkGenerator) SaveLink(t Ticket) (string, error) {
+	class := eventTicketClass{
+		ID:           g.id(t.EventID),
+		IssuerName:   g.issuerName,
+		EventName:    localize(t.EventName),
+		ReviewStatus: "UNDER_REVIEW",
+	}
+	if t.Venue != "" {
+		class.Venue = &eventVenue{
+			Name:    localize(t.Venue),
+			Address: localize(t.Address),
+		}
+	}
+	if !t.ShowTime.IsZero() {
+		class.DateTime = &eventDateTime{Start: t.ShowTime.Format(time.RFC3339)}
+	}
+
+	object := eventTicketObject{
+		ID:               g.id(t.Number),
+		ClassID:          class.ID,
+		State:            "ACTIVE",
+		TicketHolderName: t.CustomerName,
+		TicketNumber:     t.Number,
+		TicketType:       localize(t.Tier),
+		Barcode: walletBarcode{
+			Type:          "QR_CODE",
+			Value:         t.Code,
+			AlternateText: t.Number,
+		},
+	}
+
+	claims := saveLinkClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   g.clientEmail,
+			Audience: jwt.ClaimStrings{"google"},
+			IssuedAt: jwt.NewNumericDate(g.now()),
+		},
+		Type:    "savetowallet",
+		Origins: g.origins,
+		Payload: saveLinkPayload{
+			EventTicketClasses: []eventTicketClass{class},
+			EventTicketObjects: []eventTicketObject{object},
+		},
+	}
+	if claims.Origins == nil {
+		claims.Origins = []string{}
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if g.privateKeyID != "" {
+		token.Header["kid"] = g.privateKeyID
+	}
+
+	signed, err := token.SignedString(g.privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return GoogleWalletSaveURL + signed, nil
+}
+
+// id builds a Google Wallet resource id, which is prefixed by the issuer id and
+// allows only alphanumeric, `.`, `_` and `-` characters.
+func (g *GoogleWalletLinkGenerator) id(suffix string) string {
+	return fmt.Sprintf("%s.%s", g.issuerID, googleWalletInvalidIDChars.ReplaceAllString(suffix, "_"))
+}
+
+func localize(value string) localizedString {
+	return localizedString{
+		DefaultValue: translatedString{
+			Language: "en-US",
+			Value:    value,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package wallet_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/wallet"
+)
+
+func TestGoogleWalletLinkGenerator_SaveLink(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	serviceAccount, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "wallet@tsel-ticketmaster.iam.gserviceaccount.com",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey})),
+	})
+	assert.NoError(t, err)
+
+	g, err := wallet.NewGoogleWalletLinkGenerator(wallet.GoogleWalletProperty{
+		IssuerID:       "3388000000000000000",
+		IssuerName:     "TicketMaster",
+		ServiceAccount: serviceAccount,
+	})
+	assert.NoError(t, err)
+
+	ticket := newTicket()
+	ticket.Number = "TICKET/1"
+
+	link, err := g.SaveLink(ticket)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(link, wallet.GoogleWalletSaveURL))
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(strings.TrimPrefix(link, wallet.GoogleWalletSaveURL), claims, func(token *jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "RS256", token.Method.Alg())
+	assert.Equal(t, "key-1", token.Header["kid"])
+
+	assert.Equal(t, "wallet@tsel-ticketmaster.iam.gserviceaccount.com", claims["iss"])
+	assert.Equal(t, "savetowallet", claims["typ"])
+	assert.True(t, claims.VerifyAudience("google", true))
+
+	payload := claims["payload"].(map[string]interface{})
+	class := payload["eventTicketClasses"].([]interface{})[0].(map[string]interface{})
+	object := payload["eventTicketObjects"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "3388000000000000000.EVENT-1", class["id"])
+	assert.Equal(t, "3388000000000000000.TICKET_1", object["id"])
+	assert.Equal(t, class["id"], object["classId"])
+	assert.Equal(t, "TICKET-1.signature", object["barcode"].(map[string]interface{})["value"])
+}
@@ -0,0 +1,81 @@
+package wallet
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// Errors.
+var (
+	ErrInvalidCertificate = fmt.Errorf("wallet: invalid certificate")
+	ErrInvalidPrivateKey  = fmt.Errorf("wallet: invalid private key")
+)
+
+// Ticket is the ticket shown on a wallet pass.
+type Ticket struct {
+	Number       string
+	Code         string
+	CustomerName string
+	EventID      string
+	EventName    string
+	Venue        string
+	Address      string
+	Tier         string
+	ShowTime     time.Time
+}
+
+// PassGenerator is an abstraction of a generator of wallet pass files, e.g. an
+// Apple Wallet `.pkpass` bundle.
+type PassGenerator interface {
+	Generate(t Ticket) (pass []byte, err error)
+}
+
+// SaveLinkGenerator is an abstraction of a generator of links that save the
+// ticket to a wallet, e.g. a Google Wallet save link.
+type SaveLinkGenerator interface {
+	SaveLink(t Ticket) (link string, err error)
+}
+
+func parseCertificate(pemBytes []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, ErrInvalidCertificate
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCertificate, err)
+	}
+
+	return cert, nil
+}
+
+// parsePrivateKey parses a PKCS #1, PKCS #8 or EC private key.
+func parsePrivateKey(pemBytes []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPrivateKey, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return signer, nil
+}
@@ -0,0 +1,82 @@
+package wallet_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/wallet"
+)
+
+// testCertificates is a test stand-in of the Apple WWDR intermediate and a pass
+// type certificate issued by it.
+type testCertificates struct {
+	wwdr        *x509.Certificate
+	wwdrPEM     []byte
+	passType    *x509.Certificate
+	passTypePEM []byte
+	keyPEM      []byte
+}
+
+func newTestCertificates(t *testing.T) testCertificates {
+	wwdrKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	wwdrTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Worldwide Developer Relations"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	wwdrDER, err := x509.CreateCertificate(rand.Reader, wwdrTemplate, wwdrTemplate, &wwdrKey.PublicKey, wwdrKey)
+	assert.NoError(t, err)
+	wwdr, err := x509.ParseCertificate(wwdrDER)
+	assert.NoError(t, err)
+
+	passTypeKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	passTypeTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Pass Type ID: pass.com.tsel-ticketmaster.ticket"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	passTypeDER, err := x509.CreateCertificate(rand.Reader, passTypeTemplate, wwdr, &passTypeKey.PublicKey, wwdrKey)
+	assert.NoError(t, err)
+	passType, err := x509.ParseCertificate(passTypeDER)
+	assert.NoError(t, err)
+
+	return testCertificates{
+		wwdr:        wwdr,
+		wwdrPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: wwdrDER}),
+		passType:    passType,
+		passTypePEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: passTypeDER}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(passTypeKey)}),
+	}
+}
+
+func newTicket() wallet.Ticket {
+	return wallet.Ticket{
+		Number:       "TICKET-1",
+		Code:         "TICKET-1.signature",
+		CustomerName: "John Doe",
+		EventID:      "EVENT-1",
+		EventName:    "Concert",
+		Venue:        "GBK",
+		Address:      "Jl. Pintu Satu Senayan, Jakarta",
+		Tier:         "VIP",
+		ShowTime:     time.Date(2026, time.October, 10, 12, 30, 0, 0, time.UTC),
+	}
+}
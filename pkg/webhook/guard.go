@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// Guard error
+var (
+	ErrInsecureURL      = fmt.Errorf("Webhook: The endpoint url must be https")
+	ErrForbiddenAddress = fmt.Errorf("Webhook: The endpoint address is not public")
+)
+
+// deniedPrefixes are the networks an endpoint can not be in, so a registered
+// url can not reach the internal services, e.g. the cloud metadata server.
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// IsPublicAddr tells whether the address is outside of the private, loopback,
+// link-local and other reserved networks.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return addr.IsValid()
+}
+
+// ValidateURL checks that the endpoint url is https and does not name a
+// private host. A host name is only resolved when it is dialed, see
+// DialControl.
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("Webhook: Invalid endpoint url")
+	}
+	if u.Scheme != "https" {
+		return ErrInsecureURL
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// DialControl refuses to connect to a non public address. It is the Control of
+// a net.Dialer so the address is checked after the host is resolved, a host
+// resolving to a private address is refused as well.
+func DialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !IsPublicAddr(addr) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package webhook_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	for _, addr := range []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.True(t, webhook.IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+
+	for _, addr := range []string{
+		"127.0.0.1", "10.0.0.5", "172.16.3.4", "192.168.1.1", "169.254.169.254",
+		"100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1",
+	} {
+		assert.False(t, webhook.IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	assert.NoError(t, webhook.ValidateURL("https://organizer.example.com/hooks"))
+	assert.NoError(t, webhook.ValidateURL("https://93.184.216.34/hooks"))
+
+	assert.ErrorIs(t, webhook.ValidateURL("http://organizer.example.com/hooks"), webhook.ErrInsecureURL)
+	assert.ErrorIs(t, webhook.ValidateURL("https://localhost:8080/hooks"), webhook.ErrForbiddenAddress)
+	assert.ErrorIs(t, webhook.ValidateURL("https://169.254.169.254/latest/meta-data"), webhook.ErrForbiddenAddress)
+	assert.ErrorIs(t, webhook.ValidateURL("https://[::1]/hooks"), webhook.ErrForbiddenAddress)
+	assert.Error(t, webhook.ValidateURL("organizer.example.com/hooks"))
+}
+
+func TestDialControl(t *testing.T) {
+	assert.NoError(t, webhook.DialControl("tcp4", "93.184.216.34:443", nil))
+	assert.ErrorIs(t, webhook.DialControl("tcp4", "10.1.2.3:443", nil), webhook.ErrForbiddenAddress)
+	assert.ErrorIs(t, webhook.DialControl("tcp6", "[::1]:443", nil), webhook.ErrForbiddenAddress)
+}
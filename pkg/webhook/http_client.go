@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// maxResponseBodySize limits the response body kept in the delivery log.
+const maxResponseBodySize = 1024
+
+// Request is a webhook to deliver.
+type Request struct {
+	// ID identifies the delivery, it is the same across its attempts so the
+	// receiving end can deduplicate.
+	ID     string
+	Event  string
+	URL    string
+	Secret string
+	Body   []byte
+}
+
+// Result is the response of the endpoint.
+type Result struct {
+	StatusCode int
+	// Body is the beginning of the response body.
+	Body     string
+	Duration time.Duration
+}
+
+// Client is collection of behavior of webhook client.
+type Client interface {
+	// Deliver POSTs the signed request, the request fails on any non 2xx
+	// response.
+	Deliver(ctx context.Context, r Request) (Result, error)
+}
+
+// HTTPClientProperty is the property of the HTTP client.
+type HTTPClientProperty struct {
+	Logger *logrus.Logger
+	// Client is the HTTP client, a client with Timeout which only connects to
+	// public addresses is used when it is nil. The redirects are never
+	// followed.
+	Client *http.Client
+	// Timeout is the timeout of the requests unless Client has one,
+	// DefaultTimeout is used when it is zero.
+	Timeout   time.Duration
+	UserAgent string
+}
+
+type httpClient struct {
+	logger    *logrus.Logger
+	client    *http.Client
+	userAgent string
+	now       func() time.Time
+}
+
+// NewHTTPClient is a constructor.
+func NewHTTPClient(props HTTPClientProperty) Client {
+	logger := props.Logger
+	if logger == nil {
+		logger = logrus.New()
+	}
+
+	timeout := props.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	var client http.Client
+	if props.Client != nil {
+		client = *props.Client
+	} else {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		// a proxy would connect to the address on behalf of the dialer.
+		transport.Proxy = nil
+		transport.DialContext = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   DialControl,
+		}).DialContext
+
+		client = http.Client{Transport: transport}
+	}
+	// the endpoint is not trusted to answer, a request never waits forever.
+	if client.Timeout <= 0 {
+		client.Timeout = timeout
+	}
+	// a redirect would reach an address the endpoint url is not checked for.
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &httpClient{
+		logger:    logger,
+		client:    &client,
+		userAgent: props.UserAgent,
+		now:       time.Now,
+	}
+}
+
+// Deliver implements Client.
+func (c *httpClient) Deliver(ctx context.Context, r Request) (Result, error) {
+	tp := otel.GetTracerProvider()
+	t := tp.Tracer("webhook")
+	ctx, span := t.Start(ctx, "deliver")
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return Result{}, err
+	}
+
+	now := c.now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, r.ID)
+	req.Header.Set(EventHeader, r.Event)
+	req.Header.Set(TimestampHeader, fmt.Sprint(now.Unix()))
+	req.Header.Set(SignatureHeader, Sign(r.Secret, now, r.Body))
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return Result{Duration: time.Since(now)}, err
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBodySize))
+	result := Result{
+		StatusCode: res.StatusCode,
+		Body:       string(body),
+		Duration:   time.Since(now),
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return result, fmt.Errorf("Webhook: endpoint responded %d", res.StatusCode)
+	}
+
+	return result, nil
+}
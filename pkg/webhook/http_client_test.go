@@ -0,0 +1,128 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
+)
+
+func TestHTTPClientDeliver(t *testing.T) {
+	body := []byte(`{"id":"7","event":"ticket.acquired","data":{"ticket_number":"TICKET-1"}}`)
+
+	t.Run("post the signed payload", func(t *testing.T) {
+		var received *http.Request
+		var receivedBody []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+			w.Write([]byte("ok"))
+		}))
+		defer srv.Close()
+
+		c := webhook.NewHTTPClient(webhook.HTTPClientProperty{Client: srv.Client(), UserAgent: "TicketMaster-Webhook/1.0"})
+		result, err := c.Deliver(context.Background(), webhook.Request{
+			ID:     "7",
+			Event:  webhook.EventTicketAcquired,
+			URL:    srv.URL + "/hooks",
+			Secret: "whsec_secret",
+			Body:   body,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "ok", result.Body)
+
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.Equal(t, "/hooks", received.URL.Path)
+		assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+		assert.Equal(t, "TicketMaster-Webhook/1.0", received.Header.Get("User-Agent"))
+		assert.Equal(t, "7", received.Header.Get(webhook.IDHeader))
+		assert.Equal(t, webhook.EventTicketAcquired, received.Header.Get(webhook.EventHeader))
+		assert.Equal(t, body, receivedBody)
+		assert.NoError(t, webhook.Verify(
+			"whsec_secret",
+			received.Header.Get(webhook.TimestampHeader),
+			receivedBody,
+			received.Header.Get(webhook.SignatureHeader),
+			time.Minute,
+			time.Now(),
+		))
+	})
+
+	t.Run("fail on a non 2xx response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("maintenance"))
+		}))
+		defer srv.Close()
+
+		c := webhook.NewHTTPClient(webhook.HTTPClientProperty{Client: srv.Client()})
+		result, err := c.Deliver(context.Background(), webhook.Request{URL: srv.URL, Body: body})
+		assert.EqualError(t, err, "Webhook: endpoint responded 503")
+		assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+		assert.Equal(t, "maintenance", result.Body)
+	})
+
+	t.Run("fail when the endpoint is unreachable", func(t *testing.T) {
+		srv := httptest.NewServer(http.NotFoundHandler())
+		srv.Close()
+
+		c := webhook.NewHTTPClient(webhook.HTTPClientProperty{Client: &http.Client{Timeout: time.Second}})
+		result, err := c.Deliver(context.Background(), webhook.Request{URL: srv.URL, Body: body})
+		assert.Error(t, err)
+		assert.Zero(t, result.StatusCode)
+	})
+
+	t.Run("do not follow a redirect", func(t *testing.T) {
+		redirected := false
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/internal" {
+				redirected = true
+				return
+			}
+			w.Header().Set("Location", "/internal")
+			w.WriteHeader(http.StatusFound)
+		}))
+		defer srv.Close()
+
+		c := webhook.NewHTTPClient(webhook.HTTPClientProperty{Client: srv.Client()})
+		result, err := c.Deliver(context.Background(), webhook.Request{URL: srv.URL + "/hooks", Body: body})
+		assert.EqualError(t, err, "Webhook: endpoint responded 302")
+		assert.Equal(t, http.StatusFound, result.StatusCode)
+		assert.False(t, redirected)
+	})
+
+	t.Run("refuse to connect to a private address", func(t *testing.T) {
+		called := false
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer srv.Close()
+
+		c := webhook.NewHTTPClient(webhook.HTTPClientProperty{Timeout: time.Second})
+		_, err := c.Deliver(context.Background(), webhook.Request{URL: srv.URL, Body: body})
+		assert.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+		assert.False(t, called)
+	})
+
+	t.Run("give up on an endpoint which does not answer", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer srv.Close()
+		defer close(release)
+
+		// the client of the test server has no timeout of its own.
+		c := webhook.NewHTTPClient(webhook.HTTPClientProperty{Client: srv.Client(), Timeout: 50 * time.Millisecond})
+		start := time.Now()
+		_, err := c.Deliver(context.Background(), webhook.Request{URL: srv.URL, Body: body})
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -0,0 +1,108 @@
+// Package webhook delivers signed JSON payloads to the endpoints registered by
+// partners, e.g. event organizers.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the timeout of the requests to the endpoints when no timeout is
+// configured, a request never waits forever.
+const DefaultTimeout = 10 * time.Second
+
+// Event of a webhook payload.
+const (
+	EventTicketAcquired = "ticket.acquired"
+)
+
+// Header of a webhook request.
+const (
+	IDHeader        = "X-TM-Webhook-ID"
+	EventHeader     = "X-TM-Webhook-Event"
+	TimestampHeader = "X-TM-Webhook-Timestamp"
+	// SignatureHeader carries `sha256=<hex>`, the HMAC-SHA256 of
+	// `<timestamp>.<body>` keyed by the secret of the endpoint.
+	SignatureHeader = "X-TM-Webhook-Signature"
+)
+
+// Webhook error
+var (
+	ErrInvalidSignature = fmt.Errorf("Webhook: Invalid signature")
+	ErrExpiredSignature = fmt.Errorf("Webhook: Signature timestamp is outside of the tolerance")
+)
+
+// Sign returns the signature of the body sent at the timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return "sha256=" + hex.EncodeToString(mac(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// Verify checks the signature and the timestamp headers of a received webhook,
+// a timestamp older or newer than the tolerance is rejected to prevent replays.
+// It is what the receiving end is expected to do.
+func Verify(secret, timestamp string, body []byte, signature string, tolerance time.Duration, now time.Time) error {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !strings.HasPrefix(signature, "sha256=") {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal(mac(secret, timestamp, body), expected) {
+		return ErrInvalidSignature
+	}
+
+	if d := now.Sub(time.Unix(sec, 0)); d > tolerance || d < -tolerance {
+		return ErrExpiredSignature
+	}
+
+	return nil
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+// GenerateSecret returns a random secret of an endpoint.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Backoff returns how long to wait before the next attempt after the given
+// number of failed attempts, doubling from base up to max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	if attempts < 1 {
+		return base
+	}
+
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= max || d <= 0 {
+			return max
+		}
+	}
+
+	if d > max {
+		return max
+	}
+	return d
+}
@@ -0,0 +1,46 @@
+package webhook_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/webhook"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Unix(1791546600, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"id":"1","event":"ticket.acquired"}`)
+	signature := webhook.Sign("whsec_secret", now, body)
+
+	assert.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+	assert.NoError(t, webhook.Verify("whsec_secret", timestamp, body, signature, 5*time.Minute, now.Add(time.Minute)))
+
+	assert.ErrorIs(t, webhook.Verify("whsec_other", timestamp, body, signature, 5*time.Minute, now), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("whsec_secret", timestamp, []byte(`{}`), signature, 5*time.Minute, now), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("whsec_secret", "1791546601", body, signature, 5*time.Minute, now), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("whsec_secret", timestamp, body, "", 5*time.Minute, now), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("whsec_secret", timestamp, body, signature, 5*time.Minute, now.Add(10*time.Minute)), webhook.ErrExpiredSignature)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := webhook.GenerateSecret()
+	assert.NoError(t, err)
+	assert.Regexp(t, "^whsec_[0-9a-f]{64}$", a)
+
+	b, _ := webhook.GenerateSecret()
+	assert.NotEqual(t, a, b)
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 10*time.Second, time.Hour
+
+	assert.Equal(t, 10*time.Second, webhook.Backoff(0, base, max))
+	assert.Equal(t, 10*time.Second, webhook.Backoff(1, base, max))
+	assert.Equal(t, 20*time.Second, webhook.Backoff(2, base, max))
+	assert.Equal(t, 160*time.Second, webhook.Backoff(5, base, max))
+	assert.Equal(t, time.Hour, webhook.Backoff(10, base, max))
+	assert.Equal(t, time.Hour, webhook.Backoff(100, base, max))
+}
@@ -0,0 +1,129 @@
+package whatsapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status of a sent message.
+const (
+	StatusSent      = "sent"
+	StatusDelivered = "delivered"
+	StatusRead      = "read"
+	StatusFailed    = "failed"
+)
+
+// precedingStatuses are the statuses each status moves a message from, the
+// status of a message only advances from sent to delivered to read. A message
+// fails before it is read.
+var precedingStatuses = map[string][]string{
+	StatusDelivered: {StatusSent},
+	StatusRead:      {StatusSent, StatusDelivered},
+	StatusFailed:    {StatusSent, StatusDelivered},
+}
+
+// PrecedingStatuses returns the statuses a message may move to the status
+// from, so an update arriving late does not move the message back. It is empty
+// for an unknown status.
+func PrecedingStatuses(status string) []string {
+	return precedingStatuses[status]
+}
+
+// SignatureHeader is the header carrying the HMAC-SHA256 of the callback body.
+const SignatureHeader = "X-Hub-Signature-256"
+
+// StatusUpdate is the delivery status of a sent message.
+type StatusUpdate struct {
+	MessageID   string
+	RecipientID string
+	Status      string
+	Timestamp   time.Time
+	// Error describes why the message failed.
+	Error string
+}
+
+type callbackPayload struct {
+	Entry []struct {
+		Changes []struct {
+			Value struct {
+				Statuses []struct {
+					ID          string `json:"id"`
+					RecipientID string `json:"recipient_id"`
+					Status      string `json:"status"`
+					Timestamp   string `json:"timestamp"`
+					Errors      []struct {
+						Code    int    `json:"code"`
+						Title   string `json:"title"`
+						Message string `json:"message"`
+					} `json:"errors"`
+				} `json:"statuses"`
+			} `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+// ParseStatusCallback reads the status updates of a callback, the callbacks of
+// incoming messages have none.
+func ParseStatusCallback(body []byte) ([]StatusUpdate, error) {
+	var payload callbackPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	updates := make([]StatusUpdate, 0)
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			for _, s := range change.Value.Statuses {
+				update := StatusUpdate{
+					MessageID:   s.ID,
+					RecipientID: s.RecipientID,
+					Status:      s.Status,
+				}
+				if sec, err := strconv.ParseInt(s.Timestamp, 10, 64); err == nil {
+					update.Timestamp = time.Unix(sec, 0)
+				}
+
+				messages := make([]string, 0, len(s.Errors))
+				for _, e := range s.Errors {
+					message := e.Title
+					if e.Message != "" {
+						message = e.Message
+					}
+					messages = append(messages, strconv.Itoa(e.Code)+": "+message)
+				}
+				update.Error = strings.Join(messages, "; ")
+
+				updates = append(updates, update)
+			}
+		}
+	}
+
+	return updates, nil
+}
+
+// VerifySignature checks the `sha256=<hex>` signature of the callback body
+// signed with the app secret.
+func VerifySignature(secret string, body []byte, signature string) error {
+	if secret == "" {
+		// anyone could sign the body with an empty secret.
+		return ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !strings.HasPrefix(signature, "sha256=") {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
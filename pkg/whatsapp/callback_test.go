@@ -0,0 +1,77 @@
+package whatsapp_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+const statusCallback = `{
+  "object": "whatsapp_business_account",
+  "entry": [{
+    "id": "102290129340398",
+    "changes": [{
+      "field": "messages",
+      "value": {
+        "messaging_product": "whatsapp",
+        "metadata": {"display_phone_number": "15550783881", "phone_number_id": "1234567890"},
+        "statuses": [
+          {"id": "wamid.1", "status": "delivered", "timestamp": "1791546600", "recipient_id": "6281234567890"},
+          {"id": "wamid.2", "status": "failed", "timestamp": "1791546601", "recipient_id": "6281234567890",
+           "errors": [{"code": 131026, "title": "Message undeliverable"}]}
+        ]
+      }
+    }]
+  }]
+}`
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseStatusCallback(t *testing.T) {
+	updates, err := whatsapp.ParseStatusCallback([]byte(statusCallback))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []whatsapp.StatusUpdate{
+		{
+			MessageID:   "wamid.1",
+			RecipientID: "6281234567890",
+			Status:      whatsapp.StatusDelivered,
+			Timestamp:   time.Unix(1791546600, 0),
+		},
+		{
+			MessageID:   "wamid.2",
+			RecipientID: "6281234567890",
+			Status:      whatsapp.StatusFailed,
+			Timestamp:   time.Unix(1791546601, 0),
+			Error:       "131026: Message undeliverable",
+		},
+	}, updates)
+
+	_, err = whatsapp.ParseStatusCallback([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestVerifySignature(t *testing.T) {
+	assert.NoError(t, whatsapp.VerifySignature("app-secret", []byte(statusCallback), sign("app-secret", statusCallback)))
+	assert.ErrorIs(t, whatsapp.VerifySignature("app-secret", []byte(statusCallback), sign("other-secret", statusCallback)), whatsapp.ErrInvalidSignature)
+	assert.ErrorIs(t, whatsapp.VerifySignature("app-secret", []byte(statusCallback+" "), sign("app-secret", statusCallback)), whatsapp.ErrInvalidSignature)
+	assert.ErrorIs(t, whatsapp.VerifySignature("app-secret", []byte(statusCallback), ""), whatsapp.ErrInvalidSignature)
+	assert.ErrorIs(t, whatsapp.VerifySignature("", []byte(statusCallback), sign("", statusCallback)), whatsapp.ErrInvalidSignature)
+}
+
+func TestPrecedingStatuses(t *testing.T) {
+	assert.Equal(t, []string{whatsapp.StatusSent}, whatsapp.PrecedingStatuses(whatsapp.StatusDelivered))
+	assert.Equal(t, []string{whatsapp.StatusSent, whatsapp.StatusDelivered}, whatsapp.PrecedingStatuses(whatsapp.StatusRead))
+	assert.NotContains(t, whatsapp.PrecedingStatuses(whatsapp.StatusFailed), whatsapp.StatusRead)
+	assert.Empty(t, whatsapp.PrecedingStatuses(whatsapp.StatusSent))
+	assert.Empty(t, whatsapp.PrecedingStatuses("deleted"))
+}
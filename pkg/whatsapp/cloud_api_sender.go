@@ -0,0 +1,190 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+)
+
+// CloudAPIEndpoint is the base URL of the WhatsApp Business Cloud API.
+const CloudAPIEndpoint = "https://graph.facebook.com/v19.0"
+
+// CloudAPISenderProperty is the configuration of the business API.
+type CloudAPISenderProperty struct {
+	Logger *logrus.Logger
+	// Endpoint is the versioned base URL, CloudAPIEndpoint is used when it is
+	// empty.
+	Endpoint      string
+	PhoneNumberID string
+	AccessToken   string
+	Client        *http.Client
+	// Timeout is the timeout of the requests when Client is nil,
+	// DefaultTimeout is used when it is zero.
+	Timeout time.Duration
+}
+
+// CloudAPISender sends the messages through the WhatsApp Business Cloud API,
+// `POST {endpoint}/{phone number id}/messages`.
+type CloudAPISender struct {
+	url         string
+	accessToken string
+	client      *http.Client
+}
+
+type cloudAPIRequest struct {
+	MessagingProduct string            `json:"messaging_product"`
+	To               string            `json:"to"`
+	Type             string            `json:"type"`
+	Template         *cloudAPITemplate `json:"template,omitempty"`
+	Document         *cloudAPIDocument `json:"document,omitempty"`
+}
+
+type cloudAPITemplate struct {
+	Name       string              `json:"name"`
+	Language   cloudAPILanguage    `json:"language"`
+	Components []cloudAPIComponent `json:"components,omitempty"`
+}
+
+type cloudAPILanguage struct {
+	Code string `json:"code"`
+}
+
+type cloudAPIComponent struct {
+	Type       string              `json:"type"`
+	Parameters []cloudAPIParameter `json:"parameters"`
+}
+
+type cloudAPIParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type cloudAPIDocument struct {
+	Link     string `json:"link"`
+	Filename string `json:"filename,omitempty"`
+	Caption  string `json:"caption,omitempty"`
+}
+
+type cloudAPIResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+	Error *struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+// NewCloudAPISender is a constructor. A sender logging the messages is returned
+// when it is not active.
+func NewCloudAPISender(props CloudAPISenderProperty, active bool) Sender {
+	if !active {
+		logger := props.Logger
+		if logger == nil {
+			logger = logrus.New()
+		}
+		return &unimplementSender{logger: logger}
+	}
+
+	endpoint := props.Endpoint
+	if endpoint == "" {
+		endpoint = CloudAPIEndpoint
+	}
+
+	client := props.Client
+	if client == nil {
+		timeout := props.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &CloudAPISender{
+		url:         fmt.Sprintf("%s/%s/messages", strings.TrimSuffix(endpoint, "/"), props.PhoneNumberID),
+		accessToken: props.AccessToken,
+		client:      client,
+	}
+}
+
+// Send implements Sender.
+func (s *CloudAPISender) Send(ctx context.Context, m Message) (id string, err error) {
+	tp := otel.GetTracerProvider()
+	t := tp.Tracer("whatsapp")
+	ctx, span := t.Start(ctx, "send")
+	defer span.End()
+
+	if (m.Template == nil) == (m.Document == nil) {
+		return "", ErrInvalidMessage
+	}
+
+	req := cloudAPIRequest{
+		MessagingProduct: "whatsapp",
+		// the api takes the number without the plus sign.
+		To:   strings.TrimPrefix(m.To, "+"),
+		Type: m.Type(),
+	}
+	if m.Template != nil {
+		template := &cloudAPITemplate{
+			Name:     m.Template.Name,
+			Language: cloudAPILanguage{Code: m.Template.Language},
+		}
+		if len(m.Template.Parameters) > 0 {
+			parameters := make([]cloudAPIParameter, len(m.Template.Parameters))
+			for i, p := range m.Template.Parameters {
+				parameters[i] = cloudAPIParameter{Type: "text", Text: p}
+			}
+			template.Components = []cloudAPIComponent{{Type: "body", Parameters: parameters}}
+		}
+		req.Template = template
+	} else {
+		req.Document = &cloudAPIDocument{
+			Link:     m.Document.Link,
+			Filename: m.Document.Filename,
+			Caption:  m.Document.Caption,
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+s.accessToken)
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	resBody, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	var apiRes cloudAPIResponse
+	json.Unmarshal(resBody, &apiRes)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		if apiRes.Error != nil {
+			return "", fmt.Errorf("WhatsApp: API responded %d: %s (%d)", res.StatusCode, apiRes.Error.Message, apiRes.Error.Code)
+		}
+		return "", fmt.Errorf("WhatsApp: API responded %d: %s", res.StatusCode, bytes.TrimSpace(resBody))
+	}
+
+	if len(apiRes.Messages) == 0 {
+		return "", fmt.Errorf("WhatsApp: API responded without message id")
+	}
+
+	return apiRes.Messages[0].ID, nil
+}
@@ -0,0 +1,140 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-notification/pkg/whatsapp"
+)
+
+// fakeCloudAPI is a local stand-in of the business API recording the messages.
+type fakeCloudAPI struct {
+	mu       sync.Mutex
+	requests []map[string]interface{}
+}
+
+func (f *fakeCloudAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v19.0/1234567890/messages" || r.Header.Get("Authorization") != "Bearer access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"message":"Invalid OAuth access token.","code":190}}`))
+		return
+	}
+
+	var body map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&body)
+
+	f.mu.Lock()
+	f.requests = append(f.requests, body)
+	id := fmt.Sprintf("wamid.%d", len(f.requests))
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"messaging_product":"whatsapp","contacts":[{"input":"%s","wa_id":"%s"}],"messages":[{"id":"%s"}]}`, body["to"], body["to"], id)
+}
+
+func newCloudAPISender(t *testing.T, accessToken string) (whatsapp.Sender, *fakeCloudAPI) {
+	api := &fakeCloudAPI{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	return whatsapp.NewCloudAPISender(whatsapp.CloudAPISenderProperty{
+		Endpoint:      srv.URL + "/v19.0",
+		PhoneNumberID: "1234567890",
+		AccessToken:   accessToken,
+	}, true), api
+}
+
+func TestCloudAPISenderSend(t *testing.T) {
+	t.Run("send a template message with its body parameters", func(t *testing.T) {
+		s, api := newCloudAPISender(t, "access-token")
+
+		id, err := s.Send(context.Background(), whatsapp.Message{
+			To: "+6281234567890",
+			Template: &whatsapp.Template{
+				Name:       "ticket_confirmation",
+				Language:   "id",
+				Parameters: []string{"John Doe", "Concert"},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "wamid.1", id)
+
+		assert.Equal(t, map[string]interface{}{
+			"messaging_product": "whatsapp",
+			"to":                "6281234567890",
+			"type":              "template",
+			"template": map[string]interface{}{
+				"name":     "ticket_confirmation",
+				"language": map[string]interface{}{"code": "id"},
+				"components": []interface{}{
+					map[string]interface{}{
+						"type": "body",
+						"parameters": []interface{}{
+							map[string]interface{}{"type": "text", "text": "John Doe"},
+							map[string]interface{}{"type": "text", "text": "Concert"},
+						},
+					},
+				},
+			},
+		}, api.requests[0])
+	})
+
+	t.Run("send a document by link", func(t *testing.T) {
+		s, api := newCloudAPISender(t, "access-token")
+
+		id, err := s.Send(context.Background(), whatsapp.Message{
+			To: "+6281234567890",
+			Document: &whatsapp.Document{
+				Link:     "https://files.example.com/TICKET-1.pdf?expires=1&signature=abc",
+				Filename: "TICKET-1.pdf",
+				Caption:  "Concert",
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "wamid.1", id)
+
+		assert.Equal(t, map[string]interface{}{
+			"messaging_product": "whatsapp",
+			"to":                "6281234567890",
+			"type":              "document",
+			"document": map[string]interface{}{
+				"link":     "https://files.example.com/TICKET-1.pdf?expires=1&signature=abc",
+				"filename": "TICKET-1.pdf",
+				"caption":  "Concert",
+			},
+		}, api.requests[0])
+	})
+
+	t.Run("return the api error", func(t *testing.T) {
+		s, _ := newCloudAPISender(t, "expired-token")
+
+		_, err := s.Send(context.Background(), whatsapp.Message{To: "+6281234567890", Template: &whatsapp.Template{Name: "hello_world"}})
+		assert.ErrorContains(t, err, "API responded 401: Invalid OAuth access token. (190)")
+	})
+
+	t.Run("reject a message without content", func(t *testing.T) {
+		s, api := newCloudAPISender(t, "access-token")
+
+		_, err := s.Send(context.Background(), whatsapp.Message{To: "+6281234567890"})
+		assert.ErrorIs(t, err, whatsapp.ErrInvalidMessage)
+		assert.Empty(t, api.requests)
+	})
+
+	t.Run("log the message when it is not active", func(t *testing.T) {
+		s := whatsapp.NewCloudAPISender(whatsapp.CloudAPISenderProperty{}, false)
+
+		id, err := s.Send(context.Background(), whatsapp.Message{
+			To:        "+6281234567890",
+			Document:  &whatsapp.Document{Link: "https://files.example.com/TICKET-1.pdf"},
+			Reference: "TICKET-1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "fake.document.TICKET-1", id)
+	})
+}
@@ -0,0 +1,86 @@
+// Package whatsapp sends WhatsApp Business messages and reads their delivery
+// status callbacks.
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTimeout is the timeout of the requests to the Cloud API when no timeout is
+// configured, a request never waits forever.
+const DefaultTimeout = 10 * time.Second
+
+// Type of message.
+const (
+	TypeTemplate = "template"
+	TypeDocument = "document"
+)
+
+// WhatsApp error
+var (
+	ErrInvalidMessage   = fmt.Errorf("WhatsApp: Message must have either a template or a document")
+	ErrInvalidSignature = fmt.Errorf("WhatsApp: Invalid callback signature")
+)
+
+// Template is a pre-approved template message, the parameters fill the
+// `{{1}}`, `{{2}}`, ... variables of its body in order.
+type Template struct {
+	Name       string
+	Language   string
+	Parameters []string
+}
+
+// Document is a file sent by link, e.g. the ticket pdf.
+type Document struct {
+	Link     string
+	Filename string
+	Caption  string
+}
+
+// Message is either a template or a document message.
+type Message struct {
+	// To is the E.164 phone number of the recipient.
+	To       string
+	Template *Template
+	Document *Document
+	// Reference is kept along the sent message by the caller, e.g. the ticket
+	// number, and is not sent.
+	Reference string
+}
+
+// Type returns the type of the message.
+func (m Message) Type() string {
+	if m.Template != nil {
+		return TypeTemplate
+	}
+	return TypeDocument
+}
+
+// Sender is collection of behavior of WhatsApp sender.
+type Sender interface {
+	// Send sends the message and returns its id, the id is referred by the
+	// status callbacks.
+	Send(ctx context.Context, m Message) (id string, err error)
+}
+
+type unimplementSender struct {
+	logger *logrus.Logger
+}
+
+func (us *unimplementSender) Send(ctx context.Context, m Message) (id string, err error) {
+	if (m.Template == nil) == (m.Document == nil) {
+		return "", ErrInvalidMessage
+	}
+
+	us.logger.WithContext(ctx).WithFields(logrus.Fields{
+		"whatsapp.to":        m.To,
+		"whatsapp.type":      m.Type(),
+		"whatsapp.reference": m.Reference,
+	}).Info("fake sending whatsapp message")
+
+	return fmt.Sprintf("fake.%s.%s", m.Type(), m.Reference), nil
+}